@@ -0,0 +1,62 @@
+package reevit
+
+import (
+	"net/http"
+	"time"
+)
+
+// AdaptiveTimeout derives the deadline of each call from the latency recently observed on
+// its endpoint, so that slow but healthy endpoints such as report exports get the time
+// they need while a stalled checkout call still fails fast.
+type AdaptiveTimeout struct {
+	// Multiplier is applied to the endpoint's p99 latency. It defaults to 1.5.
+	Multiplier float64
+	// Floor and Ceiling bound the deadline. They default to 2 and 60 seconds.
+	Floor   time.Duration
+	Ceiling time.Duration
+	// MinSamples is the number of calls an endpoint needs before its statistics are
+	// trusted; until then calls get Ceiling. It defaults to 20.
+	MinSamples int
+}
+
+// WithAdaptiveTimeout sets per-call deadlines at the endpoint's p99 latency times
+// Multiplier, bounded by Floor and Ceiling. WithRequestTimeout still takes precedence for
+// individual calls. The Timeout of the underlying http.Client is disabled so that it does
+// not cut calls short; the Ceiling takes its place.
+func WithAdaptiveTimeout(config AdaptiveTimeout) Option {
+	return func(c *Client) {
+		if config.Multiplier <= 0 {
+			config.Multiplier = 1.5
+		}
+		if config.Floor <= 0 {
+			config.Floor = 2 * time.Second
+		}
+		if config.Ceiling <= 0 {
+			config.Ceiling = time.Minute
+		}
+		if config.Ceiling < config.Floor {
+			config.Ceiling = config.Floor
+		}
+		if config.MinSamples <= 0 {
+			config.MinSamples = 20
+		}
+		c.adaptiveTimeout = &config
+	}
+}
+
+// timeoutFor returns the adaptive deadline for req.
+func (c *Client) timeoutFor(req *http.Request) time.Duration {
+	config := c.adaptiveTimeout
+	stats, ok := c.latency.endpoint(endpointKey(req))
+	if !ok || stats.Requests < uint64(config.MinSamples) {
+		return config.Ceiling
+	}
+	timeout := time.Duration(float64(stats.P99) * config.Multiplier)
+	if timeout < config.Floor {
+		return config.Floor
+	}
+	if timeout > config.Ceiling {
+		return config.Ceiling
+	}
+	return timeout
+}
@@ -0,0 +1,204 @@
+package reevit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAmount is returned by ParseAmount when the input is not a valid amount.
+var ErrInvalidAmount = errors.New("reevit: invalid amount")
+
+type currencyFormat struct {
+	symbol string
+	digits int
+}
+
+var currencyFormats = map[string]currencyFormat{
+	"GHS": {symbol: "₵", digits: 2},
+	"NGN": {symbol: "₦", digits: 2},
+	"KES": {symbol: "KSh", digits: 2},
+	"UGX": {symbol: "USh", digits: 0},
+	"TZS": {symbol: "TSh", digits: 2},
+	"RWF": {symbol: "FRw", digits: 0},
+	"ZAR": {symbol: "R", digits: 2},
+	"XOF": {symbol: "CFA", digits: 0},
+	"XAF": {symbol: "FCFA", digits: 0},
+	"USD": {symbol: "$", digits: 2},
+	"EUR": {symbol: "€", digits: 2},
+	"GBP": {symbol: "£", digits: 2},
+}
+
+type localeFormat struct {
+	group        string
+	decimal      string
+	symbolSuffix bool
+}
+
+var localeFormats = map[string]localeFormat{
+	"en": {group: ",", decimal: "."},
+	"sw": {group: ",", decimal: "."},
+	"fr": {group: "\u202f", decimal: ",", symbolSuffix: true},
+	"pt": {group: ".", decimal: ",", symbolSuffix: true},
+}
+
+// CurrencyDigits returns the number of minor-unit digits for a currency (2 when unknown).
+func CurrencyDigits(currency string) int {
+	if format, ok := currencyFormats[strings.ToUpper(strings.TrimSpace(currency))]; ok {
+		return format.digits
+	}
+	return 2
+}
+
+func lookupLocale(locale string) localeFormat {
+	language := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if format, ok := localeFormats[language]; ok {
+		return format
+	}
+	return localeFormats["en"]
+}
+
+// FormatAmount renders an amount in minor units for display, for example
+// FormatAmount(123450, "GHS", "en-GH") returns "₵1,234.50". Unknown currencies are
+// rendered with their ISO code, and unknown locales fall back to English conventions.
+func FormatAmount(amount int64, currency, locale string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	format, ok := currencyFormats[currency]
+	if !ok {
+		format = currencyFormat{symbol: currency + " ", digits: 2}
+	}
+	loc := lookupLocale(locale)
+
+	negative := amount < 0
+	magnitude := uint64(amount)
+	if negative {
+		magnitude = uint64(-amount)
+	}
+
+	digits := strconv.FormatUint(magnitude, 10)
+	if len(digits) <= format.digits {
+		digits = strings.Repeat("0", format.digits-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-format.digits], digits[len(digits)-format.digits:]
+
+	var grouped strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(loc.group)
+		}
+		grouped.WriteRune(r)
+	}
+	number := grouped.String()
+	if fraction != "" {
+		number += loc.decimal + fraction
+	}
+
+	symbol := strings.TrimSpace(format.symbol)
+	var formatted string
+	if loc.symbolSuffix {
+		formatted = number + "\u00a0" + symbol
+	} else {
+		formatted = format.symbol + number
+	}
+	if negative {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
+
+// ParseAmount parses user input such as "₵1,234.50" or "1 234,50" into minor units
+// using the currency's precision and the locale's separators. Group separators are only
+// accepted between groups of three digits, and input that ends in the other convention's
+// decimal separator, such as "1234.50" for the pt locale, is rejected rather than read as
+// a grouped whole number.
+func ParseAmount(input, currency, locale string) (int64, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	digitsAllowed := CurrencyDigits(currency)
+	loc := lookupLocale(locale)
+
+	cleaned := strings.TrimSpace(input)
+	if format, ok := currencyFormats[currency]; ok {
+		cleaned = strings.ReplaceAll(cleaned, format.symbol, "")
+	}
+	cleaned = strings.TrimSpace(strings.ReplaceAll(cleaned, currency, ""))
+
+	negative := strings.HasPrefix(cleaned, "-")
+	cleaned = strings.TrimSpace(strings.TrimPrefix(cleaned, "-"))
+
+	otherDecimal := ","
+	if loc.decimal == "," {
+		otherDecimal = "."
+	}
+	if i := strings.LastIndex(cleaned, otherDecimal); i >= 0 {
+		if tail := cleaned[i+1:]; len(tail) >= 1 && len(tail) <= digitsAllowed && isDigits(tail) {
+			return 0, fmt.Errorf("%w: %q uses %q as a decimal separator, which the locale writes as %q", ErrInvalidAmount, input, otherDecimal, loc.decimal)
+		}
+	}
+
+	whole, fraction := cleaned, ""
+	if i := strings.LastIndex(cleaned, loc.decimal); i >= 0 {
+		whole, fraction = cleaned[:i], cleaned[i+len(loc.decimal):]
+	}
+	whole, ok := ungroupDigits(whole, loc)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q has misplaced group separators", ErrInvalidAmount, input)
+	}
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, input)
+	}
+	if len(fraction) > digitsAllowed {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places for %s", ErrInvalidAmount, input, digitsAllowed, currency)
+	}
+	fraction += strings.Repeat("0", digitsAllowed-len(fraction))
+
+	combined := whole + fraction
+	for _, r := range combined {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, input)
+		}
+	}
+	value, err := strconv.ParseInt(combined, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, input)
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// ungroupDigits removes the group separators of loc, and spaces, from whole. It reports
+// false when they do not split whole into a leading group of one to three characters
+// followed by groups of exactly three.
+func ungroupDigits(whole string, loc localeFormat) (string, bool) {
+	separators := []string{" ", "\u00a0", "\u202f"}
+	if group := strings.TrimSpace(loc.group); group != "" {
+		separators = append(separators, group)
+	}
+	for _, separator := range separators {
+		whole = strings.ReplaceAll(whole, separator, "\x00")
+	}
+	groups := strings.Split(whole, "\x00")
+	if len(groups) == 1 {
+		return whole, true
+	}
+	for i, group := range groups {
+		if (i == 0 && (len(group) == 0 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
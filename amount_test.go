@@ -0,0 +1,108 @@
+package reevit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "₵1,234.50", FormatAmount(123450, "GHS", "en-GH"))
+	require.Equal(t, "₦1,234.50", FormatAmount(123450, "NGN", "en-NG"))
+	require.Equal(t, "USh1,234", FormatAmount(1234, "UGX", "en"))
+	require.Equal(t, "₵0.05", FormatAmount(5, "ghs", ""))
+	require.Equal(t, "-$10.00", FormatAmount(-1000, "USD", "en-US"))
+	require.Equal(t, "1\u202f234\u00a0CFA", FormatAmount(1234, "XOF", "fr-SN"))
+	require.Equal(t, "1\u202f234,50\u00a0€", FormatAmount(123450, "EUR", "fr-FR"))
+	require.Equal(t, "ABC 1.00", FormatAmount(100, "ABC", "en"))
+}
+
+func TestParseAmount(t *testing.T) {
+	amount, err := ParseAmount("₵1,234.50", "GHS", "en-GH")
+	require.NoError(t, err)
+	require.Equal(t, int64(123450), amount)
+
+	amount, err = ParseAmount("1 234,5", "EUR", "fr")
+	require.NoError(t, err)
+	require.Equal(t, int64(123450), amount)
+
+	amount, err = ParseAmount("NGN 20", "NGN", "en")
+	require.NoError(t, err)
+	require.Equal(t, int64(2000), amount)
+
+	_, err = ParseAmount("12.345", "GHS", "en")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = ParseAmount("1.5", "UGX", "en")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+	_, err = ParseAmount("abc", "GHS", "en")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestParseAmountSeparators(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency string
+		locale   string
+		want     int64
+		wantErr  bool
+	}{
+		{"1.234,50", "EUR", "pt", 123450, false},
+		{"1.234.567", "EUR", "pt", 123456700, false},
+		{"1234,5", "EUR", "pt", 123450, false},
+		{"1.500", "EUR", "pt", 150000, false},
+		{"1234.50", "EUR", "pt", 0, true},
+		{"1.50", "EUR", "pt", 0, true},
+		{"12.34.567", "EUR", "pt", 0, true},
+		{"1.2345,00", "EUR", "pt", 0, true},
+		{"1\u202f234,50", "EUR", "fr", 123450, false},
+		{"1 234 567", "XOF", "fr", 1234567, false},
+		{"1234.50", "EUR", "fr", 0, true},
+		{"1 23,50", "EUR", "fr", 0, true},
+		{"1,234.50", "GHS", "en", 123450, false},
+		{"1,50", "GHS", "en", 0, true},
+		{"12,34,567", "GHS", "en", 0, true},
+		{"1.500", "UGX", "en", 0, true},
+		{"1,500", "UGX", "en", 1500, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale+" "+tt.input, func(t *testing.T) {
+			amount, err := ParseAmount(tt.input, tt.currency, tt.locale)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidAmount)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, amount)
+		})
+	}
+}
+
+func TestMoney(t *testing.T) {
+	price := NewMoney(123450, "ghs")
+	require.Equal(t, "₵1,234.50", price.String())
+	require.Equal(t, "1234.50", price.Decimal())
+	require.Equal(t, "-12", NewMoney(-12, "UGX").Decimal())
+	require.Equal(t, "0.05", NewMoney(5, "USD").Decimal())
+
+	total, err := price.Add(NewMoney(550, "GHS"))
+	require.NoError(t, err)
+	require.Equal(t, Money{Amount: 124000, Currency: "GHS"}, total)
+	_, err = price.Sub(NewMoney(1, "NGN"))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	shares, err := NewMoney(100, "GHS").Allocate(1, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int64{34, 33, 33}, []int64{shares[0].Amount, shares[1].Amount, shares[2].Amount})
+	shares, err = NewMoney(-5, "GHS").Allocate(1, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int64{-3, 0, -2}, []int64{shares[0].Amount, shares[1].Amount, shares[2].Amount})
+
+	parsed, err := ParseMoney("1 234,5", "EUR", "fr")
+	require.NoError(t, err)
+	cmp, err := parsed.Cmp(NewMoney(123450, "EUR"))
+	require.NoError(t, err)
+	require.Zero(t, cmp)
+
+	payment := Payment{Amount: 5000, Currency: "GHS"}
+	require.Equal(t, NewMoney(5000, "GHS"), payment.Money())
+}
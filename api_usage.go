@@ -0,0 +1,53 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// UsageService reports the organization's consumption of the API itself, as opposed to
+// the metered usage of subscriptions recorded with Subscriptions.ReportUsage.
+type UsageService service
+
+// APILimits is the organization's rate-limit tier and its consumption of the daily quota.
+type APILimits struct {
+	// Tier names the rate-limit tier, e.g. "standard" or "enterprise".
+	Tier string `json:"tier"`
+	// RequestsPerSecond and Burst are the sustained rate and burst allowed by the tier.
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+	// DailyLimit is the number of requests allowed per day, zero when unlimited, of
+	// which DailyUsed have been made since ResetsAt minus one day.
+	DailyLimit int64     `json:"daily_limit"`
+	DailyUsed  int64     `json:"daily_used"`
+	ResetsAt   time.Time `json:"resets_at"`
+}
+
+// DailyRemaining returns the requests left in the daily quota, or -1 when it is unlimited.
+func (l *APILimits) DailyRemaining() int64 {
+	if l.DailyLimit <= 0 {
+		return -1
+	}
+	if remaining := l.DailyLimit - l.DailyUsed; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// GetLimits returns the rate-limit tier of the organization and its daily consumption.
+//
+// API Docs: GET /v1/usage/limits
+func (s *UsageService) GetLimits(ctx context.Context, opts ...RequestOption) (*APILimits, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/usage/limits", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits APILimits
+	if err := s.client.do(httpRequest, &limits); err != nil {
+		return nil, err
+	}
+
+	return &limits, nil
+}
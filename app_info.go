@@ -0,0 +1,57 @@
+package reevit
+
+import "strings"
+
+// AppInfo identifies the application or platform integration built on the SDK. Partners
+// set it so that Reevit can attribute traffic and reach out about their integration.
+type AppInfo struct {
+	Name    string
+	Version string
+	URL     string
+}
+
+// String formats the app info as a User-Agent product, e.g.
+// "ShopPlugin/2.1.0 (+https://example.com)".
+func (a AppInfo) String() string {
+	text := a.Name
+	if a.Version != "" {
+		text += "/" + a.Version
+	}
+	if a.URL != "" {
+		text += " (+" + a.URL + ")"
+	}
+	return text
+}
+
+// WithAppInfo identifies the application making requests. It is appended to the
+// User-Agent and sent in the X-Reevit-App-Name, X-Reevit-App-Version and X-Reevit-App-URL
+// headers of every request.
+func WithAppInfo(name, version, url string) Option {
+	return func(c *Client) {
+		c.appInfo = &AppInfo{
+			Name:    strings.TrimSpace(name),
+			Version: strings.TrimSpace(version),
+			URL:     strings.TrimSpace(url),
+		}
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the User-Agent of every request, e.g. to tag the
+// service or deployment the calls come from.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Client) {
+		c.userAgentSuffix = strings.TrimSpace(suffix)
+	}
+}
+
+// userAgentHeader returns the User-Agent sent by the client.
+func (c *Client) userAgentHeader() string {
+	ua := userAgent
+	if c.appInfo != nil && c.appInfo.Name != "" {
+		ua += " " + c.appInfo.String()
+	}
+	if c.userAgentSuffix != "" {
+		ua += " " + c.userAgentSuffix
+	}
+	return ua
+}
@@ -0,0 +1,158 @@
+package reevit
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AvailabilityService handles communication with the method availability endpoint of the Reevit API.
+type AvailabilityService service
+
+//go:embed data/availability.json
+var embeddedAvailability []byte
+
+var (
+	defaultAvailabilityOnce sync.Once
+	defaultAvailability     *Availability
+)
+
+// Availability describes which payment methods and currencies are supported per country.
+type Availability struct {
+	Version   string                         `json:"version"`
+	Countries map[string]CountryAvailability `json:"countries"`
+}
+
+// CountryAvailability maps each supported payment method to its supported currencies.
+type CountryAvailability struct {
+	Methods map[string][]string `json:"methods"`
+}
+
+// DefaultAvailability returns a copy of the availability dataset embedded in the SDK.
+// It can be used to render method pickers without an API call; use
+// AvailabilityService.Current to use the live dataset when the API is reachable.
+func DefaultAvailability() *Availability {
+	return embeddedDefaultAvailability().clone()
+}
+
+func embeddedDefaultAvailability() *Availability {
+	defaultAvailabilityOnce.Do(func() {
+		defaultAvailability = &Availability{}
+		if err := json.Unmarshal(embeddedAvailability, defaultAvailability); err != nil {
+			panic("reevit: embedded availability data is invalid: " + err.Error())
+		}
+	})
+	return defaultAvailability
+}
+
+// IsSupported reports whether the embedded dataset supports the country, method and currency combination.
+func IsSupported(country, method, currency string) bool {
+	return embeddedDefaultAvailability().IsSupported(country, method, currency)
+}
+
+// clone returns a deep copy of a, so that callers editing the result do not change the
+// shared dataset.
+func (a *Availability) clone() *Availability {
+	if a == nil {
+		return nil
+	}
+	cloned := &Availability{Version: a.Version}
+	if a.Countries != nil {
+		cloned.Countries = make(map[string]CountryAvailability, len(a.Countries))
+		for country, countryAvailability := range a.Countries {
+			var methods map[string][]string
+			if countryAvailability.Methods != nil {
+				methods = make(map[string][]string, len(countryAvailability.Methods))
+				for method, currencies := range countryAvailability.Methods {
+					methods[method] = append([]string(nil), currencies...)
+				}
+			}
+			cloned.Countries[country] = CountryAvailability{Methods: methods}
+		}
+	}
+	return cloned
+}
+
+// IsSupported reports whether the country, method and currency combination is supported.
+func (a *Availability) IsSupported(country, method, currency string) bool {
+	if a == nil {
+		return false
+	}
+	countryAvailability, ok := a.Countries[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return false
+	}
+	currencies, ok := countryAvailability.Methods[strings.ToLower(strings.TrimSpace(method))]
+	if !ok {
+		return false
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	for _, supported := range currencies {
+		if supported == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// Methods returns the sorted list of methods supported in a country.
+func (a *Availability) Methods(country string) []string {
+	if a == nil {
+		return nil
+	}
+	countryAvailability, ok := a.Countries[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return nil
+	}
+	methods := make([]string, 0, len(countryAvailability.Methods))
+	for method := range countryAvailability.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Get fetches the live availability dataset.
+//
+// API Docs: GET /v1/availability
+func (s *AvailabilityService) Get(ctx context.Context, opts ...RequestOption) (*Availability, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/availability", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var availability Availability
+	if err := s.client.do(httpRequest, &availability); err != nil {
+		return nil, err
+	}
+
+	return &availability, nil
+}
+
+// Current returns a copy of the live availability dataset, cached for 24 hours like the
+// directory. When the API is unreachable it falls back to the dataset embedded in the SDK,
+// so method pickers keep working offline; an error is only returned when the API rejects
+// the request.
+//
+// API Docs: GET /v1/availability
+func (s *AvailabilityService) Current(ctx context.Context, opts ...RequestOption) (*Availability, error) {
+	const cacheKey = "availability"
+	if cached, ok := s.client.directory.get(cacheKey); ok {
+		return cached.(*Availability).clone(), nil
+	}
+
+	availability, err := s.Get(ctx, opts...)
+	if err != nil {
+		if canUseFallback(err) {
+			return DefaultAvailability(), nil
+		}
+		return nil, err
+	}
+	s.client.directory.set(cacheKey, availability.clone())
+
+	return availability, nil
+}
@@ -0,0 +1,68 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityCurrent(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		require.Equal(t, "/v1/availability", r.URL.Path)
+		_, _ = w.Write([]byte(`{"version":"live","countries":{"GH":{"methods":{"momo":["GHS"],"card":["GHS","USD"]}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	availability, err := client.Availability.Current(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "live", availability.Version)
+	require.True(t, availability.IsSupported("gh", "CARD", "usd"))
+	require.Equal(t, []string{"card", "momo"}, availability.Methods("GH"))
+
+	availability.Countries["GH"].Methods["card"][0] = "changed"
+	availability.Countries["GH"].Methods["ussd"] = []string{"GHS"}
+	availability.Countries["NG"] = CountryAvailability{}
+
+	availability, err = client.Availability.Current(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Equal(t, map[string]CountryAvailability{"GH": {Methods: map[string][]string{"momo": {"GHS"}, "card": {"GHS", "USD"}}}}, availability.Countries)
+}
+
+func TestDefaultAvailabilityReturnsCopies(t *testing.T) {
+	availability := DefaultAvailability()
+	require.True(t, IsSupported("GH", "mobile_money", "GHS"))
+	for country := range availability.Countries {
+		delete(availability.Countries, country)
+	}
+	require.True(t, IsSupported("GH", "mobile_money", "GHS"))
+	require.NotEmpty(t, DefaultAvailability().Countries)
+}
+
+func TestAvailabilityCurrentFallsBackToEmbedded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithMaxRetries(0))
+	availability, err := client.Availability.Current(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, DefaultAvailability(), availability)
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"unauthorized","message":"invalid API key"}`))
+	}))
+	defer rejecting.Close()
+
+	client = NewClient("pfk_test", "org_1", WithBaseURL(rejecting.URL))
+	_, err = client.Availability.Current(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,342 @@
+// Package backfill copies historical Reevit data into a sink of your choice, page by page,
+// persisting its progress so an interrupted run resumes where it stopped.
+//
+//	job := &backfill.Job{
+//		ID:     "warehouse-2024",
+//		Client: client,
+//		From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+//		To:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+//		Sink:   warehouseSink,
+//		State:  backfill.FileStateStore{Path: "backfill-state.json"},
+//	}
+//	err := job.Run(ctx)
+//
+// Delivery is at-least-once: a page written just before an interruption is written again
+// on resume, so sinks should upsert by ID.
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+)
+
+// Resource names a kind of data that can be backfilled.
+type Resource string
+
+// Resources supported by Job.
+const (
+	ResourcePayments    Resource = "payments"
+	ResourceRefunds     Resource = "refunds"
+	ResourceSettlements Resource = "settlements"
+	// ResourceLedgerEntries copies the org's ledger, every credit and debit behind the
+	// balance.
+	ResourceLedgerEntries Resource = "ledger_entries"
+)
+
+const (
+	defaultPageSize       = 100
+	defaultRateLimitWait  = 5 * time.Second
+	maxRateLimitedRetries = 5
+)
+
+// Batch is one page of data handed to a Sink. Exactly one of the slices is populated,
+// matching Resource.
+type Batch struct {
+	Resource      Resource
+	Payments      []reevit.PaymentSummary
+	Refunds       []reevit.Refund
+	Settlements   []reevit.Settlement
+	LedgerEntries []reevit.LedgerEntry
+}
+
+// Sink receives backfilled data. Returning an error stops the run without advancing the
+// saved progress, so the batch is delivered again on the next run.
+type Sink interface {
+	Write(ctx context.Context, batch Batch) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, batch Batch) error
+
+// Write implements Sink.
+func (f SinkFunc) Write(ctx context.Context, batch Batch) error {
+	return f(ctx, batch)
+}
+
+// Job describes a backfill run.
+type Job struct {
+	// ID identifies the run in the StateStore; reuse it to resume.
+	ID     string
+	Client *reevit.Client
+	// From and To bound the creation time of the copied records; zero means unbounded.
+	From time.Time
+	To   time.Time
+	// Resources defaults to payments, refunds, settlements and ledger entries. Refunds are
+	// read per payment, which costs one extra request for every payment in range.
+	Resources []Resource
+	PageSize  int
+	Sink      Sink
+	// State persists progress. When nil, progress is kept in memory only.
+	State StateStore
+	// OnProgress, if set, is called after every page with the number of records copied
+	// for the resource in Stage. For payments, Total and ETA are estimated from the
+	// payment stats of the range, at the cost of one request; settlements and ledger
+	// entries have no total.
+	OnProgress reevit.ProgressFunc
+}
+
+// Run copies every configured resource, resuming from the saved progress. Rate-limited
+// pages are retried after the reset advertised by the API; combine with
+// reevit.WithRateLimit on the client to avoid hitting the limit in the first place.
+func (j *Job) Run(ctx context.Context) error {
+	if j.Client == nil || j.Sink == nil {
+		return errors.New("backfill: Client and Sink are required")
+	}
+	store := j.State
+	if store == nil {
+		store = NewMemoryStateStore()
+	}
+	progress, err := store.Load(ctx, j.ID)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		progress = &Progress{}
+	}
+	save := func() error {
+		progress.UpdatedAt = time.Now().UTC()
+		return store.Save(ctx, j.ID, progress)
+	}
+
+	resources := j.Resources
+	if len(resources) == 0 {
+		resources = []Resource{ResourcePayments, ResourceRefunds, ResourceSettlements, ResourceLedgerEntries}
+	}
+	withPayments, withRefunds, withSettlements, withLedger := false, false, false, false
+	for _, resource := range resources {
+		switch resource {
+		case ResourcePayments:
+			withPayments = true
+		case ResourceRefunds:
+			withRefunds = true
+		case ResourceSettlements:
+			withSettlements = true
+		case ResourceLedgerEntries:
+			withLedger = true
+		default:
+			return fmt.Errorf("backfill: unsupported resource %q", resource)
+		}
+	}
+
+	if (withPayments || withRefunds) && !progress.PaymentsDone {
+		if err := j.runPayments(ctx, progress, withPayments, withRefunds, save); err != nil {
+			return err
+		}
+	}
+	if withSettlements && !progress.SettlementsDone {
+		if err := j.runSettlements(ctx, progress, save); err != nil {
+			return err
+		}
+	}
+	if withLedger && !progress.LedgerDone {
+		if err := j.runLedger(ctx, progress, save); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *Job) pageSize() int {
+	if j.PageSize > 0 {
+		return j.PageSize
+	}
+	return defaultPageSize
+}
+
+func (j *Job) runPayments(ctx context.Context, progress *Progress, withPayments, withRefunds bool, save func() error) error {
+	tracker := reevit.NewProgressTracker(j.countPayments(ctx), int64(progress.PaymentsCopied))
+	for {
+		options := &reevit.PaymentListOptions{
+			Limit:         j.pageSize(),
+			Cursor:        progress.PaymentCursor,
+			CreatedAfter:  j.From,
+			CreatedBefore: j.To,
+		}
+		payments, err := fetch(ctx, func(ctx context.Context) ([]reevit.PaymentSummary, error) {
+			return j.Client.Payments.List(ctx, options)
+		})
+		if err != nil {
+			return err
+		}
+		if len(payments) == 0 {
+			progress.PaymentsDone = true
+			return save()
+		}
+
+		if withPayments {
+			if err := j.Sink.Write(ctx, Batch{Resource: ResourcePayments, Payments: payments}); err != nil {
+				return err
+			}
+		}
+		if withRefunds {
+			var refunds []reevit.Refund
+			for _, payment := range payments {
+				paymentRefunds, err := j.listRefunds(ctx, payment.ID)
+				if err != nil {
+					return err
+				}
+				refunds = append(refunds, paymentRefunds...)
+			}
+			if len(refunds) > 0 {
+				if err := j.Sink.Write(ctx, Batch{Resource: ResourceRefunds, Refunds: refunds}); err != nil {
+					return err
+				}
+			}
+		}
+
+		progress.PaymentCursor = payments[len(payments)-1].ID
+		progress.PaymentsCopied += len(payments)
+		if err := save(); err != nil {
+			return err
+		}
+		j.reportProgress(tracker, ResourcePayments, progress.PaymentsCopied)
+	}
+}
+
+// listRefunds returns every refund of paymentID, following pages of up to PageSize
+// refunds until one comes back empty.
+func (j *Job) listRefunds(ctx context.Context, paymentID string) ([]reevit.Refund, error) {
+	var refunds []reevit.Refund
+	for {
+		options := &reevit.PaginationOptions{Limit: j.pageSize(), Offset: len(refunds)}
+		page, err := fetch(ctx, func(ctx context.Context) ([]reevit.Refund, error) {
+			return j.Client.Refunds.List(ctx, paymentID, options)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return refunds, nil
+		}
+		refunds = append(refunds, page...)
+	}
+}
+
+func (j *Job) runSettlements(ctx context.Context, progress *Progress, save func() error) error {
+	tracker := reevit.NewProgressTracker(0, int64(progress.SettlementOffset))
+	for {
+		options := &reevit.SettlementListOptions{
+			Limit:  j.pageSize(),
+			Offset: progress.SettlementOffset,
+			From:   j.From,
+			To:     j.To,
+		}
+		settlements, err := fetch(ctx, func(ctx context.Context) ([]reevit.Settlement, error) {
+			return j.Client.Balance.ListSettlements(ctx, options)
+		})
+		if err != nil {
+			return err
+		}
+		if len(settlements) == 0 {
+			progress.SettlementsDone = true
+			return save()
+		}
+
+		if err := j.Sink.Write(ctx, Batch{Resource: ResourceSettlements, Settlements: settlements}); err != nil {
+			return err
+		}
+
+		progress.SettlementOffset += len(settlements)
+		if err := save(); err != nil {
+			return err
+		}
+		j.reportProgress(tracker, ResourceSettlements, progress.SettlementOffset)
+	}
+}
+
+func (j *Job) runLedger(ctx context.Context, progress *Progress, save func() error) error {
+	tracker := reevit.NewProgressTracker(0, int64(progress.LedgerEntriesCopied))
+	for {
+		options := &reevit.LedgerEntryListOptions{
+			Limit:         j.pageSize(),
+			Cursor:        progress.LedgerCursor,
+			CreatedAfter:  j.From,
+			CreatedBefore: j.To,
+		}
+		entries, err := fetch(ctx, func(ctx context.Context) ([]reevit.LedgerEntry, error) {
+			return j.Client.Ledger.ListEntries(ctx, options)
+		})
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			progress.LedgerDone = true
+			return save()
+		}
+
+		if err := j.Sink.Write(ctx, Batch{Resource: ResourceLedgerEntries, LedgerEntries: entries}); err != nil {
+			return err
+		}
+
+		progress.LedgerCursor = entries[len(entries)-1].ID
+		progress.LedgerEntriesCopied += len(entries)
+		if err := save(); err != nil {
+			return err
+		}
+		j.reportProgress(tracker, ResourceLedgerEntries, progress.LedgerEntriesCopied)
+	}
+}
+
+// countPayments estimates the number of payments in range for progress reports. It
+// returns zero, an unknown total, when nobody listens or the stats are unavailable.
+func (j *Job) countPayments(ctx context.Context) int64 {
+	if j.OnProgress == nil {
+		return 0
+	}
+	options := &reevit.PaymentStatsOptions{}
+	if !j.From.IsZero() {
+		options.From = j.From.UTC().Format(time.RFC3339)
+	}
+	if !j.To.IsZero() {
+		options.To = j.To.UTC().Format(time.RFC3339)
+	}
+	stats, err := j.Client.Payments.GetStats(ctx, options)
+	if err != nil {
+		return 0
+	}
+	return stats.Count
+}
+
+func (j *Job) reportProgress(tracker *reevit.ProgressTracker, resource Resource, copied int) {
+	if j.OnProgress != nil {
+		j.OnProgress(tracker.Update(string(resource), int64(copied)))
+	}
+}
+
+// fetch calls list, waiting out rate limits reported by the API.
+func fetch[T any](ctx context.Context, list func(ctx context.Context) ([]T, error)) ([]T, error) {
+	for attempt := 0; ; attempt++ {
+		captured := reevit.WithResponseCapture(ctx)
+		items, err := list(captured)
+		var apiErr *reevit.APIError
+		if err == nil || !errors.As(err, &apiErr) || !apiErr.IsRateLimited() || attempt >= maxRateLimitedRetries {
+			return items, err
+		}
+
+		wait := defaultRateLimitWait
+		if resp := reevit.ResponseFromContext(captured); resp != nil && !resp.RateLimit.Reset.IsZero() {
+			wait = time.Until(resp.RateLimit.Reset)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
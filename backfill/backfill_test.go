@@ -0,0 +1,187 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	payments := []string{"pay_1", "pay_2", "pay_3"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payments":
+			start := 0
+			for i, id := range payments {
+				if id == r.URL.Query().Get("cursor") {
+					start = i + 1
+				}
+			}
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			// Like the API, pages are capped whatever the requested limit.
+			if limit > 2 {
+				limit = 2
+			}
+			end := start + limit
+			if end > len(payments) {
+				end = len(payments)
+			}
+			fmt.Fprint(w, "[")
+			for i, id := range payments[start:end] {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"id":%q}`, id)
+			}
+			fmt.Fprint(w, "]")
+		case "/v1/payments/stats":
+			fmt.Fprint(w, `{"count":3}`)
+		case "/v1/payments/pay_2/refunds":
+			if r.URL.Query().Get("offset") != "" {
+				fmt.Fprint(w, `{"refunds":[]}`)
+				return
+			}
+			fmt.Fprint(w, `{"refunds":[{"id":"ref_1","payment_id":"pay_2"}]}`)
+		case "/v1/payments/pay_3/refunds":
+			// More refunds than fit on one page.
+			refunds := []string{"ref_2", "ref_3", "ref_4"}
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			end := offset + limit
+			if end > len(refunds) {
+				end = len(refunds)
+			}
+			fmt.Fprint(w, `{"refunds":[`)
+			for i, id := range refunds[offset:end] {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"id":%q,"payment_id":"pay_3"}`, id)
+			}
+			fmt.Fprint(w, `]}`)
+		case "/v1/ledger/entries":
+			if r.URL.Query().Get("cursor") == "" {
+				fmt.Fprint(w, `{"entries":[{"id":"led_1","type":"payment","amount":1000},{"id":"led_2","type":"fee","amount":-30}]}`)
+				return
+			}
+			if r.URL.Query().Get("cursor") == "led_3" {
+				fmt.Fprint(w, `{"entries":[]}`)
+				return
+			}
+			require.Equal(t, "led_2", r.URL.Query().Get("cursor"))
+			fmt.Fprint(w, `{"entries":[{"id":"led_3","type":"refund","amount":-500}]}`)
+		case "/v1/settlements":
+			if r.URL.Query().Get("offset") == "" {
+				fmt.Fprint(w, `[{"id":"stl_1"}]`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestJobResumesAfterInterruption(t *testing.T) {
+	server := newTestServer(t)
+	client := reevit.NewClient("pfk_test", "org_1", reevit.WithBaseURL(server.URL))
+	state := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+
+	var written []string
+	fail := true
+	sink := SinkFunc(func(ctx context.Context, batch Batch) error {
+		for _, payment := range batch.Payments {
+			if payment.ID == "pay_3" && fail {
+				fail = false
+				return errors.New("warehouse unavailable")
+			}
+			written = append(written, payment.ID)
+		}
+		for _, refund := range batch.Refunds {
+			written = append(written, refund.ID)
+		}
+		for _, settlement := range batch.Settlements {
+			written = append(written, settlement.ID)
+		}
+		for _, entry := range batch.LedgerEntries {
+			written = append(written, entry.ID)
+		}
+		return nil
+	})
+
+	job := &Job{ID: "job_1", Client: client, PageSize: 2, Sink: sink, State: state}
+	require.EqualError(t, job.Run(context.Background()), "warehouse unavailable")
+	progress, err := state.Load(context.Background(), "job_1")
+	require.NoError(t, err)
+	require.Equal(t, "pay_2", progress.PaymentCursor)
+
+	require.NoError(t, job.Run(context.Background()))
+	require.Equal(t, []string{"pay_1", "pay_2", "ref_1", "pay_3", "ref_2", "ref_3", "ref_4", "stl_1", "led_1", "led_2", "led_3"}, written)
+
+	progress, err = state.Load(context.Background(), "job_1")
+	require.NoError(t, err)
+	require.True(t, progress.PaymentsDone)
+	require.True(t, progress.SettlementsDone)
+	require.True(t, progress.LedgerDone)
+	require.Equal(t, 3, progress.PaymentsCopied)
+	require.Equal(t, 3, progress.LedgerEntriesCopied)
+}
+
+func TestJobFollowsCappedPages(t *testing.T) {
+	server := newTestServer(t)
+	client := reevit.NewClient("pfk_test", "org_1", reevit.WithBaseURL(server.URL))
+	state := FileStateStore{Path: filepath.Join(t.TempDir(), "state.json")}
+
+	var payments []string
+	sink := SinkFunc(func(ctx context.Context, batch Batch) error {
+		for _, payment := range batch.Payments {
+			payments = append(payments, payment.ID)
+		}
+		return nil
+	})
+	job := &Job{ID: "job_1", Client: client, PageSize: 500, Sink: sink, State: state, Resources: []Resource{ResourcePayments}}
+	require.NoError(t, job.Run(context.Background()))
+	require.Equal(t, []string{"pay_1", "pay_2", "pay_3"}, payments)
+}
+
+func TestJobRejectsUnknownResource(t *testing.T) {
+	job := &Job{Client: reevit.NewClient("pfk_test", "org_1"), Sink: SinkFunc(nil), Resources: []Resource{"ledger"}}
+	require.EqualError(t, job.Run(context.Background()), `backfill: unsupported resource "ledger"`)
+}
+
+func TestJobReportsProgress(t *testing.T) {
+	server := newTestServer(t)
+	client := reevit.NewClient("pfk_test", "org_1", reevit.WithBaseURL(server.URL))
+
+	var updates []reevit.Progress
+	job := &Job{
+		ID:         "job_1",
+		Client:     client,
+		PageSize:   2,
+		Sink:       SinkFunc(func(ctx context.Context, batch Batch) error { return nil }),
+		OnProgress: func(p reevit.Progress) { updates = append(updates, p) },
+	}
+	require.NoError(t, job.Run(context.Background()))
+
+	require.Len(t, updates, 5)
+	require.Equal(t, "payments", updates[0].Stage)
+	require.Equal(t, int64(2), updates[0].Processed)
+	require.Equal(t, int64(3), updates[0].Total)
+	require.InDelta(t, 66.7, updates[0].Percent(), 0.1)
+	require.Equal(t, float64(100), updates[1].Percent())
+	require.Equal(t, "settlements", updates[2].Stage)
+	require.Equal(t, int64(1), updates[2].Processed)
+	require.Equal(t, float64(-1), updates[2].Percent())
+	require.Equal(t, "ledger_entries", updates[4].Stage)
+	require.Equal(t, int64(3), updates[4].Processed)
+}
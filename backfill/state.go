@@ -0,0 +1,123 @@
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Progress is the resumable state of a Job.
+type Progress struct {
+	PaymentCursor       string    `json:"payment_cursor,omitempty"`
+	PaymentsCopied      int       `json:"payments_copied"`
+	PaymentsDone        bool      `json:"payments_done"`
+	SettlementOffset    int       `json:"settlement_offset"`
+	SettlementsDone     bool      `json:"settlements_done"`
+	LedgerCursor        string    `json:"ledger_cursor,omitempty"`
+	LedgerEntriesCopied int       `json:"ledger_entries_copied"`
+	LedgerDone          bool      `json:"ledger_done"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// StateStore persists Job progress between runs.
+type StateStore interface {
+	// Load returns the saved progress of job, or nil when the job has not run before.
+	Load(ctx context.Context, job string) (*Progress, error)
+	Save(ctx context.Context, job string, progress *Progress) error
+}
+
+// MemoryStateStore keeps progress in memory, for tests and one-off runs.
+type MemoryStateStore struct {
+	mu   sync.Mutex
+	jobs map[string]Progress
+}
+
+// NewMemoryStateStore returns an empty in-memory store.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{jobs: make(map[string]Progress)}
+}
+
+// Load implements StateStore.
+func (s *MemoryStateStore) Load(ctx context.Context, job string) (*Progress, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	progress, ok := s.jobs[job]
+	if !ok {
+		return nil, nil
+	}
+	return &progress, nil
+}
+
+// Save implements StateStore.
+func (s *MemoryStateStore) Save(ctx context.Context, job string, progress *Progress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job] = *progress
+	return nil
+}
+
+// FileStateStore keeps the progress of every job in a JSON file. Writes go to a temporary
+// file that is renamed into place, so a crash never leaves a half-written state file.
+type FileStateStore struct {
+	Path string
+}
+
+// Load implements StateStore.
+func (s FileStateStore) Load(ctx context.Context, job string) (*Progress, error) {
+	jobs, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	progress, ok := jobs[job]
+	if !ok {
+		return nil, nil
+	}
+	return &progress, nil
+}
+
+// Save implements StateStore.
+func (s FileStateStore) Save(ctx context.Context, job string, progress *Progress) error {
+	jobs, err := s.read()
+	if err != nil {
+		return err
+	}
+	jobs[job] = *progress
+
+	encoded, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(encoded); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+func (s FileStateStore) read() (map[string]Progress, error) {
+	jobs := make(map[string]Progress)
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return jobs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
@@ -0,0 +1,151 @@
+package reevit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BalanceService handles communication with the balance and settlement methods of the Reevit API.
+type BalanceService service
+
+// Balance holds the org balances per currency.
+type Balance struct {
+	Available []BalanceAmount `json:"available"`
+	Pending   []BalanceAmount `json:"pending"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// BalanceAmount is a balance in minor units of a currency.
+type BalanceAmount struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Settlement represents a settlement batch paid out to the org.
+type Settlement struct {
+	ID           string              `json:"id"`
+	Status       string              `json:"status"`
+	ConnectionID string              `json:"connection_id"`
+	Provider     string              `json:"provider"`
+	Currency     string              `json:"currency"`
+	GrossAmount  int64               `json:"gross_amount"`
+	FeeAmount    int64               `json:"fee_amount"`
+	NetAmount    int64               `json:"net_amount"`
+	Payments     []SettlementPayment `json:"payments"`
+	SettledAt    *time.Time          `json:"settled_at"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// SettlementPayment is a payment included in a settlement batch.
+type SettlementPayment struct {
+	PaymentID string `json:"payment_id"`
+	Reference string `json:"reference"`
+	Amount    int64  `json:"amount"`
+	FeeAmount int64  `json:"fee_amount"`
+	NetAmount int64  `json:"net_amount"`
+}
+
+// SettlementListOptions contains filters for settlement listing.
+type SettlementListOptions struct {
+	Limit    int
+	Offset   int
+	Status   string
+	Currency string
+	Provider string
+	From     time.Time
+	To       time.Time
+}
+
+// Get returns the available and pending balances per currency.
+//
+// API Docs: GET /v1/balance
+func (s *BalanceService) Get(ctx context.Context, opts ...RequestOption) (*Balance, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/balance", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance Balance
+	if err := s.client.do(httpRequest, &balance); err != nil {
+		return nil, err
+	}
+
+	return &balance, nil
+}
+
+// ListSettlements returns settlement batches with their constituent payments.
+//
+// API Docs: GET /v1/settlements
+func (s *BalanceService) ListSettlements(ctx context.Context, options *SettlementListOptions, opts ...RequestOption) ([]Settlement, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+		setString(values, "currency", options.Currency)
+		setString(values, "provider", options.Provider)
+		setTime(values, "from", options.From)
+		setTime(values, "to", options.To)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/settlements", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[Settlement](s.client, raw, "settlements")
+}
+
+// GetSettlement retrieves a settlement batch by ID.
+//
+// API Docs: GET /v1/settlements/{id}
+func (s *BalanceService) GetSettlement(ctx context.Context, settlementID string, opts ...RequestOption) (*Settlement, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/settlements/%s", settlementID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var settlement Settlement
+	if err := s.client.do(httpRequest, &settlement); err != nil {
+		return nil, err
+	}
+
+	return &settlement, nil
+}
+
+// GetSettlementExport returns the metadata of a settlement's export file, including its
+// SHA-256 checksum and a signed download URL.
+//
+// API Docs: GET /v1/settlements/{id}/export
+func (s *BalanceService) GetSettlementExport(ctx context.Context, settlementID string, opts ...RequestOption) (*File, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/settlements/%s/export", settlementID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := s.client.do(httpRequest, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// DownloadSettlementExport streams a settlement's export file to w and verifies it against
+// the advertised checksum. A truncated or corrupted download fails with ErrChecksumMismatch,
+// as does an export without a checksum unless WithoutChecksum is given.
+func (s *BalanceService) DownloadSettlementExport(ctx context.Context, settlementID string, w io.Writer, opts ...RequestOption) (*File, error) {
+	file, err := s.GetSettlementExport(ctx, settlementID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return file, s.client.Files.download(ctx, file, w, opts...)
+}
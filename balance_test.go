@@ -0,0 +1,56 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceService(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/balance":
+			_, _ = w.Write([]byte(`{"available":[{"amount":150000,"currency":"GHS"}],"pending":[{"amount":2500,"currency":"NGN"}],"updated_at":"2026-03-02T10:00:00Z"}`))
+		case "/v1/settlements":
+			queries = append(queries, r.URL.RawQuery)
+			_, _ = w.Write([]byte(`{"settlements":[{"id":"stl_1","status":"paid","currency":"GHS","gross_amount":10000,"fee_amount":300,"net_amount":9700,"payments":[{"payment_id":"pay_1","amount":10000,"fee_amount":300,"net_amount":9700}]}]}`))
+		case "/v1/settlements/stl_1":
+			_, _ = w.Write([]byte(`{"id":"stl_1","status":"paid","settled_at":"2026-03-03T09:00:00Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	balance, err := client.Balance.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []BalanceAmount{{Amount: 150000, Currency: "GHS"}}, balance.Available)
+	require.Equal(t, "NGN", balance.Pending[0].Currency)
+
+	settlements, err := client.Balance.ListSettlements(ctx, &SettlementListOptions{
+		Limit:    10,
+		Status:   "paid",
+		Currency: "GHS",
+		From:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, settlements, 1)
+	require.Equal(t, int64(9700), settlements[0].NetAmount)
+	require.Equal(t, "pay_1", settlements[0].Payments[0].PaymentID)
+
+	_, err = client.Balance.ListSettlements(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"currency=GHS&from=2026-03-01T00%3A00%3A00Z&limit=10&status=paid", ""}, queries)
+
+	settlement, err := client.Balance.GetSettlement(ctx, "stl_1")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC), settlement.SettledAt.UTC())
+}
@@ -0,0 +1,121 @@
+package reevit
+
+import (
+	"strings"
+	"time"
+)
+
+// IntentBuilder assembles a PaymentIntentRequest step by step and validates it in Build.
+// The result can be passed to Payments.CreateIntent or CheckoutSessions.Create:
+//
+//	req, err := reevit.NewIntent(5000, "GHS").
+//		WithMethod("momo").
+//		WithCountry("GH").
+//		WithCustomer("cus_123").
+//		Build()
+type IntentBuilder struct {
+	req PaymentIntentRequest
+}
+
+// NewIntent starts a payment intent for amount (in minor units) of currency.
+func NewIntent(amount int64, currency string) *IntentBuilder {
+	return &IntentBuilder{req: PaymentIntentRequest{Amount: amount, Currency: currency}}
+}
+
+// WithMethod sets the payment method, for example MethodCard or MethodMobileMoney.
+func (b *IntentBuilder) WithMethod(method PaymentMethod) *IntentBuilder {
+	b.req.Method = method
+	return b
+}
+
+// WithCountry sets the ISO 3166-1 alpha-2 country of the payment.
+func (b *IntentBuilder) WithCountry(country string) *IntentBuilder {
+	b.req.Country = country
+	return b
+}
+
+// WithPhone sets the mobile money number to charge. Build normalizes it to E.164 format.
+func (b *IntentBuilder) WithPhone(phone string) *IntentBuilder {
+	b.req.Phone = phone
+	return b
+}
+
+// WithCustomer attaches the payment to a customer.
+func (b *IntentBuilder) WithCustomer(customerID string) *IntentBuilder {
+	b.req.CustomerID = customerID
+	return b
+}
+
+// WithReference sets the merchant reference of the payment.
+func (b *IntentBuilder) WithReference(reference string) *IntentBuilder {
+	b.req.Reference = reference
+	return b
+}
+
+// WithPolicy sets a fraud policy override for this payment.
+func (b *IntentBuilder) WithPolicy(policy *FraudPolicyInput) *IntentBuilder {
+	b.req.Policy = policy
+	return b
+}
+
+// WithScheduleAt defers the charge until at.
+func (b *IntentBuilder) WithScheduleAt(at time.Time) *IntentBuilder {
+	b.req.ScheduleAt = &at
+	return b
+}
+
+// WithExpiresAt cancels the intent if it has not completed by at.
+func (b *IntentBuilder) WithExpiresAt(at time.Time) *IntentBuilder {
+	b.req.ExpiresAt = &at
+	return b
+}
+
+// WithAutoCancelAfter cancels the intent if it has not completed d after creation.
+func (b *IntentBuilder) WithAutoCancelAfter(d time.Duration) *IntentBuilder {
+	b.req.AutoCancelAfter = d
+	return b
+}
+
+// WithMetadata sets a metadata entry. It can be called repeatedly.
+func (b *IntentBuilder) WithMetadata(key string, value interface{}) *IntentBuilder {
+	if b.req.Metadata == nil {
+		b.req.Metadata = make(map[string]interface{})
+	}
+	b.req.Metadata[key] = value
+	return b
+}
+
+// Build normalizes and validates the request with PaymentIntentRequest.Validate, so it
+// reports every problem found at once as a *ValidationError, and formats a mobile money
+// phone number in E.164.
+func (b *IntentBuilder) Build() (*PaymentIntentRequest, error) {
+	req := b.req
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	req.Country = strings.ToUpper(strings.TrimSpace(req.Country))
+	req.Method = PaymentMethod(strings.TrimSpace(string(req.Method)))
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+	req.Reference = strings.TrimSpace(req.Reference)
+	if b.req.Metadata != nil {
+		req.Metadata = make(map[string]interface{}, len(b.req.Metadata))
+		for key, value := range b.req.Metadata {
+			req.Metadata[key] = value
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req.withNormalizedPhone(), nil
+}
+
+func isAlpha(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package reevit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntentBuilder(t *testing.T) {
+	req, err := NewIntent(5000, " ghs ").
+		WithMethod("momo").
+		WithCountry("gh").
+		WithCustomer("cus_1").
+		WithPhone("024 123 4567").
+		WithMetadata("order_id", "ord_1").
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, &PaymentIntentRequest{
+		Amount:     5000,
+		Currency:   "GHS",
+		Method:     "momo",
+		Country:    "GH",
+		CustomerID: "cus_1",
+		Phone:      "+233241234567",
+		Metadata:   map[string]interface{}{"order_id": "ord_1"},
+	}, req)
+
+	_, err = NewIntent(5000, "GHS").WithMethod(MethodMobileMoney).WithCountry("GH").WithPhone("0991234567").Build()
+	require.ErrorContains(t, err, "phone is not a valid mobile money number")
+
+	_, err = NewIntent(0, "cedi").WithCountry("GHA").Build()
+	require.EqualError(t, err, "reevit: invalid payment intent: amount must be greater than zero; currency must be a 3-letter ISO 4217 code; country must be a 2-letter ISO 3166-1 code")
+
+	_, err = NewIntent(5000, "GHS").WithPolicy(&FraudPolicyInput{MaxAmount: 1000}).Build()
+	require.EqualError(t, err, "reevit: invalid payment intent: amount exceeds the policy max_amount")
+
+	_, err = NewIntent(5000, "GHS").WithScheduleAt(time.Now().Add(-time.Minute)).Build()
+	require.EqualError(t, err, "reevit: invalid payment intent: schedule_at must be in the future")
+
+	// Build applies every check of Validate, including splits and auto-cancel.
+	_, err = NewIntent(5000, "GHS").WithAutoCancelAfter(time.Millisecond).Build()
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, "auto_cancel_after_seconds", validationErr.Fields[0].Field)
+	builder := NewIntent(5000, "GHS")
+	builder.req.Split = &SplitConfig{Splits: []Split{{DestinationID: "dest_1", BasisPoints: 12000}}}
+	_, err = builder.Build()
+	require.ErrorContains(t, err, "split basis_points must not exceed 10000 in total")
+
+	at := time.Now().Add(24 * time.Hour)
+	req, err = NewIntent(5000, "GHS").WithScheduleAt(at).Build()
+	require.NoError(t, err)
+	require.Equal(t, at, *req.ScheduleAt)
+}
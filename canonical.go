@@ -0,0 +1,206 @@
+package reevit
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Idempotency keys are derived from a canonical form of the request parameters that every
+// Reevit SDK computes identically, so that a request retried through another SDK, e.g. a
+// job moved from Node to Go, reuses the same key. The canonical form of a parameter map is
+//
+//	key1:value1|key2:value2|...
+//
+// with the keys sorted and each value written as canonical JSON:
+//
+//   - object keys are sorted, and keys and values are separated without whitespace;
+//   - keys are sorted by UTF-16 code units, like Array.prototype.sort in JavaScript;
+//   - a null value and an absent key are the same: object keys, top-level keys included,
+//     whose value is null are left out, while null array elements are kept;
+//   - numbers are IEEE 754 doubles written like JavaScript's Number.prototype.toString,
+//     so integers above 2^53 lose precision exactly as in JavaScript, -0 is written as 0,
+//     and NaN and infinities are written as null;
+//   - strings are escaped like JSON.stringify: only the quote, the backslash and control
+//     characters, without HTML escaping.
+//
+// The golden vectors in testdata/idempotency_vectors.json are shared with the other SDKs.
+
+// canonicalParams returns the canonical form of params. Values that cannot be encoded as
+// JSON, such as channels, are treated as null.
+func canonicalParams(params map[string]any) string {
+	keys := make([]string, 0, len(params))
+	values := make(map[string]interface{}, len(params))
+	for key, param := range params {
+		value := normalizeCanonical(param)
+		if value == nil {
+			continue
+		}
+		keys = append(keys, key)
+		values[key] = value
+	}
+	sortUTF16(keys)
+
+	var buf bytes.Buffer
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte('|')
+		}
+		buf.WriteString(key)
+		buf.WriteByte(':')
+		writeCanonical(&buf, values[key])
+	}
+	return buf.String()
+}
+
+// normalizeCanonical round-trips v through encoding/json, so that structs, json.Marshalers
+// and typed maps are reduced to the generic JSON values handled by writeCanonical.
+// Top-level floats are kept as is so that NaN and infinities, which encoding/json
+// rejects, are written as null.
+func normalizeCanonical(v any) interface{} {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	return value
+}
+
+func writeCanonical(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		// Out-of-range numbers parse to ±Inf, written as null like in JavaScript.
+		f, _ := strconv.ParseFloat(string(v), 64)
+		writeCanonicalNumber(buf, f)
+	case float64:
+		writeCanonicalNumber(buf, v)
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, element := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonical(buf, element)
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key, value := range v {
+			if value != nil {
+				keys = append(keys, key)
+			}
+		}
+		sortUTF16(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			writeCanonical(buf, v[key])
+		}
+		buf.WriteByte('}')
+	}
+}
+
+// writeCanonicalNumber formats f like Number.prototype.toString in JavaScript, the same
+// algorithm encoding/json uses for floats.
+func writeCanonicalNumber(buf *bytes.Buffer, f float64) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		buf.WriteString("null")
+		return
+	}
+	if f == 0 {
+		buf.WriteByte('0')
+		return
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	b := strconv.AppendFloat(nil, f, format, -1, 64)
+	if format == 'e' {
+		// Go writes two-digit exponents (1e-07); JavaScript does not (1e-7).
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	buf.Write(b)
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// sortUTF16 sorts keys by UTF-16 code units, which differs from Go's byte order for
+// characters outside the Basic Multilingual Plane.
+func sortUTF16(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		if isASCII(keys[i]) && isASCII(keys[j]) {
+			return keys[i] < keys[j]
+		}
+		a, b := utf16.Encode([]rune(keys[i])), utf16.Encode([]rune(keys[j]))
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
+
+func isASCII(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r >= utf8.RuneSelf }) < 0
+}
@@ -0,0 +1,127 @@
+package reevit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Capabilities describes what a connection's provider account can do. Capabilities the
+// SDK does not know yet are kept in ExtraFields.
+type Capabilities struct {
+	// Methods and Currencies list the payment methods and ISO 4217 currencies accepted.
+	Methods    []PaymentMethod `json:"methods,omitempty"`
+	Currencies []string        `json:"currencies,omitempty"`
+	Refunds    bool            `json:"refunds"`
+	Payouts    bool            `json:"payouts"`
+	ThreeDS    bool            `json:"three_ds"`
+	// Limits bounds the amounts the provider accepts; nil means no known limits.
+	Limits *CapabilityLimits `json:"limits,omitempty"`
+
+	// MultiCapture, MaxCaptures and OverCapturePercent are the capture capabilities read
+	// by CaptureLimitsFromConnection.
+	MultiCapture       bool    `json:"multi_capture,omitempty"`
+	MaxCaptures        int     `json:"max_captures,omitempty"`
+	OverCapturePercent float64 `json:"over_capture_percent,omitempty"`
+
+	// ExtraFields holds the capabilities returned by the API that this version of the SDK
+	// does not know yet. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
+}
+
+// CapabilityLimits bounds the amounts a provider accepts, in minor units. Zero means
+// no limit.
+type CapabilityLimits struct {
+	MinAmount   int64 `json:"min_amount,omitempty"`
+	MaxAmount   int64 `json:"max_amount,omitempty"`
+	DailyAmount int64 `json:"daily_amount,omitempty"`
+}
+
+// CapabilitiesUpdate represents a partial capabilities update. Nil and empty fields are
+// left unchanged, so that turning one capability on or off does not reset the others.
+type CapabilitiesUpdate struct {
+	Methods            []PaymentMethod   `json:"methods,omitempty"`
+	Currencies         []string          `json:"currencies,omitempty"`
+	Refunds            *bool             `json:"refunds,omitempty"`
+	Payouts            *bool             `json:"payouts,omitempty"`
+	ThreeDS            *bool             `json:"three_ds,omitempty"`
+	Limits             *CapabilityLimits `json:"limits,omitempty"`
+	MultiCapture       *bool             `json:"multi_capture,omitempty"`
+	MaxCaptures        *int              `json:"max_captures,omitempty"`
+	OverCapturePercent *float64          `json:"over_capture_percent,omitempty"`
+}
+
+// Apply returns a copy of c with the fields set in update changed. A nil c is treated as
+// empty capabilities.
+func (update *CapabilitiesUpdate) Apply(c *Capabilities) *Capabilities {
+	applied := Capabilities{}
+	if c != nil {
+		applied = *c
+	}
+	if update == nil {
+		return &applied
+	}
+	if update.Methods != nil {
+		applied.Methods = update.Methods
+	}
+	if update.Currencies != nil {
+		applied.Currencies = update.Currencies
+	}
+	if update.Refunds != nil {
+		applied.Refunds = *update.Refunds
+	}
+	if update.Payouts != nil {
+		applied.Payouts = *update.Payouts
+	}
+	if update.ThreeDS != nil {
+		applied.ThreeDS = *update.ThreeDS
+	}
+	if update.Limits != nil {
+		applied.Limits = update.Limits
+	}
+	if update.MultiCapture != nil {
+		applied.MultiCapture = *update.MultiCapture
+	}
+	if update.MaxCaptures != nil {
+		applied.MaxCaptures = *update.MaxCaptures
+	}
+	if update.OverCapturePercent != nil {
+		applied.OverCapturePercent = *update.OverCapturePercent
+	}
+	return &applied
+}
+
+// SupportsMethod reports whether the connection accepts method. A nil Capabilities
+// supports nothing.
+func (c *Capabilities) SupportsMethod(method PaymentMethod) bool {
+	if c == nil {
+		return false
+	}
+	for _, supported := range c.Methods {
+		if supported == method {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsCurrency reports whether the connection accepts currency, compared without
+// regard to case. A nil Capabilities supports nothing.
+func (c *Capabilities) SupportsCurrency(currency string) bool {
+	if c == nil {
+		return false
+	}
+	for _, supported := range c.Currencies {
+		if strings.EqualFold(supported, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAmount reports whether amount, in minor units, is within Limits.
+func (c *Capabilities) AllowsAmount(amount int64) bool {
+	if c == nil || c.Limits == nil {
+		return true
+	}
+	return amount >= c.Limits.MinAmount && (c.Limits.MaxAmount == 0 || amount <= c.Limits.MaxAmount)
+}
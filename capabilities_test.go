@@ -0,0 +1,69 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	var connection Connection
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"conn_1","capabilities":{
+		"methods":["card","momo"],"currencies":["GHS","NGN"],"refunds":true,"three_ds":true,
+		"limits":{"min_amount":100,"max_amount":500000},"recurring":true
+	}}`), &connection))
+
+	capabilities := connection.Capabilities
+	require.True(t, capabilities.SupportsMethod(MethodMobileMoney))
+	require.False(t, capabilities.SupportsMethod(MethodUSSD))
+	require.True(t, capabilities.SupportsCurrency("ghs"))
+	require.False(t, capabilities.SupportsCurrency("KES"))
+	require.True(t, capabilities.Refunds)
+	require.False(t, capabilities.Payouts)
+	require.True(t, capabilities.AllowsAmount(1000))
+	require.False(t, capabilities.AllowsAmount(50))
+	require.Equal(t, map[string]json.RawMessage{"recurring": json.RawMessage("true")}, capabilities.ExtraFields)
+
+	encoded, err := json.Marshal(capabilities)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"recurring":true`)
+
+	var none *Capabilities
+	require.False(t, none.SupportsMethod(MethodCard))
+	require.True(t, none.AllowsAmount(1))
+
+	var tree interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"capabilities":{"recurring":true}}`), &tree))
+	require.EqualError(t, checkKnownFields(tree, reflect.TypeOf(Connection{})), `reevit: unknown field "recurring" in Capabilities`)
+}
+
+func TestConnectionsUpdateCapabilities(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "/v1/connections/conn_1", r.URL.Path)
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(raw)
+		_, _ = w.Write([]byte(`{"id":"conn_1","capabilities":{"refunds":true,"payouts":true,"three_ds":true}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	disabled := false
+	_, err := client.Connections.Update(context.Background(), "conn_1", &ConnectionUpdateRequest{
+		Capabilities: &CapabilitiesUpdate{Payouts: &disabled},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"capabilities":{"payouts":false}}`, body)
+
+	enabled := true
+	applied := (&CapabilitiesUpdate{ThreeDS: &enabled}).Apply(&Capabilities{Refunds: true, Currencies: []string{"GHS"}})
+	require.Equal(t, &Capabilities{Refunds: true, ThreeDS: true, Currencies: []string{"GHS"}}, applied)
+}
@@ -0,0 +1,111 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// Capture validation errors. ValidateCapture wraps them with the amounts involved.
+var (
+	// ErrCaptureExceedsAuthorized means the capture is larger than the authorized amount
+	// plus the over-capture allowance of the provider.
+	ErrCaptureExceedsAuthorized = errors.New("reevit: capture exceeds authorized amount")
+	// ErrCaptureLimitReached means the provider does not allow another capture on the payment.
+	ErrCaptureLimitReached = errors.New("reevit: capture limit reached")
+)
+
+// CaptureRequest captures funds of an authorized payment.
+type CaptureRequest struct {
+	// Amount to capture in minor units. Zero captures the whole remaining authorization.
+	Amount int64 `json:"amount,omitempty"`
+	// FinalCapture releases any uncaptured remainder after this capture. Captures are
+	// final unless the provider supports multi-capture.
+	FinalCapture *bool                  `json:"final_capture,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CaptureLimits are the capture capabilities of a provider connection.
+type CaptureLimits struct {
+	// MultiCapture allows capturing an authorization in several parts.
+	MultiCapture bool
+	// MaxCaptures caps the number of captures per payment; zero means no limit.
+	MaxCaptures int
+	// OverCapturePercent is how far above the authorized amount a capture may go, e.g. 15
+	// for tips. Zero disallows over-capture.
+	OverCapturePercent float64
+}
+
+// CaptureLimitsFromConnection reads the capture limits from the capabilities of a
+// connection.
+func CaptureLimitsFromConnection(connection *Connection) CaptureLimits {
+	if connection == nil || connection.Capabilities == nil {
+		return CaptureLimits{}
+	}
+	return CaptureLimits{
+		MultiCapture:       connection.Capabilities.MultiCapture,
+		MaxCaptures:        connection.Capabilities.MaxCaptures,
+		OverCapturePercent: connection.Capabilities.OverCapturePercent,
+	}
+}
+
+// ValidateCapture checks req against the authorization of payment and the capture limits
+// of its provider, so that obviously invalid captures fail before reaching the API.
+func ValidateCapture(payment *Payment, req *CaptureRequest, limits CaptureLimits) error {
+	if req.Amount < 0 {
+		return fmt.Errorf("reevit: invalid capture: amount must not be negative")
+	}
+	authorized := payment.AuthorizedAmount
+	if authorized == 0 {
+		authorized = payment.Amount
+	}
+
+	if payment.CaptureCount > 0 {
+		if !limits.MultiCapture {
+			return fmt.Errorf("%w: payment %s was already captured and the provider does not support multi-capture",
+				ErrCaptureLimitReached, payment.ID)
+		}
+		if limits.MaxCaptures > 0 && payment.CaptureCount >= limits.MaxCaptures {
+			return fmt.Errorf("%w: payment %s has %d of %d captures",
+				ErrCaptureLimitReached, payment.ID, payment.CaptureCount, limits.MaxCaptures)
+		}
+	}
+
+	allowed := authorized + int64(math.Floor(float64(authorized)*limits.OverCapturePercent/100))
+	remaining := allowed - payment.CapturedAmount
+	if req.Amount > remaining {
+		return fmt.Errorf("%w: payment %s: requested %d, at most %d capturable (authorized %d, captured %d, over-capture %.4g%%)",
+			ErrCaptureExceedsAuthorized, payment.ID, req.Amount, remaining, authorized, payment.CapturedAmount, limits.OverCapturePercent)
+	}
+	if remaining <= 0 {
+		return fmt.Errorf("%w: payment %s has nothing left to capture", ErrCaptureLimitReached, payment.ID)
+	}
+	return nil
+}
+
+// Capture captures funds of an authorized payment. Call ValidateCapture first to catch
+// amounts and capture counts the provider would reject.
+//
+// API Docs: POST /v1/payments/{id}/capture
+func (s *PaymentsService) Capture(ctx context.Context, paymentID string, req *CaptureRequest, opts ...RequestOption) (*Payment, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		req = &CaptureRequest{}
+	}
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/payments/%s/capture", paymentID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var payment Payment
+	if err := s.client.do(httpRequest, &payment); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
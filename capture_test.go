@@ -0,0 +1,52 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCapture(t *testing.T) {
+	payment := &Payment{ID: "pay_1", Amount: 10000, AuthorizedAmount: 10000}
+
+	require.NoError(t, ValidateCapture(payment, &CaptureRequest{Amount: 10000}, CaptureLimits{}))
+	require.ErrorIs(t, ValidateCapture(payment, &CaptureRequest{Amount: 10001}, CaptureLimits{}), ErrCaptureExceedsAuthorized)
+	require.NoError(t, ValidateCapture(payment, &CaptureRequest{Amount: 11500}, CaptureLimits{OverCapturePercent: 15}))
+	require.ErrorIs(t, ValidateCapture(payment, &CaptureRequest{Amount: 11501}, CaptureLimits{OverCapturePercent: 15}), ErrCaptureExceedsAuthorized)
+
+	captured := &Payment{ID: "pay_1", AuthorizedAmount: 10000, CapturedAmount: 4000, CaptureCount: 1}
+	require.ErrorIs(t, ValidateCapture(captured, &CaptureRequest{Amount: 1000}, CaptureLimits{}), ErrCaptureLimitReached)
+	require.NoError(t, ValidateCapture(captured, &CaptureRequest{Amount: 6000}, CaptureLimits{MultiCapture: true}))
+	require.ErrorIs(t, ValidateCapture(captured, &CaptureRequest{Amount: 6001}, CaptureLimits{MultiCapture: true}), ErrCaptureExceedsAuthorized)
+	require.ErrorIs(t, ValidateCapture(captured, &CaptureRequest{}, CaptureLimits{MultiCapture: true, MaxCaptures: 1}), ErrCaptureLimitReached)
+
+	limits := CaptureLimitsFromConnection(&Connection{Capabilities: &Capabilities{
+		MultiCapture:       true,
+		MaxCaptures:        3,
+		OverCapturePercent: 20,
+	}})
+	require.Equal(t, CaptureLimits{MultiCapture: true, MaxCaptures: 3, OverCapturePercent: 20}, limits)
+}
+
+func TestCapture(t *testing.T) {
+	var body map[string]interface{}
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"succeeded","captured_amount":2500,"capture_count":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	final := false
+	payment, err := client.Payments.Capture(context.Background(), "pay_1", &CaptureRequest{Amount: 2500, FinalCapture: &final})
+	require.NoError(t, err)
+	require.Equal(t, "/v1/payments/pay_1/capture", path)
+	require.Equal(t, map[string]interface{}{"amount": float64(2500), "final_capture": false}, body)
+	require.Equal(t, int64(2500), payment.CapturedAmount)
+}
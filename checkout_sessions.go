@@ -22,17 +22,17 @@ type CheckoutSession struct {
 //
 // API Docs: POST /v1/checkout/sessions
 func (s *CheckoutSessionsService) Create(ctx context.Context, req *PaymentIntentRequest, opts ...RequestOption) (*CheckoutSession, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/checkout/sessions", req)
-	if err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/checkout/sessions", req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var session CheckoutSession
-	if err := s.client.do(ctx, httpRequest, &session); err != nil {
+	if err := s.client.do(httpRequest, &session); err != nil {
 		return nil, err
 	}
 
@@ -9,34 +9,93 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// Version is the version of this SDK, reported to the API with every request.
+const Version = "0.9.1"
+
 const (
 	defaultBaseURL = "https://api.reevit.io"
-	userAgent      = "@reevit/go v0.9.1"
+	userAgent      = "@reevit/go v" + Version
 )
 
 // Client is the Reevit API client.
 type Client struct {
 	baseURL    string
-	apiKey     string
+	apiKey     atomic.Pointer[string]
 	orgID      string
 	httpClient *http.Client
+	redactors  []RedactFunc
+	directory  directoryCache
+
+	requestMiddleware  []RequestMiddleware
+	responseMiddleware []ResponseMiddleware
+
+	logger   Logger
+	logLevel LogLevel
+
+	limiter     *rateLimiter
+	maxRetries  int
+	retryBudget *retryBudget
+	retryStats  retryCounters
+	latency     latencyTracker
+
+	adaptiveTimeout *AdaptiveTimeout
+
+	idempotencyStore IdempotencyStore
+	idempotencyTTL   time.Duration
+	bucketGuard      *bucketGuard
+	autoIdempotency  bool
+
+	credentials     CredentialsProvider
+	tokenSource     TokenSource
+	signingSecret   []byte
+	strictDecoding  bool
+	paymentNotifier PaymentNotifier
+	deprecationHook func(SDKDeprecation)
+	orgDefaults     *orgDefaults
+
+	compressor           Compressor
+	compressionThreshold int
+	compressionRejected  atomic.Bool
+
+	appInfo         *AppInfo
+	userAgentSuffix string
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services
-	Payments         *PaymentsService
-	Connections      *ConnectionsService
-	Subscriptions    *SubscriptionsService
-	Fraud            *FraudService
-	Customers        *CustomersService
-	PaymentLinks     *PaymentLinksService
-	CheckoutSessions *CheckoutSessionsService
-	Webhooks         *WebhooksService
-	RoutingRules     *RoutingRulesService
-	Invoices         *InvoicesService
+	Payments           *PaymentsService
+	Refunds            *RefundsService
+	Connections        *ConnectionsService
+	Subscriptions      *SubscriptionsService
+	Plans              *PlansService
+	Fraud              *FraudService
+	Customers          *CustomersService
+	PaymentLinks       *PaymentLinksService
+	CheckoutSessions   *CheckoutSessionsService
+	Webhooks           *WebhooksService
+	WebhookEndpoints   *WebhookEndpointsService
+	RoutingRules       *RoutingRulesService
+	Invoices           *InvoicesService
+	Availability       *AvailabilityService
+	Directory          *DirectoryService
+	SettlementCalendar *SettlementCalendarService
+	FX                 *FXService
+	Balance            *BalanceService
+	Ledger             *LedgerService
+	Reports            *ReportsService
+	Files              *FilesService
+	Events             *EventsService
+	TransferSchedules  *TransferSchedulesService
+	Transfers          *TransfersService
+	Sandbox            *SandboxService
+	Usage              *UsageService
+	OrgSettings        *OrgSettingsService
+	Notifications      *NotificationsService
+	CustomDomains      *CustomDomainsService
 }
 
 type service struct {
@@ -64,28 +123,53 @@ func WithHTTPClient(httpClient *http.Client) Option {
 func NewClient(apiKey, orgID string, opts ...Option) *Client {
 	c := &Client{
 		baseURL: defaultBaseURL,
-		apiKey:  apiKey,
 		orgID:   orgID,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
 	}
 
+	c.apiKey.Store(&apiKey)
+
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.adaptiveTimeout != nil && c.httpClient.Timeout > 0 {
+		httpClient := *c.httpClient
+		httpClient.Timeout = 0
+		c.httpClient = &httpClient
+	}
 
 	c.common.client = c
 	c.Payments = (*PaymentsService)(&c.common)
+	c.Refunds = (*RefundsService)(&c.common)
 	c.Connections = (*ConnectionsService)(&c.common)
 	c.Subscriptions = (*SubscriptionsService)(&c.common)
+	c.Plans = (*PlansService)(&c.common)
 	c.Fraud = (*FraudService)(&c.common)
 	c.Customers = (*CustomersService)(&c.common)
 	c.PaymentLinks = (*PaymentLinksService)(&c.common)
 	c.CheckoutSessions = (*CheckoutSessionsService)(&c.common)
 	c.Webhooks = (*WebhooksService)(&c.common)
+	c.WebhookEndpoints = (*WebhookEndpointsService)(&c.common)
 	c.RoutingRules = (*RoutingRulesService)(&c.common)
 	c.Invoices = (*InvoicesService)(&c.common)
+	c.Availability = (*AvailabilityService)(&c.common)
+	c.Directory = (*DirectoryService)(&c.common)
+	c.SettlementCalendar = (*SettlementCalendarService)(&c.common)
+	c.FX = (*FXService)(&c.common)
+	c.Balance = (*BalanceService)(&c.common)
+	c.Ledger = (*LedgerService)(&c.common)
+	c.Reports = (*ReportsService)(&c.common)
+	c.Files = (*FilesService)(&c.common)
+	c.Events = (*EventsService)(&c.common)
+	c.TransferSchedules = (*TransferSchedulesService)(&c.common)
+	c.Transfers = (*TransfersService)(&c.common)
+	c.Sandbox = (*SandboxService)(&c.common)
+	c.Usage = (*UsageService)(&c.common)
+	c.OrgSettings = (*OrgSettingsService)(&c.common)
+	c.Notifications = (*NotificationsService)(&c.common)
+	c.CustomDomains = (*CustomDomainsService)(&c.common)
 
 	return c
 }
@@ -100,24 +184,72 @@ func WithIdempotencyKey(key string) RequestOption {
 	}
 }
 
-// newRequest creates an API request.
-func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
+// WithHeader sets a header on the request, such as a trace ID, a feature flag or a header
+// required by a partner integration. It replaces any value the SDK would have sent.
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// WithHeaders sets every header in headers on the request, like WithHeader.
+func WithHeaders(headers map[string]string) RequestOption {
+	return func(req *http.Request) {
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+	}
+}
+
+// WithRequestTimeout bounds the time a single API call may take, including reading the
+// response body. It applies on top of any deadline already carried by the call's context.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(req *http.Request) {
+		if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok {
+			settings.timeout = d
+		}
+	}
+}
+
+// requestSettings carries per-call settings from RequestOptions to doRaw.
+type requestSettings struct {
+	timeout       time.Duration
+	noCompression bool
+	// skipChecksum lets downloads proceed when the file metadata has no checksum.
+	skipChecksum bool
+	// onDownloadProgress receives the bytes written by downloads.
+	onDownloadProgress ProgressFunc
+	// freshIdempotencyKey makes WithAutoIdempotency use a random key instead of one
+	// derived from the request.
+	freshIdempotencyKey bool
+}
+
+type requestSettingsKey struct{}
+
+// newRequest creates an API request bound to ctx and applies the request options.
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*http.Request, error) {
 	normalizedPath := normalizePath(path)
-	if !isPublicPath(normalizedPath) && strings.TrimSpace(c.orgID) == "" {
+	if !isPublicPath(normalizedPath) && strings.TrimSpace(c.orgID) == "" && c.tokenSource == nil {
 		return nil, errors.New("reevit: orgID is required for authenticated requests")
 	}
 	u := fmt.Sprintf("%s%s", strings.TrimRight(c.baseURL, "/"), normalizedPath)
 
 	var buf io.ReadWriter
 	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
+		encoded := new(bytes.Buffer)
+		err := json.NewEncoder(encoded).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+		redacted, err := c.redactBody(encoded.Bytes())
 		if err != nil {
 			return nil, err
 		}
+		buf = bytes.NewBuffer(redacted)
 	}
 
-	req, err := http.NewRequest(method, u, buf)
+	ctx = context.WithValue(ctx, requestSettingsKey{}, &requestSettings{})
+	req, err := http.NewRequestWithContext(ctx, method, u, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -125,89 +257,166 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgentHeader())
 	req.Header.Set("X-Reevit-Client", "@reevit/go")
-	req.Header.Set("X-Reevit-Client-Version", "0.9.1")
-	if strings.TrimSpace(c.apiKey) != "" {
-		req.Header.Set("X-Reevit-Key", c.apiKey)
+	req.Header.Set("X-Reevit-Client-Version", Version)
+	if c.appInfo != nil {
+		setHeader(req.Header, "X-Reevit-App-Name", c.appInfo.Name)
+		setHeader(req.Header, "X-Reevit-App-Version", c.appInfo.Version)
+		setHeader(req.Header, "X-Reevit-App-URL", c.appInfo.URL)
+	}
+	if err := c.setAuthorization(ctx, req); err != nil {
+		return nil, err
 	}
 	if !isPublicPath(normalizedPath) && strings.TrimSpace(c.orgID) != "" {
 		req.Header.Set("X-Org-Id", c.orgID)
 	}
 
+	for _, opt := range opts {
+		opt(req)
+	}
+	if c.autoIdempotency && isMutatingMethod(method) && req.Header.Get("Idempotency-Key") == "" {
+		req.Header.Set("Idempotency-Key", c.autoIdempotencyKey(req, normalizedPath, body))
+	}
+
 	return req, nil
 }
 
 // do executes an API request.
-func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error {
-	body, err := c.doRaw(ctx, req)
+func (c *Client) do(req *http.Request, v interface{}) error {
+	body, err := c.doRaw(req)
 	if err != nil {
 		return err
 	}
 	if v == nil || len(body) == 0 {
 		return nil
 	}
-	return json.Unmarshal(body, v)
+	return c.decodeJSON(body, v)
 }
 
-func (c *Client) doRaw(ctx context.Context, req *http.Request) ([]byte, error) {
-	req = req.WithContext(ctx)
+func (c *Client) doRaw(req *http.Request) ([]byte, error) {
+	var timeout time.Duration
+	if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok {
+		timeout = settings.timeout
+	}
+	if timeout <= 0 && c.adaptiveTimeout != nil {
+		timeout = c.timeoutFor(req)
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	c.retryStats.requests.Add(1)
+	if c.retryBudget != nil {
+		c.retryBudget.recordRequest()
+	}
+
+	uncompressed := req
+	req, compressed, err := c.compressRequest(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, readErr
-	}
-
-	// Check for API errors
-	if resp.StatusCode >= 400 {
-		payload := struct {
-			Code    string                 `json:"code"`
-			Message string                 `json:"message"`
-			Details map[string]interface{} `json:"details"`
-		}{}
-		message := strings.TrimSpace(string(bodyBytes))
-		if err := json.Unmarshal(bodyBytes, &payload); err == nil {
-			if payload.Message != "" {
-				message = payload.Message
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(req.Context()); err != nil {
+				return nil, err
 			}
-			return nil, &APIError{
-				StatusCode: resp.StatusCode,
-				Code:       payload.Code,
-				Message:    message,
-				Details:    payload.Details,
+		}
+		if limiter := pacing(req.Context()); limiter != nil {
+			if err := limiter.wait(req.Context()); err != nil {
+				return nil, err
 			}
 		}
-		if message == "" {
-			message = resp.Status
+
+		resp, body, err := c.send(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnsupportedMediaType && compressed {
+			// The API does not accept this encoding; fall back to plain bodies for good.
+			c.compressionRejected.Store(true)
+			compressed = false
+			if req, err = rewind(uncompressed); err != nil {
+				return nil, err
+			}
+			continue
 		}
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    message,
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < c.maxRetries {
+			if c.retryBudget != nil && !c.retryBudget.allowRetry(endpointKey(req)) {
+				c.retryStats.budgetExhausted.Add(1)
+				return nil, &RetryBudgetExhaustedError{Endpoint: endpointKey(req), Err: newError(resp, body)}
+			}
+			c.retryStats.retries.Add(1)
+			delay := retryDelay(resp, attempt)
+			if c.limiter != nil {
+				c.limiter.pause(delay)
+			}
+			if limiter := pacing(req.Context()); limiter != nil {
+				limiter.pause(delay)
+			}
+			if err := sleep(req.Context(), delay); err != nil {
+				return nil, err
+			}
+			if req, err = rewind(req); err != nil {
+				return nil, err
+			}
+			continue
 		}
+
+		c.checkDeprecation(resp, body)
+
+		// Check for API errors
+		if resp.StatusCode >= 400 {
+			return nil, newError(resp, body)
+		}
+		if resp.StatusCode == http.StatusNoContent {
+			return nil, nil
+		}
+		return body, nil
 	}
-	if resp.StatusCode == http.StatusNoContent {
-		return nil, nil
-	}
-	return bodyBytes, nil
 }
 
-// APIError represents a Reevit API error.
-type APIError struct {
-	StatusCode int
-	Code       string
-	Message    string
-	Details    map[string]interface{}
-}
+// send performs a single attempt of req and returns the response with its body read.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	for _, middleware := range c.requestMiddleware {
+		if err := middleware(req); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.signingSecret != nil {
+		if err := c.signRequest(req); err != nil {
+			return nil, nil, err
+		}
+	}
 
-func (e *APIError) Error() string {
-	if e.Code != "" {
-		return fmt.Sprintf("reevit: request failed with status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	c.logRequest(req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.latency.record(req, nil, err, time.Since(start))
+		c.logResponse(req, nil, nil, start, err)
+		return nil, nil, err
 	}
-	return fmt.Sprintf("reevit: request failed with status %d: %s", e.StatusCode, e.Message)
+	defer resp.Body.Close()
+
+	captureResponse(req.Context(), resp)
+
+	bodyBytes, readErr := io.ReadAll(resp.Body)
+	c.latency.record(req, resp, readErr, time.Since(start))
+	c.logResponse(req, resp, bodyBytes, start, readErr)
+	if readErr != nil {
+		return nil, nil, readErr
+	}
+
+	for _, middleware := range c.responseMiddleware {
+		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		if err := middleware(resp); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return resp, bodyBytes, nil
 }
@@ -0,0 +1,893 @@
+package reevit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Trace-Echo", r.Header.Get("X-Trace-Id"))
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	var audited string
+	client := NewClient("pfk_test", "org_1",
+		WithBaseURL(server.URL),
+		WithRequestMiddleware(func(req *http.Request) error {
+			req.Header.Set("X-Trace-Id", "trace_1")
+			return nil
+		}),
+		WithResponseMiddleware(func(resp *http.Response) error {
+			body, err := io.ReadAll(resp.Body)
+			audited = resp.Header.Get("X-Trace-Echo") + " " + string(body)
+			return err
+		}),
+	)
+
+	payment, err := client.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	require.Equal(t, "pay_1", payment.ID)
+	require.Equal(t, `trace_1 {"id":"pay_1"}`, audited)
+
+	rejecting := NewClient("pfk_test", "org_1",
+		WithBaseURL(server.URL),
+		WithRequestMiddleware(func(req *http.Request) error { return errors.New("blocked") }),
+	)
+	_, err = rejecting.Payments.Get(context.Background(), "pay_1")
+	require.EqualError(t, err, "blocked")
+}
+
+type recordingLogger struct {
+	entries []map[string]interface{}
+	levels  []LogLevel
+}
+
+func (l *recordingLogger) Log(ctx context.Context, level LogLevel, msg string, fields map[string]interface{}) {
+	l.levels = append(l.levels, level)
+	l.entries = append(l.entries, fields)
+}
+
+func TestClientLoggerRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req_1")
+		_, _ = w.Write([]byte(`{"id":"conn_1","provider":"paystack"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient("pfk_secret", "org_1", WithBaseURL(server.URL), WithLogger(logger, LogLevelDebug))
+
+	_, err := client.Connections.Create(context.Background(), &ConnectionRequest{
+		Provider:    "paystack",
+		Credentials: map[string]interface{}{"secret_key": "sk_live_1"},
+		Labels:      []string{"4111 1111 1111 1111"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []LogLevel{LogLevelDebug, LogLevelInfo}, logger.levels)
+	request := logger.entries[0]
+	require.Equal(t, redactedValue, request["headers"].(map[string]string)["X-Reevit-Key"])
+	body := request["body"].(map[string]interface{})
+	require.Equal(t, redactedValue, body["credentials"])
+	require.Equal(t, []interface{}{redactedValue}, body["labels"])
+
+	response := logger.entries[1]
+	require.Equal(t, http.StatusOK, response["status"])
+	require.Equal(t, "req_1", response["request_id"])
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.CreateIntent(context.Background(), &PaymentIntentRequest{Amount: 100, Currency: "GHS"}, WithRequestTimeout(20*time.Millisecond))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRequestOptionsOnReads(t *testing.T) {
+	var key, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.List(context.Background(), &PaymentListOptions{Status: "succeeded"}, WithIdempotencyKey("key_1"))
+	require.NoError(t, err)
+	require.Equal(t, "key_1", key)
+	require.Equal(t, "status=succeeded", query)
+
+	_, err = client.Payments.List(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, query)
+}
+
+func TestPathSegmentsAreEscaped(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.Get(context.Background(), "../refunds/ref_1?expand=all")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/payments/..%2Frefunds%2Fref_1%3Fexpand=all", path)
+	require.Empty(t, query)
+
+	_, err = client.Payments.Get(context.Background(), "..")
+	require.NoError(t, err)
+	require.Equal(t, "/v1/payments/%2E%2E", path)
+}
+
+func TestResponseFromContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req_1")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Reset", "1735689600")
+		if r.URL.Path == "/v1/payments/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"payment not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	require.Nil(t, ResponseFromContext(context.Background()))
+
+	ctx := WithResponseCapture(context.Background())
+	_, err := client.Payments.Get(ctx, "pay_1")
+	require.NoError(t, err)
+	response := ResponseFromContext(ctx)
+	require.NotNil(t, response)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "req_1", response.RequestID)
+	require.Equal(t, 100, response.RateLimit.Limit)
+	require.Equal(t, 99, response.RateLimit.Remaining)
+	require.Equal(t, time.Unix(1735689600, 0), response.RateLimit.Reset)
+
+	_, err = client.Payments.Get(ctx, "missing")
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, ResponseFromContext(ctx).StatusCode)
+}
+
+func TestImportByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/connections/conn_1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"connection not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"conn_1","provider":"paystack","status":"active"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	connection, err := client.Connections.ImportByID(context.Background(), " conn_1 ")
+	require.NoError(t, err)
+	require.Equal(t, "conn_1", connection.ID)
+
+	_, err = client.Connections.ImportByID(context.Background(), "conn_2")
+	require.True(t, IsNotFound(err))
+	require.Contains(t, err.Error(), `connection "conn_2" does not exist`)
+
+	_, err = client.Connections.ImportByID(context.Background(), "")
+	require.EqualError(t, err, "reevit: connection ID is required")
+}
+
+func TestWebhookEndpoints(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/webhook-endpoints":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_, _ = w.Write([]byte(`{"id":"we_1","url":"https://example.com/hooks","status":"enabled","events":["payment.succeeded"],"secret":"whsec_1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/webhook-endpoints":
+			_, _ = w.Write([]byte(`{"endpoints":[{"id":"we_1","url":"https://example.com/hooks","status":"enabled"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/webhook-endpoints/we_1":
+			_, _ = w.Write([]byte(`{"id":"we_1","url":"https://example.com/hooks","status":"disabled"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/webhook-endpoints/we_1/rotate-secret":
+			_, _ = w.Write([]byte(`{"endpoint_id":"we_1","secret":"whsec_2","previous_secret_expires_at":"2026-01-02T00:00:00Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/webhook-endpoints/we_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"webhook endpoint not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	endpoint, err := client.WebhookEndpoints.Create(ctx, &WebhookEndpointRequest{URL: "https://example.com/hooks", Events: []string{"payment.succeeded"}})
+	require.NoError(t, err)
+	require.Equal(t, "whsec_1", endpoint.Secret)
+	require.Equal(t, []interface{}{"payment.succeeded"}, body["events"])
+
+	endpoints, err := client.WebhookEndpoints.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	require.Empty(t, endpoints[0].Secret)
+
+	endpoint, err = client.WebhookEndpoints.Update(ctx, "we_1", &WebhookEndpointUpdateRequest{Status: "disabled"})
+	require.NoError(t, err)
+	require.Equal(t, "disabled", endpoint.Status)
+
+	rotation, err := client.WebhookEndpoints.RotateSecret(ctx, "we_1")
+	require.NoError(t, err)
+	require.Equal(t, "whsec_2", rotation.Secret)
+	require.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), rotation.PreviousSecretExpiresAt)
+
+	require.NoError(t, client.WebhookEndpoints.Delete(ctx, "we_1"))
+
+	_, err = client.WebhookEndpoints.ImportByID(ctx, "we_1")
+	require.True(t, IsNotFound(err))
+	require.Contains(t, err.Error(), `webhook endpoint "we_1" does not exist`)
+
+	require.Equal(t, []string{
+		"POST /v1/webhook-endpoints",
+		"GET /v1/webhook-endpoints",
+		"PATCH /v1/webhook-endpoints/we_1",
+		"POST /v1/webhook-endpoints/we_1/rotate-secret",
+		"DELETE /v1/webhook-endpoints/we_1",
+		"GET /v1/webhook-endpoints/we_1",
+	}, requests)
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	var query, redeliverPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			require.Equal(t, "/v1/webhook-endpoints/we_1/deliveries", r.URL.Path)
+			query = r.URL.RawQuery
+			_, _ = w.Write([]byte(`{"deliveries":[{"id":"whd_1","endpoint_id":"we_1","event_id":"evt_1","event_type":"payment.succeeded","status":"failed","attempt_count":5,"response_code":500,"response_body":"oops"}]}`))
+		case http.MethodPost:
+			redeliverPath = r.URL.Path
+			_, _ = w.Write([]byte(`{"id":"whd_2","endpoint_id":"we_1","event_id":"evt_1","status":"pending","attempt_count":0}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	deliveries, err := client.WebhookEndpoints.ListDeliveries(ctx, "we_1", &WebhookDeliveryListOptions{Status: "failed", Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, "limit=20&status=failed", query)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, 500, deliveries[0].ResponseCode)
+	require.Equal(t, "payment.succeeded", deliveries[0].EventType)
+
+	delivery, err := client.WebhookEndpoints.Redeliver(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "/v1/webhook-deliveries/whd_1/redeliver", redeliverPath)
+	require.Equal(t, "whd_2", delivery.ID)
+	require.Equal(t, "pending", delivery.Status)
+}
+
+func TestNotificationsUpdateAll(t *testing.T) {
+	var method, path string
+	var body map[string]map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"settings":[{"type":"payment_receipt","enabled":true,"channels":["email","sms"]},{"type":"payout_sent","enabled":false,"channels":["email"],"recipients":["finance@example.com"]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	enabled, disabled := true, false
+	settings, err := client.Notifications.UpdateAll(context.Background(), map[NotificationType]*NotificationSettingUpdate{
+		NotificationPaymentReceipt: {Enabled: &enabled, Channels: []NotificationChannel{NotificationEmail, NotificationSMS}},
+		NotificationPayoutSent:     {Enabled: &disabled, Recipients: []string{"finance@example.com"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPatch, method)
+	require.Equal(t, "/v1/notifications/settings", path)
+	require.Equal(t, true, body["settings"]["payment_receipt"]["enabled"])
+	require.Equal(t, false, body["settings"]["payout_sent"]["enabled"])
+	require.Equal(t, []interface{}{"finance@example.com"}, body["settings"]["payout_sent"]["recipients"])
+
+	require.Len(t, settings, 2)
+	require.True(t, settings[0].SendsVia(NotificationSMS))
+	require.False(t, settings[1].SendsVia(NotificationEmail))
+}
+
+func TestCustomDomainsWaitUntilActive(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch len(requests) {
+		case 1:
+			_, _ = w.Write([]byte(`{"id":"dom_1","domain":"pay.example.com","status":"pending","dns_records":[{"type":"TXT","name":"_reevit.pay.example.com","value":"reevit-verify=abc"},{"type":"CNAME","name":"pay.example.com","value":"hosted.reevit.io"}]}`))
+		case 2:
+			_, _ = w.Write([]byte(`{"id":"dom_1","domain":"pay.example.com","status":"provisioning","tls_status":"pending"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"dom_1","domain":"pay.example.com","status":"active","tls_status":"issued"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	domain, err := client.CustomDomains.WaitUntilActive(context.Background(), "dom_1", time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, CustomDomainActive, domain.Status)
+	require.Equal(t, "issued", domain.TLSStatus)
+	require.Equal(t, []string{
+		"POST /v1/custom-domains/dom_1/verify",
+		"POST /v1/custom-domains/dom_1/verify",
+		"GET /v1/custom-domains/dom_1",
+	}, requests)
+
+	pending := &CustomDomain{DNSRecords: []DNSRecord{{Type: "CNAME", Value: "hosted.reevit.io"}, {Type: "TXT", Value: "reevit-verify=abc"}}}
+	require.Equal(t, "reevit-verify=abc", pending.VerificationToken())
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"dom_2","domain":"shop.example.com","status":"failed","failure_reason":"CAA record forbids certificate issuance"}`))
+	}))
+	defer failing.Close()
+
+	client = NewClient("pfk_test", "org_1", WithBaseURL(failing.URL))
+	domain, err = client.CustomDomains.WaitUntilActive(context.Background(), "dom_2", time.Millisecond)
+	require.ErrorIs(t, err, ErrCustomDomainFailed)
+	require.Contains(t, err.Error(), "CAA record")
+	require.Equal(t, "dom_2", domain.ID)
+
+	_, err = client.CustomDomains.Create(context.Background(), &CustomDomainRequest{Domain: " "})
+	require.EqualError(t, err, "reevit: custom domain is required")
+}
+
+func TestFraudEvaluate(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"action":"block","risk_score":0.92,"matched_rules":[{"rule":"max_amount","action":"block","reason":"amount above 1000"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	decision, err := client.Fraud.Evaluate(context.Background(), &PaymentIntentRequest{
+		Amount:   5000,
+		Currency: "GHS",
+		Policy:   &FraudPolicyInput{MaxAmount: 1000},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/v1/policies/fraud/evaluate", path)
+	require.Equal(t, FraudActionBlock, decision.Action)
+	require.Equal(t, "max_amount", decision.MatchedRules[0].Rule)
+}
+
+func TestFraudBlockList(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(bytes.TrimSpace(body)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"entries":[{"id":"blk_1","type":"bin","value":"412345"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"blk_1","type":"bin","value":"412345"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	entry, err := client.Fraud.AddBlockedBIN(ctx, "412345", "")
+	require.NoError(t, err)
+	require.Equal(t, "blk_1", entry.ID)
+	_, err = client.Fraud.BlockEmailDomain(ctx, "@Mailinator.com", "disposable")
+	require.NoError(t, err)
+	require.NoError(t, client.Fraud.RemoveBlockedBIN(ctx, "412345"))
+	entries, err := client.Fraud.ListBlockEntries(ctx, &BlockEntryListOptions{Type: BlockTypeBIN, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.Equal(t, []string{
+		`POST /v1/policies/fraud/blocklist {"type":"bin","value":"412345"}`,
+		`POST /v1/policies/fraud/blocklist {"reason":"disposable","type":"email_domain","value":"mailinator.com"}`,
+		`DELETE /v1/policies/fraud/blocklist/bin/412345 `,
+		`GET /v1/policies/fraud/blocklist?limit=10&type=bin `,
+	}, requests)
+
+	_, err = client.Fraud.AddBlockedBIN(ctx, "41234x", "")
+	require.EqualError(t, err, `reevit: BIN must be 6 to 8 digits, got "41234x"`)
+}
+
+func TestPaymentSearch(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"data":[{"id":"pay_1","status":"failed"}],"facets":{"provider":[{"value":"paystack","count":12}]},"total_count":12,"has_more":true,"next_cursor":"cur_2"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	result, err := client.Payments.Search(context.Background(), "status:failed AND amount>5000", &PaymentSearchOptions{Limit: 1, Facets: []string{"provider"}})
+	require.NoError(t, err)
+	require.Equal(t, "status:failed AND amount>5000", query.Get("query"))
+	require.Equal(t, "provider", query.Get("facets"))
+	require.Equal(t, "pay_1", result.Data[0].ID)
+	require.Equal(t, int64(12), result.Facets["provider"][0].Count)
+	require.Equal(t, "cur_2", result.NextCursor)
+
+	_, err = client.Payments.Search(context.Background(), " ", nil)
+	require.Error(t, err)
+}
+
+func TestTransferSchedules(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.RequestURI())
+		if strings.HasSuffix(r.URL.Path, "/executions") {
+			_, _ = w.Write([]byte(`{"executions":[{"id":"tsex_1","schedule_id":"tsch_1","transfer_id":"tr_1","status":"succeeded"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"tsch_1","status":"paused","interval":"weekly","transfer":{"destination_id":"acct_9","amount":250000,"currency":"GHS"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+	schedule, err := client.TransferSchedules.Create(ctx, &TransferScheduleRequest{
+		Transfer: TransferRequest{DestinationID: "acct_9", Amount: 250000, Currency: "GHS"},
+		Interval: "weekly",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "acct_9", schedule.Transfer.DestinationID)
+	_, err = client.TransferSchedules.Pause(ctx, "tsch_1")
+	require.NoError(t, err)
+	executions, err := client.TransferSchedules.ListExecutions(ctx, "tsch_1", &TransferScheduleExecutionListOptions{Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, "tr_1", executions[0].TransferID)
+
+	require.Equal(t, []string{
+		"POST /v1/transfer-schedules",
+		"POST /v1/transfer-schedules/tsch_1/pause",
+		"GET /v1/transfer-schedules/tsch_1/executions?limit=5",
+	}, paths)
+}
+
+func TestRetrieve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payments/pay_1":
+			_, _ = w.Write([]byte(`{"id":"pay_1","status":"succeeded"}`))
+		case "/v1/refunds/rfnd_1":
+			_, _ = w.Write([]byte(`{"id":"rfnd_1","payment_id":"pay_1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	object, err := client.Retrieve(context.Background(), "pay_1")
+	require.NoError(t, err)
+	payment, ok := object.(*Payment)
+	require.True(t, ok)
+	require.Equal(t, PaymentStatusSucceeded, payment.Status)
+
+	object, err = client.Retrieve(context.Background(), " rfnd_1 ")
+	require.NoError(t, err)
+	require.Equal(t, "pay_1", object.(*Refund).PaymentID)
+
+	object, err = client.Retrieve(context.Background(), "sub_missing")
+	require.True(t, IsNotFound(err))
+	require.Nil(t, object)
+
+	_, err = client.Retrieve(context.Background(), "cus_1")
+	require.ErrorIs(t, err, ErrUnknownIDPrefix)
+}
+
+func TestConfirmWithParams(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/payments/pay_1/confirm", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"processing"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	payment, err := client.Payments.ConfirmWithParams(context.Background(), "pay_1", &ConfirmRequest{
+		OTP:     "123456",
+		ThreeDS: &ThreeDSResult{Status: "Y", ECI: "05"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentStatusProcessing, payment.Status)
+
+	_, err = client.Payments.Confirm(context.Background(), "pay_1")
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"otp":"123456","three_ds":{"status":"Y","eci":"05"}}`, `{}`}, bodies)
+}
+
+func TestTransfers(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.RequestURI())
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"transfers":[{"id":"tr_1","payment_id":"pay_1","destination_id":"acct_9","amount":4000}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"tr_1","destination_id":"acct_9","amount":4000,"amount_reversed":1000}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+	transfers, err := client.Transfers.ListForPayment(ctx, "pay_1")
+	require.NoError(t, err)
+	require.Equal(t, "pay_1", transfers[0].PaymentID)
+	_, err = client.Transfers.List(ctx, &TransferListOptions{DestinationID: "acct_9"})
+	require.NoError(t, err)
+	transfer, err := client.Transfers.Reverse(ctx, "tr_1", &TransferReversalRequest{Amount: 1000})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), transfer.AmountReversed)
+
+	require.Equal(t, []string{
+		"GET /v1/payments/pay_1/transfers",
+		"GET /v1/transfers?destination_id=acct_9",
+		"POST /v1/transfers/tr_1/reverse",
+	}, paths)
+
+	_, err = client.Payments.CreateIntent(ctx, &PaymentIntentRequest{Amount: 10000, Currency: "GHS", Split: &SplitConfig{
+		Splits: []Split{{DestinationID: "acct_1", Amount: 6000}, {DestinationID: "acct_2", BasisPoints: 5000}},
+	}})
+	var validation *ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.FieldErrors("split.splits"), 1)
+	require.Len(t, paths, 3)
+}
+
+func TestPreconnect(t *testing.T) {
+	var opened atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			require.Equal(t, http.MethodHead, r.Method)
+			require.Empty(t, r.Header.Get("X-Reevit-Key"))
+			w.WriteHeader(http.StatusNotFound)
+		case "/v1/policies/fraud":
+			_, _ = w.Write([]byte(`{"max_amount":500000}`))
+		default:
+			_, _ = w.Write([]byte(`{"connections":[{"id":"conn_1"}]}`))
+		}
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			opened.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	result, err := client.Preconnect(context.Background(), &PreconnectOptions{Connections: 2, Prefetch: true})
+	require.NoError(t, err)
+	require.Equal(t, "conn_1", result.Connections[0].ID)
+	require.NotNil(t, result.FraudPolicy)
+	require.LessOrEqual(t, opened.Load(), int32(2))
+
+	before := opened.Load()
+	_, err = client.Connections.List(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, before, opened.Load())
+}
+
+func TestAppInfo(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL),
+		WithAppInfo("ShopPlugin", "2.1.0", "https://example.com"),
+		WithUserAgentSuffix("checkout-svc"),
+	)
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	require.Equal(t, "@reevit/go v"+Version+" ShopPlugin/2.1.0 (+https://example.com) checkout-svc", header.Get("User-Agent"))
+	require.Equal(t, "ShopPlugin", header.Get("X-Reevit-App-Name"))
+	require.Equal(t, "2.1.0", header.Get("X-Reevit-App-Version"))
+	require.Equal(t, "https://example.com", header.Get("X-Reevit-App-URL"))
+}
+
+func TestAPIKeyRotation(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-Reevit-Key"))
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test_old", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	client.SetAPIKey("pfk_test_new")
+	_, err = client.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"pfk_test_old", "pfk_test_new"}, keys)
+
+	rotated := errors.New("secret not found")
+	client = NewClient("", "org_1", WithBaseURL(server.URL),
+		WithCredentialsProvider(CredentialsProviderFunc(func(ctx context.Context) (string, error) {
+			return "", rotated
+		})),
+	)
+	_, err = client.Payments.Get(context.Background(), "pay_1")
+	require.ErrorIs(t, err, rotated)
+	require.Len(t, keys, 2)
+}
+
+func TestOAuthToken(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("", "", WithBaseURL(server.URL),
+		WithOAuthToken(TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "at_123", nil
+		})),
+	)
+	_, err := client.Payments.Get(context.Background(), "pay_1", WithOrgOverride("org_connected"))
+	require.NoError(t, err)
+	require.Equal(t, "Bearer at_123", header.Get("Authorization"))
+	require.Equal(t, "org_connected", header.Get("Reevit-Account"))
+	require.Empty(t, header.Get("X-Reevit-Key"))
+	require.Empty(t, header.Get("X-Org-Id"))
+
+	expired := errors.New("refresh token revoked")
+	client = NewClient("", "", WithBaseURL(server.URL),
+		WithOAuthToken(TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "", expired
+		})),
+	)
+	_, err = client.Payments.Get(context.Background(), "pay_1")
+	require.ErrorIs(t, err, expired)
+
+	// An API key client acting for a connected org does not also name its own org.
+	client = NewClient("pfk_test", "org_platform", WithBaseURL(server.URL))
+	_, err = client.Payments.Get(context.Background(), "pay_1", WithOrgOverride("org_connected"))
+	require.NoError(t, err)
+	require.Equal(t, "org_connected", header.Get("Reevit-Account"))
+	require.Equal(t, "org_connected", header.Get("X-Org-Id"))
+}
+
+func TestRequestSigning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get("X-Reevit-Timestamp")
+		want := "sha256=" + requestSignature([]byte("whsec_sign"), r.Method, r.URL.RequestURI(), timestamp, body)
+		if timestamp == "" || r.Header.Get("X-Reevit-Signature") != want {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v1/payments" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithRequestSigning("whsec_sign"))
+	_, err := client.Payments.CreateIntent(context.Background(), &PaymentIntentRequest{Amount: 100, Currency: "GHS"})
+	require.NoError(t, err)
+	_, err = client.Payments.List(context.Background(), &PaymentListOptions{Limit: 5})
+	require.NoError(t, err)
+
+	client = NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithRequestSigning("wrong"))
+	_, err = client.Payments.Get(context.Background(), "pay_1")
+	var authErr *AuthenticationError
+	require.ErrorAs(t, err, &authErr)
+}
+
+func TestWithHeaders(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.Get(context.Background(), "pay_1",
+		WithHeader("X-Trace-Id", "trace-1"),
+		WithHeaders(map[string]string{"X-Feature-Flag": "new-routing", "X-Partner": "acme"}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "trace-1", header.Get("X-Trace-Id"))
+	require.Equal(t, "new-routing", header.Get("X-Feature-Flag"))
+	require.Equal(t, "acme", header.Get("X-Partner"))
+	require.Equal(t, "pfk_test", header.Get("X-Reevit-Key"))
+}
+
+func TestRawRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Reevit-Key") != "pfk_test" || r.Header.Get("Idempotency-Key") != "key_1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v1/payouts/missing" {
+			w.Header().Set("X-Request-ID", "req_404")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"no such payout"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Request-ID", "req_1")
+		_, _ = w.Write([]byte(`{"id":"po_1","echo":` + string(body) + `,"limit":"` + r.URL.Query().Get("limit") + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	req, err := client.NewRequest(http.MethodPost, "/v1/payouts?limit=5", map[string]int{"amount": 100})
+	require.NoError(t, err)
+	WithIdempotencyKey("key_1")(req)
+
+	var result struct {
+		ID    string         `json:"id"`
+		Echo  map[string]int `json:"echo"`
+		Limit string         `json:"limit"`
+	}
+	resp, err := client.Do(context.Background(), req, &result)
+	require.NoError(t, err)
+	require.Equal(t, "req_1", resp.RequestID)
+	require.Equal(t, "po_1", result.ID)
+	require.Equal(t, 100, result.Echo["amount"])
+	require.Equal(t, "5", result.Limit)
+
+	req, err = client.NewRequest(http.MethodGet, "/v1/payouts/missing", nil)
+	require.NoError(t, err)
+	WithIdempotencyKey("key_1")(req)
+	resp, err = client.Do(context.Background(), req, nil)
+	require.True(t, IsNotFound(err))
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, "req_404", resp.RequestID)
+}
+
+func TestPaymentsListEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/payments/pay_1/events", r.URL.Path)
+		_, _ = w.Write([]byte(`{"events":[
+			{"id":"pevt_1","payment_id":"pay_1","type":"created","status":"pending","actor":{"type":"api_key","id":"key_1"},"occurred_at":"2026-03-01T10:00:00Z"},
+			{"id":"pevt_2","payment_id":"pay_1","type":"provider_attempted","status":"failed","connection_id":"conn_1","provider":"paystack","failure_code":"insufficient_funds","actor":{"type":"provider","id":"paystack"},"occurred_at":"2026-03-01T10:00:05Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	events, err := client.Payments.ListEvents(context.Background(), "pay_1")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, PaymentEventCreated, events[0].Type)
+	require.Equal(t, PaymentEventActor{Type: "api_key", ID: "key_1"}, events[0].Actor)
+	require.Equal(t, PaymentEventProviderAttempted, events[1].Type)
+	require.Equal(t, ProviderPaystack, events[1].Provider)
+	require.Equal(t, "insufficient_funds", events[1].FailureCode)
+	require.Equal(t, time.Date(2026, 3, 1, 10, 0, 5, 0, time.UTC), events[1].OccurredAt)
+
+	_, err = client.Payments.ListEvents(context.Background(), "cus_1")
+	require.ErrorIs(t, err, ErrWrongIDKind)
+}
+
+func TestRequestCompression(t *testing.T) {
+	var encodings []string
+	rejectGzip := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		encodings = append(encodings, encoding)
+		body := io.Reader(r.Body)
+		if encoding == "gzip" {
+			if rejectGzip {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			reader, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = reader
+		}
+		var req PaymentIntentRequest
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		_, _ = w.Write([]byte(`{"id":"pay_1","amount":` + strconv.FormatInt(req.Amount, 10) + `}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	large := &PaymentIntentRequest{Amount: 100, Currency: "GHS", Metadata: map[string]interface{}{"note": strings.Repeat("a", 2048)}}
+	small := &PaymentIntentRequest{Amount: 100, Currency: "GHS"}
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithRequestCompression(1024, nil))
+
+	payment, err := client.Payments.CreateIntent(ctx, large)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), payment.Amount)
+	_, err = client.Payments.CreateIntent(ctx, small)
+	require.NoError(t, err)
+	_, err = client.Payments.CreateIntent(ctx, large, WithoutCompression())
+	require.NoError(t, err)
+	require.Equal(t, []string{"gzip", "", ""}, encodings)
+
+	encodings, rejectGzip = nil, true
+	_, err = client.Payments.CreateIntent(ctx, large)
+	require.NoError(t, err)
+	_, err = client.Payments.CreateIntent(ctx, large)
+	require.NoError(t, err)
+	require.Equal(t, []string{"gzip", "", ""}, encodings)
+}
+
+func TestPaymentsSimulateRoute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v1/payments/route-simulations", r.URL.Path)
+		var req PaymentIntentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, MethodCard, req.Method)
+		_, _ = w.Write([]byte(`{
+			"candidates":[
+				{"connection_id":"conn_2","provider":"flutterwave","labels":["primary"],"score":0.92,"reasons":["label:primary","success_rate:0.97"]},
+				{"connection_id":"conn_1","provider":"paystack","score":0.71,"reasons":["fallback"]}
+			],
+			"excluded":[{"connection_id":"conn_3","provider":"hubtel","reasons":["excluded:method_not_supported"]}],
+			"matched_rules":["rule_1"]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	simulation, err := client.Payments.SimulateRoute(context.Background(), &PaymentIntentRequest{Amount: 1000, Currency: "GHS", Method: MethodCard})
+	require.NoError(t, err)
+	require.Len(t, simulation.Candidates, 2)
+	require.Equal(t, "conn_2", simulation.Candidates[0].ConnectionID)
+	require.Equal(t, 0.92, simulation.Candidates[0].Score)
+	require.Equal(t, []string{"excluded:method_not_supported"}, simulation.Excluded[0].Reasons)
+	require.Equal(t, []string{"rule_1"}, simulation.MatchedRules)
+
+	_, err = client.Payments.SimulateRoute(context.Background(), &PaymentIntentRequest{Currency: "GHS"})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
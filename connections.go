@@ -2,7 +2,7 @@ package reevit
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"time"
@@ -13,32 +13,36 @@ type ConnectionsService service
 
 // ConnectionRequest represents a request to create a connection.
 type ConnectionRequest struct {
-	Provider     string                 `json:"provider"`
+	Provider     Provider               `json:"provider"`
 	Mode         string                 `json:"mode"`
 	Credentials  map[string]interface{} `json:"credentials"`
-	Capabilities map[string]interface{} `json:"capabilities,omitempty"`
+	Capabilities *Capabilities          `json:"capabilities,omitempty"`
 	RoutingHints *RoutingHints          `json:"routing_hints,omitempty"`
 	Labels       []string               `json:"labels,omitempty"`
 }
 
 // Connection represents a connection object.
 type Connection struct {
-	ID           string                 `json:"id"`
-	Provider     string                 `json:"provider"`
-	Mode         string                 `json:"mode"`
-	Status       string                 `json:"status"`
-	Capabilities map[string]interface{} `json:"capabilities"`
-	RoutingHints *RoutingHints          `json:"routing_hints"`
-	Labels       []string               `json:"labels"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
+	ID           string        `json:"id"`
+	Provider     Provider      `json:"provider"`
+	Mode         string        `json:"mode"`
+	Status       string        `json:"status"`
+	Capabilities *Capabilities `json:"capabilities"`
+	RoutingHints *RoutingHints `json:"routing_hints"`
+	Labels       []string      `json:"labels"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
 }
 
 // ConnectionListOptions contains filters for connection listing.
 type ConnectionListOptions struct {
 	Limit    int
 	Offset   int
-	Provider string
+	Provider Provider
 	Mode     string
 	Status   string
 }
@@ -58,6 +62,16 @@ type ConnectionLabelsUpdate struct {
 	Labels []string `json:"labels"`
 }
 
+// ConnectionUpdateRequest updates the configuration of a connection in place.
+// Nil and empty fields are left unchanged.
+type ConnectionUpdateRequest struct {
+	Mode         string                 `json:"mode,omitempty"`
+	Credentials  map[string]interface{} `json:"credentials,omitempty"`
+	Capabilities *CapabilitiesUpdate    `json:"capabilities,omitempty"`
+	RoutingHints *RoutingHints          `json:"routing_hints,omitempty"`
+	Labels       []string               `json:"labels,omitempty"`
+}
+
 // ConnectionStatusUpdate updates a connection status.
 type ConnectionStatusUpdate struct {
 	Status string `json:"status"`
@@ -67,17 +81,17 @@ type ConnectionStatusUpdate struct {
 //
 // API Docs: POST /v1/connections
 func (s *ConnectionsService) Create(ctx context.Context, req *ConnectionRequest, opts ...RequestOption) (*Connection, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/connections", req)
-	if err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/connections", req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var connection Connection
-	if err := s.client.do(ctx, httpRequest, &connection); err != nil {
+	if err := s.client.do(httpRequest, &connection); err != nil {
 		return nil, err
 	}
 
@@ -87,77 +101,119 @@ func (s *ConnectionsService) Create(ctx context.Context, req *ConnectionRequest,
 // List returns a list of connections.
 //
 // API Docs: GET /v1/connections
-func (s *ConnectionsService) List(ctx context.Context, options ...ConnectionListOptions) ([]Connection, error) {
+func (s *ConnectionsService) List(ctx context.Context, options *ConnectionListOptions, opts ...RequestOption) ([]Connection, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
-		setString(values, "provider", options[0].Provider)
-		setString(values, "mode", options[0].Mode)
-		setString(values, "status", options[0].Status)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "provider", string(options.Provider))
+		setString(values, "mode", options.Mode)
+		setString(values, "status", options.Status)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/connections", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/connections", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[Connection](raw, "connections")
+	return decodeArrayResponse[Connection](s.client, raw, "connections")
+}
+
+// ListAutoPaging returns an iterator over all connections matching the filters,
+// fetching options.Limit connections per page.
+func (s *ConnectionsService) ListAutoPaging(ctx context.Context, options ConnectionListOptions, opts ...RequestOption) *Iter[Connection] {
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]Connection, error) {
+		page := options
+		page.Limit = limit
+		page.Offset = offset
+		return s.List(ctx, &page, opts...)
+	})
 }
 
 // Get retrieves a connection by ID.
 //
 // API Docs: GET /v1/connections/{id}
-func (s *ConnectionsService) Get(ctx context.Context, connectionID string) (*Connection, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/connections/%s", connectionID), nil)
+func (s *ConnectionsService) Get(ctx context.Context, connectionID string, opts ...RequestOption) (*Connection, error) {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/connections/%s", connectionID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var connection Connection
-	if err := s.client.do(ctx, httpRequest, &connection); err != nil {
+	if err := s.client.do(httpRequest, &connection); err != nil {
 		return nil, err
 	}
 
 	return &connection, nil
 }
 
+// Update updates a connection's configuration. The connection keeps its ID.
+//
+// API Docs: PATCH /v1/connections/{id}
+func (s *ConnectionsService) Update(ctx context.Context, connectionID string, req *ConnectionUpdateRequest, opts ...RequestOption) (*Connection, error) {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/connections/%s", connectionID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var connection Connection
+	if err := s.client.do(httpRequest, &connection); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+// ImportByID fetches an existing connection so it can be adopted by an external state
+// manager such as a Terraform provider.
+func (s *ConnectionsService) ImportByID(ctx context.Context, connectionID string, opts ...RequestOption) (*Connection, error) {
+	return importByID(ctx, "connection", connectionID, s.Get, opts...)
+}
+
 // Delete removes a connection.
 //
 // API Docs: DELETE /v1/connections/{id}
 func (s *ConnectionsService) Delete(ctx context.Context, connectionID string, opts ...RequestOption) error {
-	httpRequest, err := s.client.newRequest(http.MethodDelete, fmt.Sprintf("/v1/connections/%s", connectionID), nil)
-	if err != nil {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
 		return err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/connections/%s", connectionID), nil, opts...)
+	if err != nil {
+		return err
 	}
 
-	return s.client.do(ctx, httpRequest, nil)
+	return s.client.do(httpRequest, nil)
 }
 
 // Validate validates a connection configuration.
 //
 // API Docs: POST /v1/connections/{id}/validate
 func (s *ConnectionsService) Validate(ctx context.Context, connectionID string, opts ...RequestOption) (*Connection, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/connections/%s/validate", connectionID), map[string]interface{}{})
-	if err != nil {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/connections/%s/validate", connectionID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var connection Connection
-	if err := s.client.do(ctx, httpRequest, &connection); err != nil {
+	if err := s.client.do(httpRequest, &connection); err != nil {
 		return nil, err
 	}
 
@@ -167,41 +223,45 @@ func (s *ConnectionsService) Validate(ctx context.Context, connectionID string,
 // ListAudit returns the audit history for a connection.
 //
 // API Docs: GET /v1/connections/{id}/audit
-func (s *ConnectionsService) ListAudit(ctx context.Context, connectionID string, options ...ConnectionListOptions) ([]ConnectionAuditEntry, error) {
+func (s *ConnectionsService) ListAudit(ctx context.Context, connectionID string, options *ConnectionListOptions, opts ...RequestOption) ([]ConnectionAuditEntry, error) {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
+		return nil, err
+	}
+
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath(fmt.Sprintf("/v1/connections/%s/audit", connectionID), values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/connections/%s/audit", connectionID), values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[ConnectionAuditEntry](raw, "audit")
+	return decodeArrayResponse[ConnectionAuditEntry](s.client, raw, "audit")
 }
 
 // UpdateLabels updates connection labels.
 //
 // API Docs: PATCH /v1/connections/{id}/labels
 func (s *ConnectionsService) UpdateLabels(ctx context.Context, connectionID string, req *ConnectionLabelsUpdate, opts ...RequestOption) (*Connection, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/connections/%s/labels", connectionID), req)
-	if err != nil {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/connections/%s/labels", connectionID), req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var connection Connection
-	if err := s.client.do(ctx, httpRequest, &connection); err != nil {
+	if err := s.client.do(httpRequest, &connection); err != nil {
 		return nil, err
 	}
 
@@ -212,17 +272,17 @@ func (s *ConnectionsService) UpdateLabels(ctx context.Context, connectionID stri
 //
 // API Docs: PATCH /v1/connections/{id}/status
 func (s *ConnectionsService) UpdateStatus(ctx context.Context, connectionID string, req *ConnectionStatusUpdate, opts ...RequestOption) (*Connection, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/connections/%s/status", connectionID), req)
-	if err != nil {
+	if err := checkID(IDKindConnection, connectionID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/connections/%s/status", connectionID), req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var connection Connection
-	if err := s.client.do(ctx, httpRequest, &connection); err != nil {
+	if err := s.client.do(httpRequest, &connection); err != nil {
 		return nil, err
 	}
 
@@ -233,19 +293,15 @@ func (s *ConnectionsService) UpdateStatus(ctx context.Context, connectionID stri
 //
 // API Docs: POST /v1/connections/test
 func (s *ConnectionsService) Test(ctx context.Context, req *ConnectionRequest, opts ...RequestOption) (bool, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/connections/test", req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/connections/test", req, opts...)
 	if err != nil {
 		return false, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var result struct {
 		Success bool `json:"success"`
 	}
-	if err := s.client.do(ctx, httpRequest, &result); err != nil {
+	if err := s.client.do(httpRequest, &result); err != nil {
 		return false, err
 	}
 
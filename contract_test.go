@@ -0,0 +1,115 @@
+package reevit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Reevit-Platform/go-sdk/internal/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+// The contract tests keep the hand-written types in line with the API. The spec types
+// are generated from openapi/openapi.json with go generate ./internal/apitypes; the
+// fixtures in testdata/contract are responses recorded from the sandbox and request
+// bodies the sandbox accepted.
+
+var contractTypes = []struct {
+	sdk, spec interface{}
+	fixture   string
+	// wire, when set, is the struct sdk is encoded as by its MarshalJSON method.
+	wire interface{}
+}{
+	{Payment{}, apitypes.Payment{}, "payment.json", nil},
+	{Refund{}, apitypes.Refund{}, "refund.json", nil},
+	{Transfer{}, apitypes.Transfer{}, "transfer.json", nil},
+	{PaymentIntentRequest{}, apitypes.PaymentIntentRequest{}, "payment_intent_request.json", paymentIntentRequestJSON{}},
+	{RefundRequest{}, apitypes.RefundRequest{}, "refund_request.json", nil},
+	{TransferRequest{}, apitypes.TransferRequest{}, "transfer_request.json", nil},
+}
+
+func TestTypesMatchSpec(t *testing.T) {
+	for _, types := range contractTypes {
+		sdk, spec := reflect.TypeOf(types.sdk), reflect.TypeOf(types.spec)
+		name := sdk.Name()
+		if types.wire != nil {
+			sdk = reflect.TypeOf(types.wire)
+		}
+		t.Run(name, func(t *testing.T) {
+			compareStructs(t, name, sdk, spec)
+		})
+	}
+}
+
+func TestFixturesRoundTrip(t *testing.T) {
+	for _, types := range contractTypes {
+		t.Run(types.fixture, func(t *testing.T) {
+			fixture, err := os.ReadFile(filepath.Join("testdata", "contract", types.fixture))
+			require.NoError(t, err)
+
+			for _, typ := range []reflect.Type{reflect.TypeOf(types.spec), reflect.TypeOf(types.sdk)} {
+				value := reflect.New(typ).Interface()
+				decoder := json.NewDecoder(bytes.NewReader(fixture))
+				decoder.DisallowUnknownFields()
+				require.NoError(t, decoder.Decode(value), typ.String())
+				// SDK types with ExtraFields accept any field; check the fixture separately.
+				var tree interface{}
+				require.NoError(t, json.Unmarshal(fixture, &tree))
+				require.NoError(t, checkKnownFields(tree, typ), typ.String())
+
+				encoded, err := json.Marshal(value)
+				require.NoError(t, err)
+				var original, roundTripped map[string]interface{}
+				require.NoError(t, json.Unmarshal(fixture, &original))
+				require.NoError(t, json.Unmarshal(encoded, &roundTripped))
+				for key, want := range original {
+					require.Equal(t, want, roundTripped[key], "%s.%s", typ, key)
+				}
+			}
+		})
+	}
+}
+
+// compareStructs checks that sdk and spec have the same JSON fields with compatible types.
+func compareStructs(t *testing.T, path string, sdk, spec reflect.Type) {
+	sdkFields, specFields := jsonFields(sdk), jsonFields(spec)
+	for name, specField := range specFields {
+		sdkField, ok := sdkFields[name]
+		if !ok {
+			t.Errorf("%s.%s is in the spec but missing from the SDK type", path, name)
+			continue
+		}
+		compareTypes(t, path+"."+name, sdkField, specField)
+	}
+	for name := range sdkFields {
+		if _, ok := specFields[name]; !ok {
+			t.Errorf("%s.%s is in the SDK type but not in the spec", path, name)
+		}
+	}
+}
+
+func compareTypes(t *testing.T, path string, sdk, spec reflect.Type) {
+	if sdk.Kind() == reflect.Pointer && spec.Kind() == reflect.Pointer {
+		sdk, spec = sdk.Elem(), spec.Elem()
+	}
+	if sdk.Kind() != spec.Kind() {
+		t.Errorf("%s is %s in the SDK but %s in the spec", path, sdk, spec)
+		return
+	}
+	switch sdk.Kind() {
+	case reflect.Slice, reflect.Map:
+		compareTypes(t, path+"[]", sdk.Elem(), spec.Elem())
+	case reflect.Struct:
+		if sdk == reflect.TypeOf(time.Time{}) || spec == reflect.TypeOf(time.Time{}) {
+			if sdk != spec {
+				t.Errorf("%s is %s in the SDK but %s in the spec", path, sdk, spec)
+			}
+			return
+		}
+		compareStructs(t, path, sdk, spec)
+	}
+}
@@ -0,0 +1,165 @@
+package reevit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProviderCredentials are typed credentials for one PSP. Implementations validate their
+// required fields and convert themselves to the credentials object the API expects.
+type ProviderCredentials interface {
+	// Provider returns the PSP identifier, e.g. ProviderPaystack.
+	Provider() Provider
+	// Validate checks the credentials client-side before they are sent.
+	Validate() error
+	// Map returns the credentials in the shape of ConnectionRequest.Credentials.
+	Map() map[string]interface{}
+}
+
+// NewConnectionRequest builds a ConnectionRequest for creds in the given mode ("test" or
+// "live"), validating the credentials first.
+func NewConnectionRequest(creds ProviderCredentials, mode string) (*ConnectionRequest, error) {
+	if err := creds.Validate(); err != nil {
+		return nil, err
+	}
+	return &ConnectionRequest{
+		Provider:    creds.Provider(),
+		Mode:        mode,
+		Credentials: creds.Map(),
+	}, nil
+}
+
+// PaystackCredentials are the API keys of a Paystack integration.
+type PaystackCredentials struct {
+	SecretKey string
+	PublicKey string
+}
+
+// Provider implements ProviderCredentials.
+func (c PaystackCredentials) Provider() Provider { return ProviderPaystack }
+
+// Validate implements ProviderCredentials.
+func (c PaystackCredentials) Validate() error {
+	if err := requireCredentials(ProviderPaystack, "secret_key", c.SecretKey); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(c.SecretKey, "sk_") {
+		return fmt.Errorf("reevit: paystack secret_key must start with sk_")
+	}
+	return nil
+}
+
+// Map implements ProviderCredentials.
+func (c PaystackCredentials) Map() map[string]interface{} {
+	return credentialMap("secret_key", c.SecretKey, "public_key", c.PublicKey)
+}
+
+// FlutterwaveCredentials are the API keys of a Flutterwave integration.
+type FlutterwaveCredentials struct {
+	SecretKey     string
+	PublicKey     string
+	EncryptionKey string
+	// SecretHash is the value configured for the verif-hash webhook header.
+	SecretHash string
+}
+
+// Provider implements ProviderCredentials.
+func (c FlutterwaveCredentials) Provider() Provider { return ProviderFlutterwave }
+
+// Validate implements ProviderCredentials.
+func (c FlutterwaveCredentials) Validate() error {
+	return requireCredentials(ProviderFlutterwave, "secret_key", c.SecretKey)
+}
+
+// Map implements ProviderCredentials.
+func (c FlutterwaveCredentials) Map() map[string]interface{} {
+	return credentialMap(
+		"secret_key", c.SecretKey,
+		"public_key", c.PublicKey,
+		"encryption_key", c.EncryptionKey,
+		"secret_hash", c.SecretHash,
+	)
+}
+
+// HubtelCredentials are the API credentials of a Hubtel merchant account.
+type HubtelCredentials struct {
+	ClientID              string
+	ClientSecret          string
+	MerchantAccountNumber string
+}
+
+// Provider implements ProviderCredentials.
+func (c HubtelCredentials) Provider() Provider { return ProviderHubtel }
+
+// Validate implements ProviderCredentials.
+func (c HubtelCredentials) Validate() error {
+	return requireCredentials(ProviderHubtel,
+		"client_id", c.ClientID,
+		"client_secret", c.ClientSecret,
+		"merchant_account_number", c.MerchantAccountNumber,
+	)
+}
+
+// Map implements ProviderCredentials.
+func (c HubtelCredentials) Map() map[string]interface{} {
+	return credentialMap(
+		"client_id", c.ClientID,
+		"client_secret", c.ClientSecret,
+		"merchant_account_number", c.MerchantAccountNumber,
+	)
+}
+
+// StripeCredentials are the API keys of a Stripe account.
+type StripeCredentials struct {
+	// SecretKey is a secret (sk_) or restricted (rk_) key.
+	SecretKey      string
+	PublishableKey string
+	WebhookSecret  string
+}
+
+// Provider implements ProviderCredentials.
+func (c StripeCredentials) Provider() Provider { return ProviderStripe }
+
+// Validate implements ProviderCredentials.
+func (c StripeCredentials) Validate() error {
+	if err := requireCredentials(ProviderStripe, "secret_key", c.SecretKey); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(c.SecretKey, "sk_") && !strings.HasPrefix(c.SecretKey, "rk_") {
+		return fmt.Errorf("reevit: stripe secret_key must start with sk_ or rk_")
+	}
+	if c.WebhookSecret != "" && !strings.HasPrefix(c.WebhookSecret, "whsec_") {
+		return fmt.Errorf("reevit: stripe webhook_secret must start with whsec_")
+	}
+	return nil
+}
+
+// Map implements ProviderCredentials.
+func (c StripeCredentials) Map() map[string]interface{} {
+	return credentialMap(
+		"secret_key", c.SecretKey,
+		"publishable_key", c.PublishableKey,
+		"webhook_secret", c.WebhookSecret,
+	)
+}
+
+// requireCredentials reports the first blank field of name/value pairs.
+func requireCredentials(provider Provider, pairs ...string) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if strings.TrimSpace(pairs[i+1]) == "" {
+			return fmt.Errorf("reevit: %s credentials require %s", provider, pairs[i])
+		}
+	}
+	return nil
+}
+
+// credentialMap builds a credentials object from name/value pairs, skipping blank values.
+func credentialMap(pairs ...string) map[string]interface{} {
+	credentials := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if value := strings.TrimSpace(pairs[i+1]); value != "" {
+			credentials[pairs[i]] = value
+		}
+	}
+	return credentials
+}
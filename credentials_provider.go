@@ -0,0 +1,49 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+)
+
+// CredentialsProvider supplies the API key for each request, so that long-running
+// services can pick up rotated keys from a secrets manager. It is called once per API
+// call and should cache the key itself rather than fetch it every time.
+type CredentialsProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialsProviderFunc adapts a function to CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (string, error)
+
+// APIKey implements CredentialsProvider.
+func (f CredentialsProviderFunc) APIKey(ctx context.Context) (string, error) { return f(ctx) }
+
+// WithCredentialsProvider takes the API key from provider instead of the key passed to
+// NewClient. An error from the provider fails the request before it is sent.
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// SetAPIKey replaces the API key used by subsequent requests. It is safe to call while
+// requests are in flight; those keep the key they started with. It has no effect when a
+// CredentialsProvider is configured.
+func (c *Client) SetAPIKey(key string) {
+	c.apiKey.Store(&key)
+}
+
+// currentAPIKey returns the API key to send with a request.
+func (c *Client) currentAPIKey(ctx context.Context) (string, error) {
+	if c.credentials != nil {
+		key, err := c.credentials.APIKey(ctx)
+		if err != nil {
+			return "", fmt.Errorf("reevit: loading API key: %w", err)
+		}
+		return key, nil
+	}
+	if key := c.apiKey.Load(); key != nil {
+		return *key, nil
+	}
+	return "", nil
+}
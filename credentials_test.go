@@ -0,0 +1,27 @@
+package reevit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConnectionRequest(t *testing.T) {
+	req, err := NewConnectionRequest(PaystackCredentials{SecretKey: "sk_test_123", PublicKey: "pk_test_123"}, "test")
+	require.NoError(t, err)
+	require.Equal(t, &ConnectionRequest{
+		Provider:    ProviderPaystack,
+		Mode:        "test",
+		Credentials: map[string]interface{}{"secret_key": "sk_test_123", "public_key": "pk_test_123"},
+	}, req)
+
+	_, err = NewConnectionRequest(HubtelCredentials{ClientID: "id", ClientSecret: "secret"}, "live")
+	require.EqualError(t, err, "reevit: hubtel credentials require merchant_account_number")
+
+	_, err = NewConnectionRequest(StripeCredentials{SecretKey: "pk_live_1"}, "live")
+	require.EqualError(t, err, "reevit: stripe secret_key must start with sk_ or rk_")
+
+	req, err = NewConnectionRequest(FlutterwaveCredentials{SecretKey: "FLWSECK-1", SecretHash: "hash"}, "live")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"secret_key": "FLWSECK-1", "secret_hash": "hash"}, req.Credentials)
+}
@@ -0,0 +1,204 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultDomainPollInterval is how often WaitUntilActive re-checks a custom domain when
+// no interval is given. DNS changes usually take minutes to propagate.
+const DefaultDomainPollInterval = 30 * time.Second
+
+// ErrCustomDomainFailed is returned by WaitUntilActive when verification or certificate
+// issuance failed; CustomDomain.FailureReason says why.
+var ErrCustomDomainFailed = errors.New("reevit: custom domain setup failed")
+
+// CustomDomainsService handles the custom domains that hosted checkout pages and payment
+// links are served on, for white-label platforms.
+type CustomDomainsService service
+
+// Custom domain statuses.
+const (
+	// CustomDomainPending means Reevit is waiting for the DNS records to be created.
+	CustomDomainPending = "pending"
+	// CustomDomainProvisioning means the domain is verified and its TLS certificate is
+	// being issued.
+	CustomDomainProvisioning = "provisioning"
+	// CustomDomainActive means hosted pages are served on the domain.
+	CustomDomainActive = "active"
+	// CustomDomainFailed means verification or certificate issuance failed.
+	CustomDomainFailed = "failed"
+)
+
+// CustomDomain represents a custom domain resource.
+type CustomDomain struct {
+	ID     string `json:"id"`
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+	// DNSRecords lists the records to create at the domain's DNS provider: a TXT record
+	// carrying the verification token and a CNAME pointing the domain at Reevit.
+	DNSRecords []DNSRecord `json:"dns_records"`
+	// TLSStatus is the state of the domain's certificate: "pending", "issued" or "failed".
+	TLSStatus     string     `json:"tls_status"`
+	TLSExpiresAt  *time.Time `json:"tls_expires_at"`
+	VerifiedAt    *time.Time `json:"verified_at"`
+	FailureReason string     `json:"failure_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// DNSRecord is a DNS record required by a custom domain.
+type DNSRecord struct {
+	// Type is "TXT" or "CNAME".
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Verified reports whether Reevit found the record at the last check.
+	Verified bool `json:"verified"`
+}
+
+// CustomDomainRequest represents a custom domain create payload.
+type CustomDomainRequest struct {
+	// Domain is the host name to serve hosted pages on, e.g. "pay.example.com".
+	Domain   string                 `json:"domain"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// VerificationToken returns the value of the TXT record that proves ownership of the
+// domain, or "" when there is none.
+func (d *CustomDomain) VerificationToken() string {
+	for _, record := range d.DNSRecords {
+		if strings.EqualFold(record.Type, "TXT") {
+			return record.Value
+		}
+	}
+	return ""
+}
+
+// List returns custom domains for the current org.
+//
+// API Docs: GET /v1/custom-domains
+func (s *CustomDomainsService) List(ctx context.Context, opts ...RequestOption) ([]CustomDomain, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/custom-domains", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[CustomDomain](s.client, raw, "domains")
+}
+
+// Create attaches a custom domain. The returned domain is pending until the records in
+// DNSRecords exist; call Verify or WaitUntilActive once they are created.
+//
+// API Docs: POST /v1/custom-domains
+func (s *CustomDomainsService) Create(ctx context.Context, req *CustomDomainRequest, opts ...RequestOption) (*CustomDomain, error) {
+	if req == nil || strings.TrimSpace(req.Domain) == "" {
+		return nil, errors.New("reevit: custom domain is required")
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/custom-domains", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var domain CustomDomain
+	if err := s.client.do(httpRequest, &domain); err != nil {
+		return nil, err
+	}
+
+	return &domain, nil
+}
+
+// Get fetches a custom domain by ID.
+//
+// API Docs: GET /v1/custom-domains/{id}
+func (s *CustomDomainsService) Get(ctx context.Context, domainID string, opts ...RequestOption) (*CustomDomain, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/custom-domains/%s", domainID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var domain CustomDomain
+	if err := s.client.do(httpRequest, &domain); err != nil {
+		return nil, err
+	}
+
+	return &domain, nil
+}
+
+// Verify asks Reevit to check the DNS records of a custom domain now rather than at its
+// next periodic check, and returns the domain with the outcome.
+//
+// API Docs: POST /v1/custom-domains/{id}/verify
+func (s *CustomDomainsService) Verify(ctx context.Context, domainID string, opts ...RequestOption) (*CustomDomain, error) {
+	opts = append([]RequestOption{withFreshIdempotencyKey()}, opts...)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/custom-domains/%s/verify", domainID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var domain CustomDomain
+	if err := s.client.do(httpRequest, &domain); err != nil {
+		return nil, err
+	}
+
+	return &domain, nil
+}
+
+// Delete detaches a custom domain. Hosted pages go back to Reevit's domain.
+//
+// API Docs: DELETE /v1/custom-domains/{id}
+func (s *CustomDomainsService) Delete(ctx context.Context, domainID string, opts ...RequestOption) error {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/custom-domains/%s", domainID), nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(httpRequest, nil)
+}
+
+// WaitUntilActive verifies a custom domain every interval, DefaultDomainPollInterval when
+// zero, until it is active, and returns it. Once the DNS records are verified it keeps
+// polling while the TLS certificate is issued. It returns an error wrapping
+// ErrCustomDomainFailed if setup fails, and the last domain fetched with the context's
+// error when ctx is done.
+func (s *CustomDomainsService) WaitUntilActive(ctx context.Context, domainID string, interval time.Duration, opts ...RequestOption) (*CustomDomain, error) {
+	if interval <= 0 {
+		interval = DefaultDomainPollInterval
+	}
+
+	var domain *CustomDomain
+	for {
+		// Verify only helps while the DNS records are unverified; afterwards the
+		// certificate is issued in the background.
+		fetch := s.Verify
+		if domain != nil && domain.Status != CustomDomainPending {
+			fetch = s.Get
+		}
+		latest, err := fetch(ctx, domainID, opts...)
+		if err != nil {
+			return domain, err
+		}
+		domain = latest
+
+		switch domain.Status {
+		case CustomDomainActive:
+			return domain, nil
+		case CustomDomainFailed:
+			return domain, fmt.Errorf("%w: %s: %s", ErrCustomDomainFailed, domain.Domain, domain.FailureReason)
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return domain, err
+		}
+	}
+}
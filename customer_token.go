@@ -0,0 +1,111 @@
+package reevit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Errors returned by VerifyCustomerToken. Both mean the request must be rejected.
+var (
+	ErrInvalidCustomerToken = errors.New("reevit: invalid customer token")
+	ErrCustomerTokenExpired = errors.New("reevit: customer token expired")
+)
+
+// customerTokenLeeway absorbs clock skew between Reevit and the verifying server.
+const customerTokenLeeway = 30 * time.Second
+
+// CustomerToken holds the verified claims of a customer session token.
+type CustomerToken struct {
+	CustomerID     string
+	OrganizationID string
+	Scopes         []string
+	IssuedAt       time.Time
+	ExpiresAt      time.Time
+}
+
+// HasScope reports whether the token grants scope, e.g. "payments:read".
+func (t *CustomerToken) HasScope(scope string) bool {
+	for _, granted := range t.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type customerTokenClaims struct {
+	Subject      string `json:"sub"`
+	Organization string `json:"org"`
+	Scope        string `json:"scope"`
+	IssuedAt     int64  `json:"iat"`
+	NotBefore    int64  `json:"nbf"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// VerifyCustomerToken verifies a customer session token, the HS256 JWT that frontends
+// receive from Reevit and forward to the merchant backend, against the organization's
+// customer session secret. It checks the signature, expiry and not-before time and
+// returns the claims, so that a backend can serve "list my payments" for exactly the
+// customer and scopes in the token. Callers should also check that OrganizationID is
+// their own.
+func VerifyCustomerToken(token, secret string) (*CustomerToken, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || secret == "" {
+		return nil, ErrInvalidCustomerToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeTokenPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, ErrInvalidCustomerToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidCustomerToken
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, ErrInvalidCustomerToken
+	}
+
+	var claims customerTokenClaims
+	if err := decodeTokenPart(parts[1], &claims); err != nil {
+		return nil, ErrInvalidCustomerToken
+	}
+	if claims.Subject == "" || claims.ExpiresAt == 0 || checkID(IDKindCustomer, claims.Subject) != nil {
+		return nil, ErrInvalidCustomerToken
+	}
+	now := time.Now()
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(customerTokenLeeway)) {
+		return nil, ErrCustomerTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(customerTokenLeeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, ErrInvalidCustomerToken
+	}
+
+	verified := &CustomerToken{
+		CustomerID:     claims.Subject,
+		OrganizationID: claims.Organization,
+		Scopes:         strings.Fields(claims.Scope),
+		ExpiresAt:      time.Unix(claims.ExpiresAt, 0).UTC(),
+	}
+	if claims.IssuedAt != 0 {
+		verified.IssuedAt = time.Unix(claims.IssuedAt, 0).UTC()
+	}
+	return verified, nil
+}
+
+func decodeTokenPart(part string, v interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, v)
+}
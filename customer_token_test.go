@@ -0,0 +1,63 @@
+package reevit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func signCustomerToken(t *testing.T, secret string, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyCustomerToken(t *testing.T) {
+	now := time.Now()
+	token := signCustomerToken(t, "cst_secret", map[string]interface{}{
+		"sub":   "cus_123",
+		"org":   "org_1",
+		"scope": "payments:read subscriptions:read",
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+
+	claims, err := VerifyCustomerToken(token, "cst_secret")
+	require.NoError(t, err)
+	require.Equal(t, "cus_123", claims.CustomerID)
+	require.Equal(t, "org_1", claims.OrganizationID)
+	require.True(t, claims.HasScope("payments:read"))
+	require.False(t, claims.HasScope("payments:write"))
+	require.Equal(t, now.Add(time.Hour).Unix(), claims.ExpiresAt.Unix())
+
+	_, err = VerifyCustomerToken(token, "other_secret")
+	require.ErrorIs(t, err, ErrInvalidCustomerToken)
+	_, err = VerifyCustomerToken(token[:len(token)-2], "cst_secret")
+	require.ErrorIs(t, err, ErrInvalidCustomerToken)
+	_, err = VerifyCustomerToken("not-a-token", "cst_secret")
+	require.ErrorIs(t, err, ErrInvalidCustomerToken)
+
+	expired := signCustomerToken(t, "cst_secret", map[string]interface{}{
+		"sub": "cus_123",
+		"exp": now.Add(-time.Hour).Unix(),
+	})
+	_, err = VerifyCustomerToken(expired, "cst_secret")
+	require.ErrorIs(t, err, ErrCustomerTokenExpired)
+
+	wrongSubject := signCustomerToken(t, "cst_secret", map[string]interface{}{
+		"sub": "pay_123",
+		"exp": now.Add(time.Hour).Unix(),
+	})
+	_, err = VerifyCustomerToken(wrongSubject, "cst_secret")
+	require.ErrorIs(t, err, ErrInvalidCustomerToken)
+}
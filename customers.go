@@ -2,7 +2,7 @@ package reevit
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"time"
@@ -21,6 +21,10 @@ type Customer struct {
 	Metadata   map[string]interface{} `json:"metadata"`
 	CreatedAt  time.Time              `json:"created_at"`
 	UpdatedAt  time.Time              `json:"updated_at"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
 }
 
 // CreateCustomerRequest represents a request to create a customer.
@@ -58,6 +62,34 @@ type TopCustomersOptions struct {
 	To       string
 }
 
+// CustomerConsent captures the consent flags recorded for a customer.
+type CustomerConsent struct {
+	CustomerID     string    `json:"customer_id"`
+	Marketing      bool      `json:"marketing"`
+	Analytics      bool      `json:"analytics"`
+	DataProcessing bool      `json:"data_processing"`
+	Source         string    `json:"source"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// CustomerConsentUpdate represents a partial consent update. Nil flags are left unchanged.
+type CustomerConsentUpdate struct {
+	Marketing      *bool  `json:"marketing,omitempty"`
+	Analytics      *bool  `json:"analytics,omitempty"`
+	DataProcessing *bool  `json:"data_processing,omitempty"`
+	Source         string `json:"source,omitempty"`
+}
+
+// CustomerDeletionRequest represents a GDPR/NDPA erasure request for a customer.
+// Once processed, the customer's personal data is redacted and a customer.redacted webhook is sent.
+type CustomerDeletionRequest struct {
+	ID          string     `json:"id"`
+	CustomerID  string     `json:"customer_id"`
+	Status      string     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
 // PaginationOptions contains basic offset pagination filters.
 type PaginationOptions struct {
 	Limit  int
@@ -65,41 +97,37 @@ type PaginationOptions struct {
 }
 
 // List returns customers for the current org.
-func (s *CustomersService) List(ctx context.Context, options ...CustomerListOptions) ([]Customer, error) {
+func (s *CustomersService) List(ctx context.Context, options *CustomerListOptions, opts ...RequestOption) ([]Customer, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
-		setString(values, "email", options[0].Email)
-		setString(values, "external_id", options[0].ExternalID)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "email", options.Email)
+		setString(values, "external_id", options.ExternalID)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/customers", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/customers", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[Customer](raw, "customers")
+	return decodeArrayResponse[Customer](s.client, raw, "customers")
 }
 
 // Create creates a new customer.
 func (s *CustomersService) Create(ctx context.Context, req *CreateCustomerRequest, opts ...RequestOption) (*Customer, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/customers", req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/customers", req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var customer Customer
-	if err := s.client.do(ctx, httpRequest, &customer); err != nil {
+	if err := s.client.do(httpRequest, &customer); err != nil {
 		return nil, err
 	}
 
@@ -107,14 +135,18 @@ func (s *CustomersService) Create(ctx context.Context, req *CreateCustomerReques
 }
 
 // Get fetches a customer by ID.
-func (s *CustomersService) Get(ctx context.Context, customerID string) (*Customer, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/customers/%s", customerID), nil)
+func (s *CustomersService) Get(ctx context.Context, customerID string, opts ...RequestOption) (*Customer, error) {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/customers/%s", customerID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var customer Customer
-	if err := s.client.do(ctx, httpRequest, &customer); err != nil {
+	if err := s.client.do(httpRequest, &customer); err != nil {
 		return nil, err
 	}
 
@@ -123,17 +155,17 @@ func (s *CustomersService) Get(ctx context.Context, customerID string) (*Custome
 
 // Update updates a customer by ID.
 func (s *CustomersService) Update(ctx context.Context, customerID string, req *UpdateCustomerRequest, opts ...RequestOption) (*Customer, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/customers/%s", customerID), req)
-	if err != nil {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/customers/%s", customerID), req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var customer Customer
-	if err := s.client.do(ctx, httpRequest, &customer); err != nil {
+	if err := s.client.do(httpRequest, &customer); err != nil {
 		return nil, err
 	}
 
@@ -142,30 +174,30 @@ func (s *CustomersService) Update(ctx context.Context, customerID string, req *U
 
 // Delete removes a customer.
 func (s *CustomersService) Delete(ctx context.Context, customerID string, opts ...RequestOption) error {
-	httpRequest, err := s.client.newRequest(http.MethodDelete, fmt.Sprintf("/v1/customers/%s", customerID), nil)
-	if err != nil {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
 		return err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/customers/%s", customerID), nil, opts...)
+	if err != nil {
+		return err
 	}
 
-	return s.client.do(ctx, httpRequest, nil)
+	return s.client.do(httpRequest, nil)
 }
 
 // Lookup fetches a customer by external ID.
-func (s *CustomersService) Lookup(ctx context.Context, externalID string) (*Customer, error) {
+func (s *CustomersService) Lookup(ctx context.Context, externalID string, opts ...RequestOption) (*Customer, error) {
 	values := url.Values{}
 	setString(values, "external_id", externalID)
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/customers/lookup", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/customers/lookup", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var customer Customer
-	if err := s.client.do(ctx, httpRequest, &customer); err != nil {
+	if err := s.client.do(httpRequest, &customer); err != nil {
 		return nil, err
 	}
 
@@ -173,46 +205,156 @@ func (s *CustomersService) Lookup(ctx context.Context, externalID string) (*Cust
 }
 
 // Top returns top customers by value or volume.
-func (s *CustomersService) Top(ctx context.Context, options ...TopCustomersOptions) ([]Customer, error) {
+func (s *CustomersService) Top(ctx context.Context, options *TopCustomersOptions, opts ...RequestOption) ([]Customer, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setString(values, "currency", options[0].Currency)
-		setString(values, "country", options[0].Country)
-		setString(values, "from", options[0].From)
-		setString(values, "to", options[0].To)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setString(values, "currency", options.Currency)
+		setString(values, "country", options.Country)
+		setString(values, "from", options.From)
+		setString(values, "to", options.To)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/customers/top", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/customers/top", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[Customer](raw, "customers")
+	return decodeArrayResponse[Customer](s.client, raw, "customers")
 }
 
 // ListPayments returns payment history for a customer.
-func (s *CustomersService) ListPayments(ctx context.Context, customerID string, options ...PaginationOptions) ([]PaymentSummary, error) {
+//
+// API Docs: GET /v1/customers/{id}/payments
+func (s *CustomersService) ListPayments(ctx context.Context, customerID string, options *PaginationOptions, opts ...RequestOption) ([]PaymentSummary, error) {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/customers/%s/payments", customerID), values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[PaymentSummary](s.client, raw, "payments")
+}
+
+// ListPaymentsAutoPaging returns an iterator over the whole payment history of a customer.
+func (s *CustomersService) ListPaymentsAutoPaging(ctx context.Context, customerID string, options PaginationOptions, opts ...RequestOption) *Iter[PaymentSummary] {
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]PaymentSummary, error) {
+		return s.ListPayments(ctx, customerID, &PaginationOptions{Limit: limit, Offset: offset}, opts...)
+	})
+}
+
+// ListSubscriptions returns the subscriptions of a customer, in any status.
+//
+// API Docs: GET /v1/customers/{id}/subscriptions
+func (s *CustomersService) ListSubscriptions(ctx context.Context, customerID string, options *PaginationOptions, opts ...RequestOption) ([]Subscription, error) {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
+		return nil, err
+	}
+
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath(fmt.Sprintf("/v1/customers/%s/payments", customerID), values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/customers/%s/subscriptions", customerID), values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[PaymentSummary](raw, "payments")
+	return decodeArrayResponse[Subscription](s.client, raw, "subscriptions")
+}
+
+// ListSubscriptionsAutoPaging returns an iterator over all subscriptions of a customer.
+func (s *CustomersService) ListSubscriptionsAutoPaging(ctx context.Context, customerID string, options PaginationOptions, opts ...RequestOption) *Iter[Subscription] {
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]Subscription, error) {
+		return s.ListSubscriptions(ctx, customerID, &PaginationOptions{Limit: limit, Offset: offset}, opts...)
+	})
+}
+
+// RequestDeletion asks Reevit to erase a customer's personal data.
+//
+// API Docs: POST /v1/customers/{id}/deletion
+func (s *CustomersService) RequestDeletion(ctx context.Context, customerID string, opts ...RequestOption) (*CustomerDeletionRequest, error) {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/customers/%s/deletion", customerID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletion CustomerDeletionRequest
+	if err := s.client.do(httpRequest, &deletion); err != nil {
+		return nil, err
+	}
+
+	return &deletion, nil
+}
+
+// GetConsent returns the consent flags recorded for a customer.
+//
+// API Docs: GET /v1/customers/{id}/consent
+func (s *CustomersService) GetConsent(ctx context.Context, customerID string, opts ...RequestOption) (*CustomerConsent, error) {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/customers/%s/consent", customerID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var consent CustomerConsent
+	if err := s.client.do(httpRequest, &consent); err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+// UpdateConsent updates the consent flags recorded for a customer.
+//
+// API Docs: PATCH /v1/customers/{id}/consent
+func (s *CustomersService) UpdateConsent(ctx context.Context, customerID string, req *CustomerConsentUpdate, opts ...RequestOption) (*CustomerConsent, error) {
+	if err := checkID(IDKindCustomer, customerID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/customers/%s/consent", customerID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var consent CustomerConsent
+	if err := s.client.do(httpRequest, &consent); err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
 }
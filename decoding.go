@@ -0,0 +1,192 @@
+package reevit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WithStrictDecoding makes the client reject responses containing fields its types do not
+// know, instead of ignoring them or collecting them in ExtraFields. It is meant for
+// contract tests run against the sandbox, to notice API additions the SDK has not caught
+// up with; production code should leave it off so that new API fields never break calls.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// decodeJSON decodes a response body into v, rejecting unknown fields in strict mode.
+func (c *Client) decodeJSON(body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil || !c.strictDecoding {
+		return err
+	}
+	// DisallowUnknownFields does not reach into types that decode themselves, such as
+	// those with ExtraFields, so the body is checked against the type separately.
+	var tree interface{}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return err
+	}
+	return checkKnownFields(tree, reflect.TypeOf(v))
+}
+
+// extraFieldsHolder is implemented by the types with an ExtraFields map.
+type extraFieldsHolder interface {
+	extraFields() map[string]json.RawMessage
+}
+
+var (
+	extraFieldsHolderType = reflect.TypeOf((*extraFieldsHolder)(nil)).Elem()
+	unmarshalerType       = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// checkKnownFields returns an error for the first object key of the decoded JSON value
+// that typ has no field for.
+func checkKnownFields(value interface{}, typ reflect.Type) error {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if reflect.PointerTo(typ).Implements(unmarshalerType) && !reflect.PointerTo(typ).Implements(extraFieldsHolderType) {
+		return nil
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return nil
+		}
+		for _, element := range value {
+			if err := checkKnownFields(element, typ.Elem()); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		switch typ.Kind() {
+		case reflect.Map:
+			for _, element := range value {
+				if err := checkKnownFields(element, typ.Elem()); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			fields := jsonFields(typ)
+			names := make([]string, 0, len(value))
+			for name := range value {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				field, ok := lookupField(fields, name)
+				if !ok {
+					return fmt.Errorf("reevit: unknown field %q in %s", name, typ.Name())
+				}
+				if err := checkKnownFields(value[name], field); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldsCache caches the JSON field names and types of decoded struct types.
+var fieldsCache sync.Map // reflect.Type -> map[string]reflect.Type
+
+// jsonFields returns the fields encoding/json decodes into typ, by JSON name, including
+// those of embedded structs.
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	if fields, ok := fieldsCache.Load(typ); ok {
+		return fields.(map[string]reflect.Type)
+	}
+	fields := make(map[string]reflect.Type, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	actual, _ := fieldsCache.LoadOrStore(typ, fields)
+	return actual.(map[string]reflect.Type)
+}
+
+// lookupField finds the field for a JSON key, falling back to a case-insensitive match
+// as encoding/json does.
+func lookupField(fields map[string]reflect.Type, name string) (reflect.Type, bool) {
+	if field, ok := fields[name]; ok {
+		return field, true
+	}
+	for field, typ := range fields {
+		if strings.EqualFold(field, name) {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
+// unmarshalWithExtra decodes data into v, a pointer to a struct aliasing the type being
+// decoded so that its UnmarshalJSON is not called again, and returns the fields of data
+// that v has no field for.
+func unmarshalWithExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	fields := jsonFields(reflect.TypeOf(v).Elem())
+	var extra map[string]json.RawMessage
+	for name, value := range raw {
+		if _, ok := lookupField(fields, name); ok {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// marshalWithExtra encodes v, a pointer to a struct aliasing the type being encoded, and
+// adds the extra fields that v does not already contain.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return encoded, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
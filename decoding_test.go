@@ -0,0 +1,56 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtraFields(t *testing.T) {
+	var payment Payment
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"pay_1","amount":100,"installments":{"count":3},"ID":"pay_1"}`), &payment))
+	require.Equal(t, "pay_1", payment.ID)
+	require.Equal(t, map[string]json.RawMessage{"installments": json.RawMessage(`{"count":3}`)}, payment.ExtraFields)
+
+	encoded, err := json.Marshal(payment)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(encoded, &fields))
+	require.Equal(t, map[string]interface{}{"count": float64(3)}, fields["installments"])
+	require.Equal(t, float64(100), fields["amount"])
+	require.NotContains(t, fields, "ExtraFields")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"pay_2"}`), &payment))
+	require.Nil(t, payment.ExtraFields)
+}
+
+func TestStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payments/pay_1":
+			_, _ = w.Write([]byte(`{"id":"pay_1","installments":{"count":3}}`))
+		case "/v1/payments/pay_2":
+			_, _ = w.Write([]byte(`{"id":"pay_2","route":[{"provider":"paystack","latency_ms":40}]}`))
+		case "/v1/connections":
+			_, _ = w.Write([]byte(`{"connections":[{"id":"conn_1","region":"gh"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	lenient := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	payment, err := lenient.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	require.Contains(t, payment.ExtraFields, "installments")
+
+	strict := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithStrictDecoding())
+	_, err = strict.Payments.Get(context.Background(), "pay_1")
+	require.EqualError(t, err, `reevit: unknown field "installments" in Payment`)
+	_, err = strict.Payments.Get(context.Background(), "pay_2")
+	require.EqualError(t, err, `reevit: unknown field "latency_ms" in PaymentRouteAttempt`)
+	_, err = strict.Connections.List(context.Background(), nil)
+	require.EqualError(t, err, `reevit: unknown field "region" in Connection`)
+}
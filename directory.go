@@ -0,0 +1,180 @@
+package reevit
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// directoryCacheTTL controls how long directory listings are served from the local cache.
+const directoryCacheTTL = 24 * time.Hour
+
+// DirectoryService handles communication with the bank and mobile network directory of the Reevit API.
+type DirectoryService service
+
+//go:embed data/directory.json
+var embeddedDirectory []byte
+
+var (
+	fallbackDirectoryOnce sync.Once
+	fallbackDirectory     directoryData
+)
+
+// Bank represents a bank that can receive transfers or bank-transfer intents.
+type Bank struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
+// MobileNetwork represents a mobile money network operator.
+type MobileNetwork struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
+type directoryData struct {
+	Banks    map[string][]Bank          `json:"banks"`
+	Networks map[string][]MobileNetwork `json:"networks"`
+}
+
+type directoryCache struct {
+	mu      sync.Mutex
+	entries map[string]directoryCacheEntry
+}
+
+type directoryCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func (c *directoryCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *directoryCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]directoryCacheEntry{}
+	}
+	c.entries[key] = directoryCacheEntry{value: value, expiresAt: time.Now().Add(directoryCacheTTL)}
+}
+
+func loadFallbackDirectory() directoryData {
+	fallbackDirectoryOnce.Do(func() {
+		if err := json.Unmarshal(embeddedDirectory, &fallbackDirectory); err != nil {
+			panic("reevit: embedded directory data is invalid: " + err.Error())
+		}
+		for country, banks := range fallbackDirectory.Banks {
+			for i := range banks {
+				banks[i].Country = country
+			}
+		}
+		for country, networks := range fallbackDirectory.Networks {
+			for i := range networks {
+				networks[i].Country = country
+			}
+		}
+	})
+	return fallbackDirectory
+}
+
+// ListBanks returns the banks available in a country. Results are cached for 24 hours,
+// and the directory embedded in the SDK is returned when the API is unreachable. The
+// returned slice is the caller's own and may be modified.
+//
+// API Docs: GET /v1/directory/banks
+func (s *DirectoryService) ListBanks(ctx context.Context, country string, opts ...RequestOption) ([]Bank, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	cacheKey := "banks:" + country
+	if cached, ok := s.client.directory.get(cacheKey); ok {
+		return append([]Bank(nil), cached.([]Bank)...), nil
+	}
+
+	values := url.Values{}
+	setString(values, "country", country)
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/directory/banks", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		if fallback, ok := loadFallbackDirectory().Banks[country]; ok && canUseFallback(err) {
+			return append([]Bank(nil), fallback...), nil
+		}
+		return nil, err
+	}
+
+	banks, err := decodeArrayResponse[Bank](s.client, raw, "banks")
+	if err != nil {
+		return nil, err
+	}
+	s.client.directory.set(cacheKey, append([]Bank(nil), banks...))
+
+	return banks, nil
+}
+
+// ListNetworks returns the mobile money networks available in a country. Results are cached
+// for 24 hours, and the directory embedded in the SDK is returned when the API is unreachable.
+//
+// API Docs: GET /v1/directory/networks
+func (s *DirectoryService) ListNetworks(ctx context.Context, country string, opts ...RequestOption) ([]MobileNetwork, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	cacheKey := "networks:" + country
+	if cached, ok := s.client.directory.get(cacheKey); ok {
+		return append([]MobileNetwork(nil), cached.([]MobileNetwork)...), nil
+	}
+
+	values := url.Values{}
+	setString(values, "country", country)
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/directory/networks", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		if fallback, ok := loadFallbackDirectory().Networks[country]; ok && canUseFallback(err) {
+			return append([]MobileNetwork(nil), fallback...), nil
+		}
+		return nil, err
+	}
+
+	networks, err := decodeArrayResponse[MobileNetwork](s.client, raw, "networks")
+	if err != nil {
+		return nil, err
+	}
+	s.client.directory.set(cacheKey, append([]MobileNetwork(nil), networks...))
+
+	return networks, nil
+}
+
+// canUseFallback reports whether an error means the API was unavailable rather than
+// rejecting the request, in which case embedded reference data may be served instead.
+func canUseFallback(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryCachesCopies(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/v1/directory/banks":
+			require.Equal(t, "GH", r.URL.Query().Get("country"))
+			_, _ = w.Write([]byte(`{"banks":[{"code":"GCB","name":"GCB Bank","country":"GH"}]}`))
+		case "/v1/directory/networks":
+			_, _ = w.Write([]byte(`{"networks":[{"code":"mtn","name":"MTN","country":"GH"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	banks, err := client.Directory.ListBanks(ctx, " gh ")
+	require.NoError(t, err)
+	banks[0].Name = "changed"
+	banks, err = client.Directory.ListBanks(ctx, "GH")
+	require.NoError(t, err)
+	require.Equal(t, []Bank{{Code: "GCB", Name: "GCB Bank", Country: "GH"}}, banks)
+	banks[0].Name = "changed again"
+
+	networks, err := client.Directory.ListNetworks(ctx, "GH")
+	require.NoError(t, err)
+	networks[0].Name = "changed"
+	networks, err = client.Directory.ListNetworks(ctx, "GH")
+	require.NoError(t, err)
+	require.Equal(t, "MTN", networks[0].Name)
+	require.Equal(t, 2, calls)
+
+	banks, err = client.Directory.ListBanks(ctx, "GH")
+	require.NoError(t, err)
+	require.Equal(t, "GCB Bank", banks[0].Name)
+}
+
+func TestDirectoryFallsBackToEmbedded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithMaxRetries(0))
+	ctx := context.Background()
+
+	banks, err := client.Directory.ListBanks(ctx, "GH")
+	require.NoError(t, err)
+	require.NotEmpty(t, banks)
+	require.Equal(t, "GH", banks[0].Country)
+	original := banks[0].Name
+	banks[0].Name = "changed"
+	banks, err = client.Directory.ListBanks(ctx, "GH")
+	require.NoError(t, err)
+	require.Equal(t, original, banks[0].Name)
+
+	networks, err := client.Directory.ListNetworks(ctx, "KE")
+	require.NoError(t, err)
+	require.NotEmpty(t, networks)
+
+	_, err = client.Directory.ListBanks(ctx, "ZZ")
+	require.Error(t, err)
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code":"forbidden","message":"no access"}`))
+	}))
+	defer rejecting.Close()
+	client = NewClient("pfk_test", "org_1", WithBaseURL(rejecting.URL))
+	_, err = client.Directory.ListBanks(ctx, "GH")
+	require.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package reevit
+
+// The types below name the values of enumerated API fields. They are plain strings on the
+// wire, so values added to the API after this SDK was released decode without error and
+// can be compared against string literals.
+
+// PaymentStatus is the lifecycle status of a payment.
+type PaymentStatus string
+
+// Payment statuses.
+const (
+	PaymentStatusPending           PaymentStatus = "pending"
+	PaymentStatusScheduled         PaymentStatus = "scheduled"
+	PaymentStatusProcessing        PaymentStatus = "processing"
+	PaymentStatusRequiresAction    PaymentStatus = "requires_action"
+	PaymentStatusSucceeded         PaymentStatus = "succeeded"
+	PaymentStatusFailed            PaymentStatus = "failed"
+	PaymentStatusCanceled          PaymentStatus = "canceled"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+)
+
+// IsTerminal reports whether a payment in this status will not change any more without
+// further action, i.e. it succeeded, failed, was canceled or was fully or partially
+// refunded. A partially refunded payment can still be refunded further, but only through
+// a new refund.
+func (s PaymentStatus) IsTerminal() bool {
+	switch s {
+	case PaymentStatusSucceeded, PaymentStatusFailed, PaymentStatusCanceled, "cancelled",
+		PaymentStatusRefunded, PaymentStatusPartiallyRefunded:
+		return true
+	}
+	return false
+}
+
+// PaymentEventType is the kind of an entry in a payment's timeline.
+type PaymentEventType string
+
+// Payment timeline event types.
+const (
+	PaymentEventCreated           PaymentEventType = "created"
+	PaymentEventRouted            PaymentEventType = "routed"
+	PaymentEventProviderAttempted PaymentEventType = "provider_attempted"
+	PaymentEventConfirmed         PaymentEventType = "confirmed"
+	PaymentEventFailed            PaymentEventType = "failed"
+	PaymentEventRefunded          PaymentEventType = "refunded"
+)
+
+// NotificationType is the kind of notification Reevit sends on the organization's behalf.
+type NotificationType string
+
+// Notification types configurable with client.Notifications.
+const (
+	NotificationPaymentReceipt      NotificationType = "payment_receipt"
+	NotificationRefundReceipt       NotificationType = "refund_receipt"
+	NotificationPayoutSent          NotificationType = "payout_sent"
+	NotificationSubscriptionRenewal NotificationType = "subscription_renewal"
+	NotificationRenewalFailed       NotificationType = "renewal_failed"
+	NotificationPaymentLinkExpiring NotificationType = "payment_link_expiring"
+)
+
+// NotificationChannel is how a notification is delivered.
+type NotificationChannel string
+
+// Notification channels.
+const (
+	NotificationEmail NotificationChannel = "email"
+	NotificationSMS   NotificationChannel = "sms"
+)
+
+// PaymentMethod is the instrument a payment is made with.
+type PaymentMethod string
+
+// Payment methods.
+const (
+	MethodCard         PaymentMethod = "card"
+	MethodMobileMoney  PaymentMethod = "momo"
+	MethodBankTransfer PaymentMethod = "bank_transfer"
+	MethodUSSD         PaymentMethod = "ussd"
+)
+
+// Provider identifies a payment service provider (PSP).
+type Provider string
+
+// PSP identifiers accepted in ConnectionRequest.Provider.
+const (
+	ProviderPaystack    Provider = "paystack"
+	ProviderFlutterwave Provider = "flutterwave"
+	ProviderHubtel      Provider = "hubtel"
+	ProviderStripe      Provider = "stripe"
+)
+
+// SubscriptionStatus is the lifecycle status of a subscription.
+type SubscriptionStatus string
+
+// Subscription statuses.
+const (
+	SubscriptionStatusTrialing SubscriptionStatus = "trialing"
+	SubscriptionStatusActive   SubscriptionStatus = "active"
+	SubscriptionStatusPastDue  SubscriptionStatus = "past_due"
+	SubscriptionStatusPaused   SubscriptionStatus = "paused"
+	SubscriptionStatusCanceled SubscriptionStatus = "canceled"
+)
+
+// IsTerminal reports whether the subscription has ended for good.
+func (s SubscriptionStatus) IsTerminal() bool {
+	return s == SubscriptionStatusCanceled || s == "cancelled"
+}
+
+// Interval is the billing period of a subscription or plan.
+type Interval string
+
+// Billing intervals.
+const (
+	IntervalDaily     Interval = "daily"
+	IntervalWeekly    Interval = "weekly"
+	IntervalMonthly   Interval = "monthly"
+	IntervalQuarterly Interval = "quarterly"
+	IntervalYearly    Interval = "yearly"
+)
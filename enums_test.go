@@ -0,0 +1,32 @@
+package reevit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentStatusIsTerminal(t *testing.T) {
+	require.True(t, PaymentStatusSucceeded.IsTerminal())
+	require.True(t, PaymentStatusCanceled.IsTerminal())
+	require.False(t, PaymentStatusPending.IsTerminal())
+	require.False(t, PaymentStatusScheduled.IsTerminal())
+	require.False(t, PaymentStatus("on_hold").IsTerminal())
+
+	require.True(t, SubscriptionStatusCanceled.IsTerminal())
+	require.False(t, SubscriptionStatusPastDue.IsTerminal())
+}
+
+func TestEnumsDecodeUnknownValues(t *testing.T) {
+	var payment Payment
+	err := json.Unmarshal([]byte(`{"id":"pay_1","status":"on_hold","method":"crypto","provider":"paystack"}`), &payment)
+	require.NoError(t, err)
+	require.Equal(t, PaymentStatus("on_hold"), payment.Status)
+	require.Equal(t, PaymentMethod("crypto"), payment.Method)
+	require.Equal(t, ProviderPaystack, payment.Provider)
+
+	body, err := json.Marshal(&PaymentIntentRequest{Amount: 100, Currency: "GHS", Method: MethodMobileMoney})
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"method":"momo"`)
+}
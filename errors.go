@@ -0,0 +1,270 @@
+package reevit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error codes returned by the API in the error envelope.
+const (
+	ErrorCodeNotFound    = "not_found"
+	ErrorCodeRateLimited = "rate_limited"
+	ErrorCodeValidation  = "validation_error"
+
+	ErrorCodeIdempotencyConflict = "idempotency_conflict"
+)
+
+// APIError represents a Reevit API error.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    map[string]interface{}
+	RequestID  string
+
+	// Body holds the raw response body so fields added to the envelope later remain accessible.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == 0 {
+		// Raised by the SDK before the request was sent.
+		return "reevit: " + e.Message
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("reevit: request failed with status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("reevit: request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether the error is a 404 or a not_found error code.
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.Code == ErrorCodeNotFound
+}
+
+// IsRateLimited reports whether the request was rejected by rate limiting.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.Code == ErrorCodeRateLimited
+}
+
+// IsValidation reports whether the request was rejected because of invalid input.
+func (e *APIError) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest ||
+		e.StatusCode == http.StatusUnprocessableEntity ||
+		e.Code == ErrorCodeValidation
+}
+
+// IsNotFound reports whether err is, or wraps, an APIError for a missing resource.
+// Infrastructure tools use it to tell a deleted resource apart from a failed read.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsNotFound()
+}
+
+// errorEnvelope is the standard error body. Some endpoints nest it under "error".
+type errorEnvelope struct {
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details"`
+	RequestID string                 `json:"request_id"`
+	Errors    []FieldError           `json:"errors"`
+	Error     *errorEnvelope         `json:"error"`
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		Body:       body,
+	}
+
+	var payload errorEnvelope
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if payload.Error != nil {
+			payload = *payload.Error
+		}
+		apiErr.Code = payload.Code
+		apiErr.Details = payload.Details
+		if payload.Message != "" {
+			apiErr.Message = payload.Message
+		}
+		if payload.RequestID != "" {
+			apiErr.RequestID = payload.RequestID
+		}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = resp.Status
+	}
+
+	return apiErr
+}
+
+// The status-specific error types below all wrap *APIError, so errors.As(err, &apiErr)
+// keeps working for callers that only inspect APIError.
+
+// AuthenticationError is returned for 401 responses: the API key is missing or invalid.
+type AuthenticationError struct{ *APIError }
+
+func (e *AuthenticationError) Unwrap() error { return e.APIError }
+
+// PaymentRequiredError is returned for 402 responses, when the provider declined a payment.
+type PaymentRequiredError struct {
+	*APIError
+	// DeclineCode is the provider's decline reason, e.g. "insufficient_funds".
+	DeclineCode    string
+	DeclineMessage string
+	Provider       string
+}
+
+func (e *PaymentRequiredError) Unwrap() error { return e.APIError }
+
+// PermissionError is returned for 403 responses: the key may not perform the operation.
+type PermissionError struct{ *APIError }
+
+func (e *PermissionError) Unwrap() error { return e.APIError }
+
+// NotFoundError is returned for 404 responses.
+type NotFoundError struct{ *APIError }
+
+func (e *NotFoundError) Unwrap() error { return e.APIError }
+
+// ConflictError is returned for 409 responses, when the request conflicts with the
+// current state of the resource.
+type ConflictError struct{ *APIError }
+
+func (e *ConflictError) Unwrap() error { return e.APIError }
+
+// IdempotencyConflictError is returned when an idempotency key is reused with a different
+// request. It is also a *ConflictError.
+//
+// The fingerprints identify the request payloads as seen by the API. Comparing them tells
+// a genuine key collision (PayloadMismatch), where the key should be regenerated, apart
+// from a retry that raced the original request.
+type IdempotencyConflictError struct {
+	*ConflictError
+	// IdempotencyKey is the key sent with the rejected request.
+	IdempotencyKey string
+	// OriginalFingerprint is the fingerprint of the request first made with the key.
+	OriginalFingerprint string
+	// RequestFingerprint is the fingerprint of the rejected request.
+	RequestFingerprint string
+	// OriginalRequestID is the request ID of the request first made with the key.
+	OriginalRequestID string
+}
+
+// PayloadMismatch reports whether the key was first used for a different payload.
+func (e *IdempotencyConflictError) PayloadMismatch() bool {
+	return e.OriginalFingerprint != "" && e.RequestFingerprint != "" &&
+		e.OriginalFingerprint != e.RequestFingerprint
+}
+
+func (e *IdempotencyConflictError) Unwrap() error { return e.ConflictError }
+
+// ValidationError is returned for 400 and 422 responses rejecting invalid input.
+type ValidationError struct {
+	*APIError
+	// Fields lists the individual problems reported by the API, if any.
+	Fields []FieldError
+}
+
+// FieldError describes why a single request field was rejected. Field is a dotted path
+// into the request body, e.g. "customer.email" or "items.0.amount".
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FieldErrors returns the errors reported for field.
+func (e *ValidationError) FieldErrors(field string) []FieldError {
+	var matched []FieldError
+	for _, fieldErr := range e.Fields {
+		if fieldErr.Field == field {
+			matched = append(matched, fieldErr)
+		}
+	}
+	return matched
+}
+
+func (e *ValidationError) Unwrap() error { return e.APIError }
+
+// RateLimitError is returned for 429 responses.
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is the delay requested by the Retry-After header, or zero if absent.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error { return e.APIError }
+
+// ServerError is returned for 5xx responses.
+type ServerError struct{ *APIError }
+
+func (e *ServerError) Unwrap() error { return e.APIError }
+
+// newError maps an error response to the most specific error type.
+func newError(resp *http.Response, body []byte) error {
+	apiErr := newAPIError(resp, body)
+	switch {
+	case apiErr.Code == ErrorCodeIdempotencyConflict:
+		conflict := &IdempotencyConflictError{
+			ConflictError:       &ConflictError{apiErr},
+			IdempotencyKey:      detailString(apiErr.Details, "idempotency_key"),
+			OriginalFingerprint: detailString(apiErr.Details, "original_fingerprint"),
+			RequestFingerprint:  detailString(apiErr.Details, "request_fingerprint"),
+			OriginalRequestID:   detailString(apiErr.Details, "original_request_id"),
+		}
+		if conflict.IdempotencyKey == "" && resp.Request != nil {
+			conflict.IdempotencyKey = resp.Request.Header.Get("Idempotency-Key")
+		}
+		return conflict
+	case isUpgradeRequired(apiErr):
+		return newUpgradeRequiredError(resp, apiErr)
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return &AuthenticationError{apiErr}
+	case apiErr.StatusCode == http.StatusPaymentRequired:
+		return &PaymentRequiredError{
+			APIError:       apiErr,
+			DeclineCode:    detailString(apiErr.Details, "decline_code"),
+			DeclineMessage: detailString(apiErr.Details, "decline_message"),
+			Provider:       detailString(apiErr.Details, "provider"),
+		}
+	case apiErr.StatusCode == http.StatusForbidden:
+		return &PermissionError{apiErr}
+	case apiErr.StatusCode == http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case apiErr.StatusCode == http.StatusConflict:
+		return &ConflictError{apiErr}
+	case apiErr.StatusCode == http.StatusBadRequest, apiErr.StatusCode == http.StatusUnprocessableEntity:
+		return &ValidationError{APIError: apiErr, Fields: parseFieldErrors(body)}
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"))
+		return &RateLimitError{APIError: apiErr, RetryAfter: retryAfter}
+	case apiErr.StatusCode >= 500:
+		return &ServerError{apiErr}
+	}
+	return apiErr
+}
+
+func detailString(details map[string]interface{}, key string) string {
+	value, _ := details[key].(string)
+	return value
+}
+
+// parseFieldErrors reads the field-error array, which the API sends either at the top
+// level of the envelope or inside the nested "error" object.
+func parseFieldErrors(body []byte) []FieldError {
+	var payload errorEnvelope
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	if payload.Error != nil && len(payload.Error.Errors) > 0 {
+		return payload.Error.Errors
+	}
+	return payload.Errors
+}
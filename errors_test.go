@@ -0,0 +1,168 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req_header")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"payment not found","details":{"id":"pay_1"},"request_id":"req_body"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.Equal(t, "not_found", apiErr.Code)
+	require.Equal(t, "payment not found", apiErr.Message)
+	require.Equal(t, "req_body", apiErr.RequestID)
+	require.Equal(t, "pay_1", apiErr.Details["id"])
+	require.Contains(t, string(apiErr.Body), "not_found")
+	require.True(t, apiErr.IsNotFound())
+	require.False(t, apiErr.IsRateLimited())
+}
+
+func TestAPIErrorPlainBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req_header")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "slow down", apiErr.Message)
+	require.Equal(t, "req_header", apiErr.RequestID)
+	require.True(t, apiErr.IsRateLimited())
+}
+
+func TestErrorTypes(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{http.StatusUnauthorized, `{"message":"invalid key"}`, func(t *testing.T, err error) {
+			var target *AuthenticationError
+			require.ErrorAs(t, err, &target)
+		}},
+		{http.StatusPaymentRequired, `{"code":"card_declined","details":{"decline_code":"insufficient_funds","provider":"paystack"}}`, func(t *testing.T, err error) {
+			var target *PaymentRequiredError
+			require.ErrorAs(t, err, &target)
+			require.Equal(t, "insufficient_funds", target.DeclineCode)
+			require.Equal(t, "paystack", target.Provider)
+		}},
+		{http.StatusForbidden, `{}`, func(t *testing.T, err error) {
+			var target *PermissionError
+			require.ErrorAs(t, err, &target)
+		}},
+		{http.StatusNotFound, `{}`, func(t *testing.T, err error) {
+			var target *NotFoundError
+			require.ErrorAs(t, err, &target)
+			require.True(t, IsNotFound(err))
+		}},
+		{http.StatusConflict, `{"code":"idempotency_conflict","details":{"idempotency_key":"key_1","original_fingerprint":"fp_a","request_fingerprint":"fp_b","original_request_id":"req_1"}}`, func(t *testing.T, err error) {
+			var target *IdempotencyConflictError
+			require.ErrorAs(t, err, &target)
+			require.Equal(t, "key_1", target.IdempotencyKey)
+			require.Equal(t, "fp_a", target.OriginalFingerprint)
+			require.Equal(t, "req_1", target.OriginalRequestID)
+			require.True(t, target.PayloadMismatch())
+			var conflict *ConflictError
+			require.ErrorAs(t, err, &conflict)
+		}},
+		{http.StatusUnprocessableEntity, `{"error":{"code":"validation_error","errors":[{"field":"amount","code":"too_small","message":"must be at least 100"},{"field":"customer.email","code":"invalid","message":"is not an email"}]}}`, func(t *testing.T, err error) {
+			var target *ValidationError
+			require.ErrorAs(t, err, &target)
+			require.True(t, target.IsValidation())
+			require.Len(t, target.Fields, 2)
+			require.Equal(t, []FieldError{{Field: "customer.email", Code: "invalid", Message: "is not an email"}}, target.FieldErrors("customer.email"))
+		}},
+		{http.StatusTooManyRequests, `{}`, func(t *testing.T, err error) {
+			var target *RateLimitError
+			require.ErrorAs(t, err, &target)
+			require.Equal(t, 7*time.Second, target.RetryAfter)
+		}},
+		{http.StatusBadGateway, `{}`, func(t *testing.T, err error) {
+			var target *ServerError
+			require.ErrorAs(t, err, &target)
+		}},
+		{http.StatusUpgradeRequired, `{"code":"sdk_upgrade_required","details":{"min_version":"1.2.0","changelog_url":"https://docs.reevit.io/changelog"}}`, func(t *testing.T, err error) {
+			var target *UpgradeRequiredError
+			require.ErrorAs(t, err, &target)
+			require.Equal(t, "1.2.0", target.MinimumVersion)
+			require.Equal(t, "https://docs.reevit.io/changelog", target.ChangelogURL)
+		}},
+		{http.StatusGone, `{"code":"sdk_upgrade_required"}`, func(t *testing.T, err error) {
+			var target *UpgradeRequiredError
+			require.ErrorAs(t, err, &target)
+		}},
+		{http.StatusGone, `{"code":"link_expired"}`, func(t *testing.T, err error) {
+			var target *UpgradeRequiredError
+			require.False(t, errors.As(err, &target))
+		}},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(tc.body))
+		}))
+		client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+		_, err := client.Payments.Get(context.Background(), "pay_1")
+		server.Close()
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, tc.status, apiErr.StatusCode)
+		tc.check(t, err)
+	}
+}
+
+func TestDeprecationHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Reevit-SDK-Min-Version", "1.0.0")
+		if r.URL.Path == "/v1/payments/pay_1" {
+			w.Header().Set("X-Reevit-SDK-Deprecated", "true")
+			w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+			_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUpgradeRequired)
+		_, _ = w.Write([]byte(`{"code":"sdk_upgrade_required"}`))
+	}))
+	defer server.Close()
+
+	var notices []SDKDeprecation
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithDeprecationHook(func(notice SDKDeprecation) {
+		notices = append(notices, notice)
+	}))
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	_, err = client.Payments.Get(context.Background(), "pay_2")
+	var upgrade *UpgradeRequiredError
+	require.ErrorAs(t, err, &upgrade)
+	require.Equal(t, "1.0.0", upgrade.MinimumVersion)
+
+	require.Equal(t, []SDKDeprecation{
+		{CurrentVersion: Version, MinimumVersion: "1.0.0", Sunset: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{CurrentVersion: Version, MinimumVersion: "1.0.0", Required: true},
+	}, notices)
+}
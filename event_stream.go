@@ -0,0 +1,229 @@
+package reevit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Reevit-Platform/go-sdk/webhooks"
+)
+
+const (
+	defaultStreamReconnectDelay = time.Second
+	maxStreamReconnectDelay     = 30 * time.Second
+)
+
+// EventsService gives access to the real-time event stream, which delivers the same
+// events as webhooks without a public endpoint. It is mostly useful in local development.
+type EventsService service
+
+// EventStreamOptions configures an event stream.
+type EventStreamOptions struct {
+	// Types restricts the stream to these event types, e.g. webhooks.EventPaymentSucceeded.
+	Types []string
+	// Cursor resumes the stream after the event with this ID. Pass EventStream.Cursor of a
+	// previous stream to continue where it stopped.
+	Cursor string
+	// ReconnectDelay is the initial delay before reconnecting after the connection drops.
+	// It doubles on consecutive failures, up to 30 seconds, and defaults to one second.
+	ReconnectDelay time.Duration
+}
+
+// EventStream is a live connection to the event stream. Events arrive on Events until the
+// stream's context is done or a non-retryable error occurs; Err then reports why.
+type EventStream struct {
+	events chan *webhooks.Event
+
+	mu     sync.Mutex
+	cursor string
+	err    error
+}
+
+// Events returns the channel of received events. It is closed when the stream ends.
+func (s *EventStream) Events() <-chan *webhooks.Event {
+	return s.events
+}
+
+// Cursor returns the ID of the last event delivered on Events.
+func (s *EventStream) Cursor() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor
+}
+
+// Err returns the error that ended the stream. It is only meaningful once Events is closed.
+func (s *EventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Stream connects to the server-sent event stream and decodes each event with
+// webhooks.ParseEvent. Dropped connections are re-established with the last received
+// event ID, so no events are lost while reconnecting. Authentication and permission errors
+// end the stream.
+//
+//	stream := client.Events.Stream(ctx, reevit.EventStreamOptions{})
+//	for event := range stream.Events() {
+//		log.Println(event.Type)
+//	}
+//	if err := stream.Err(); err != nil && !errors.Is(err, context.Canceled) {
+//		return err
+//	}
+//
+// API Docs: GET /v1/events/stream
+func (s *EventsService) Stream(ctx context.Context, options EventStreamOptions, opts ...RequestOption) *EventStream {
+	stream := &EventStream{
+		events: make(chan *webhooks.Event),
+		cursor: options.Cursor,
+	}
+	go s.run(ctx, stream, options, opts)
+	return stream
+}
+
+func (s *EventsService) run(ctx context.Context, stream *EventStream, options EventStreamOptions, opts []RequestOption) {
+	defer close(stream.events)
+
+	initialDelay := options.ReconnectDelay
+	if initialDelay <= 0 {
+		initialDelay = defaultStreamReconnectDelay
+	}
+	delay := initialDelay
+	for {
+		received, err := s.connect(ctx, stream, options, opts)
+		if ctx.Err() != nil {
+			stream.fail(ctx.Err())
+			return
+		}
+		if !retryableStreamError(err) {
+			stream.fail(err)
+			return
+		}
+
+		if received {
+			delay = initialDelay
+		}
+		if err := sleep(ctx, delay); err != nil {
+			stream.fail(err)
+			return
+		}
+		if delay *= 2; delay > maxStreamReconnectDelay {
+			delay = maxStreamReconnectDelay
+		}
+	}
+}
+
+// connect reads one connection until it drops. It reports whether any event was received,
+// which resets the reconnect backoff.
+func (s *EventsService) connect(ctx context.Context, stream *EventStream, options EventStreamOptions, opts []RequestOption) (bool, error) {
+	values := url.Values{}
+	setString(values, "types", strings.Join(options.Types, ","))
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/events/stream", values), nil, opts...)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if cursor := stream.Cursor(); cursor != "" {
+		req.Header.Set("Last-Event-ID", cursor)
+	}
+
+	// The stream stays open indefinitely, so the client-wide timeout must not apply.
+	httpClient := *s.client.httpClient
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return false, newError(resp, body)
+	}
+
+	received := false
+	err = readServerSentEvents(resp.Body, func(id, data string) error {
+		event, err := webhooks.ParseEvent([]byte(data))
+		if err != nil {
+			return err
+		}
+		if event.ID == "" {
+			event.ID = id
+		}
+		select {
+		case stream.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		received = true
+		stream.mu.Lock()
+		stream.cursor = event.ID
+		stream.mu.Unlock()
+		return nil
+	})
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return received, err
+}
+
+func (s *EventStream) fail(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// retryableStreamError reports whether the stream should reconnect after err. API errors
+// other than rate limiting and server errors are permanent, as are malformed events.
+func retryableStreamError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsRateLimited() || apiErr.StatusCode >= 500
+	}
+	var parseErr *webhooks.ParseError
+	return !errors.As(err, &parseErr)
+}
+
+// readServerSentEvents parses a text/event-stream body and calls emit for each event with
+// a non-empty data field. Comments, such as keep-alive pings, are ignored.
+func readServerSentEvents(r io.Reader, emit func(id, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	var id string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				if err := emit(id, strings.TrimSuffix(data.String(), "\n")); err != nil {
+					return err
+				}
+			}
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		}
+	}
+	return scanner.Err()
+}
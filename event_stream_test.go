@@ -0,0 +1,55 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Reevit-Platform/go-sdk/webhooks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStreamReconnects(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		connection := len(lastEventIDs)
+		mu.Unlock()
+
+		require.Equal(t, "payment.succeeded", r.URL.Query().Get("types"))
+		if connection > 2 {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"key revoked"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprintf(w, ": ping\n\nid: evt_%d\nevent: payment.succeeded\ndata: {\"id\":\"evt_%d\",\"type\":\"payment.succeeded\",\n", connection, connection)
+		_, _ = fmt.Fprintf(w, "data: \"data\":{\"id\":\"pay_%d\",\"status\":\"succeeded\"}}\n\n", connection)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := client.Events.Stream(ctx, EventStreamOptions{
+		Types:          []string{webhooks.EventPaymentSucceeded},
+		ReconnectDelay: time.Millisecond,
+	})
+
+	var payments []string
+	for event := range stream.Events() {
+		payments = append(payments, event.Data.(*webhooks.PaymentSucceededEvent).ID)
+	}
+	require.Equal(t, []string{"pay_1", "pay_2"}, payments)
+	require.Equal(t, []string{"", "evt_1", "evt_2"}, lastEventIDs)
+	require.Equal(t, "evt_2", stream.Cursor())
+
+	var authErr *AuthenticationError
+	require.ErrorAs(t, stream.Err(), &authErr)
+}
@@ -0,0 +1,135 @@
+package reevit
+
+import "encoding/json"
+
+// The core resource types keep the fields they do not know in ExtraFields. The methods
+// below decode and encode them through an alias of the type, which has the same fields
+// but not the methods, so that encoding/json does the actual work.
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	type payment Payment
+	extra, err := unmarshalWithExtra(data, (*payment)(p))
+	p.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (p Payment) MarshalJSON() ([]byte, error) {
+	type payment Payment
+	return marshalWithExtra((*payment)(&p), p.ExtraFields)
+}
+
+func (p *Payment) extraFields() map[string]json.RawMessage { return p.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (p *PaymentSummary) UnmarshalJSON(data []byte) error {
+	type paymentSummary PaymentSummary
+	extra, err := unmarshalWithExtra(data, (*paymentSummary)(p))
+	p.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (p PaymentSummary) MarshalJSON() ([]byte, error) {
+	type paymentSummary PaymentSummary
+	return marshalWithExtra((*paymentSummary)(&p), p.ExtraFields)
+}
+
+func (p *PaymentSummary) extraFields() map[string]json.RawMessage { return p.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (r *Refund) UnmarshalJSON(data []byte) error {
+	type refund Refund
+	extra, err := unmarshalWithExtra(data, (*refund)(r))
+	r.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (r Refund) MarshalJSON() ([]byte, error) {
+	type refund Refund
+	return marshalWithExtra((*refund)(&r), r.ExtraFields)
+}
+
+func (r *Refund) extraFields() map[string]json.RawMessage { return r.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	type subscription Subscription
+	extra, err := unmarshalWithExtra(data, (*subscription)(s))
+	s.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (s Subscription) MarshalJSON() ([]byte, error) {
+	type subscription Subscription
+	return marshalWithExtra((*subscription)(&s), s.ExtraFields)
+}
+
+func (s *Subscription) extraFields() map[string]json.RawMessage { return s.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (c *Connection) UnmarshalJSON(data []byte) error {
+	type connection Connection
+	extra, err := unmarshalWithExtra(data, (*connection)(c))
+	c.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (c Connection) MarshalJSON() ([]byte, error) {
+	type connection Connection
+	return marshalWithExtra((*connection)(&c), c.ExtraFields)
+}
+
+func (c *Connection) extraFields() map[string]json.RawMessage { return c.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	extra, err := unmarshalWithExtra(data, (*customer)(c))
+	c.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type customer Customer
+	return marshalWithExtra((*customer)(&c), c.ExtraFields)
+}
+
+func (c *Customer) extraFields() map[string]json.RawMessage { return c.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown fields in ExtraFields.
+func (t *Transfer) UnmarshalJSON(data []byte) error {
+	type transfer Transfer
+	extra, err := unmarshalWithExtra(data, (*transfer)(t))
+	t.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (t Transfer) MarshalJSON() ([]byte, error) {
+	type transfer Transfer
+	return marshalWithExtra((*transfer)(&t), t.ExtraFields)
+}
+
+func (t *Transfer) extraFields() map[string]json.RawMessage { return t.ExtraFields }
+
+// UnmarshalJSON implements json.Unmarshaler, collecting unknown capabilities in ExtraFields.
+func (c *Capabilities) UnmarshalJSON(data []byte) error {
+	type capabilities Capabilities
+	extra, err := unmarshalWithExtra(data, (*capabilities)(c))
+	c.ExtraFields = extra
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, writing ExtraFields back.
+func (c Capabilities) MarshalJSON() ([]byte, error) {
+	type capabilities Capabilities
+	return marshalWithExtra((*capabilities)(&c), c.ExtraFields)
+}
+
+func (c *Capabilities) extraFields() map[string]json.RawMessage { return c.ExtraFields }
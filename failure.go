@@ -0,0 +1,116 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// FailureClass tells what to do about a failed payment or API call.
+type FailureClass string
+
+// Failure classes returned by ClassifyFailure.
+const (
+	// Retryable failures were transient, such as a network error, a server error or an
+	// issuer that did not answer. Retry now with the same idempotency key.
+	Retryable FailureClass = "retryable"
+	// RetryLater failures may clear with time, such as insufficient funds or a rate
+	// limit. Retry after a delay, e.g. on the next billing attempt.
+	RetryLater FailureClass = "retry_later"
+	// NotRetryable failures are hard declines and rejected requests. Retrying them
+	// fails again, and repeated retries of hard declines can draw network penalties.
+	NotRetryable FailureClass = "not_retryable"
+	// ContactCustomer failures need the customer to act first: approve the prompt,
+	// authenticate, use another card or ask their bank.
+	ContactCustomer FailureClass = "contact_customer"
+)
+
+// declineClasses maps the decline codes reported by the API, normalized across
+// providers, to the platform's guidance.
+var declineClasses = map[string]FailureClass{
+	"issuer_unavailable":   Retryable,
+	"processing_error":     Retryable,
+	"provider_unavailable": Retryable,
+	"try_again":            Retryable,
+
+	"insufficient_funds":        RetryLater,
+	"withdrawal_limit_exceeded": RetryLater,
+	"wallet_limit_exceeded":     RetryLater,
+	"card_velocity_exceeded":    RetryLater,
+
+	"authentication_required": ContactCustomer,
+	"customer_cancelled":      ContactCustomer,
+	"do_not_honor":            ContactCustomer,
+	"expired_card":            ContactCustomer,
+	"incorrect_cvc":           ContactCustomer,
+	"incorrect_pin":           ContactCustomer,
+	"prompt_timeout":          ContactCustomer,
+	"transaction_not_allowed": ContactCustomer,
+
+	"account_closed":         NotRetryable,
+	"card_not_supported":     NotRetryable,
+	"currency_not_supported": NotRetryable,
+	"fraudulent":             NotRetryable,
+	"invalid_account":        NotRetryable,
+	"invalid_number":         NotRetryable,
+	"lost_card":              NotRetryable,
+	"pickup_card":            NotRetryable,
+	"restricted_card":        NotRetryable,
+	"stolen_card":            NotRetryable,
+}
+
+// ClassifyDecline returns the class of a decline code, such as
+// PaymentRequiredError.DeclineCode or Payment.FailureCode. Unknown codes are treated as
+// hard declines.
+func ClassifyDecline(code string) FailureClass {
+	if class, ok := declineClasses[strings.ToLower(strings.TrimSpace(code))]; ok {
+		return class
+	}
+	return NotRetryable
+}
+
+// ClassifyFailure encodes the platform's retry guidance for v, which is a *Payment or an
+// error returned by the client, so that hard declines are not retried. It returns "" for
+// a nil error and for payments that have not failed.
+//
+// Declines are classified by their decline code. Transport errors, timeouts and 5xx
+// responses are Retryable; rate limits are RetryLater; other API errors, such as
+// validation or authentication failures, are NotRetryable, as are errors the SDK does
+// not recognize.
+func ClassifyFailure(v interface{}) FailureClass {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case *Payment:
+		if v == nil || v.Status != PaymentStatusFailed {
+			return ""
+		}
+		return ClassifyDecline(v.FailureCode)
+	case error:
+		return classifyError(v)
+	}
+	return ""
+}
+
+func classifyError(err error) FailureClass {
+	var declined *PaymentRequiredError
+	var rateLimited *RateLimitError
+	var serverErr *ServerError
+	var urlErr *url.Error
+	var netErr net.Error
+	switch {
+	case errors.As(err, &declined):
+		return ClassifyDecline(declined.DeclineCode)
+	case errors.As(err, &rateLimited):
+		return RetryLater
+	case errors.As(err, &serverErr):
+		return Retryable
+	case errors.Is(err, context.Canceled):
+		return NotRetryable
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &urlErr), errors.As(err, &netErr):
+		return Retryable
+	}
+	return NotRetryable
+}
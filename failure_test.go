@@ -0,0 +1,61 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	require.Equal(t, FailureClass(""), ClassifyFailure(nil))
+	require.Equal(t, FailureClass(""), ClassifyFailure(&Payment{Status: PaymentStatusSucceeded, FailureCode: "stolen_card"}))
+	require.Equal(t, RetryLater, ClassifyFailure(&Payment{Status: PaymentStatusFailed, FailureCode: "insufficient_funds"}))
+	require.Equal(t, ContactCustomer, ClassifyFailure(&Payment{Status: PaymentStatusFailed, FailureCode: "Expired_Card"}))
+	require.Equal(t, NotRetryable, ClassifyFailure(&Payment{Status: PaymentStatusFailed, FailureCode: "something_new"}))
+
+	require.Equal(t, NotRetryable, ClassifyFailure(context.Canceled))
+	require.Equal(t, Retryable, ClassifyFailure(fmt.Errorf("charging: %w", context.DeadlineExceeded)))
+	require.Equal(t, NotRetryable, ClassifyFailure(ErrWrongIDKind))
+}
+
+func TestClassifyFailureFromAPI(t *testing.T) {
+	responses := map[string]struct {
+		status int
+		body   string
+	}{
+		"/v1/payments/pay_1": {http.StatusPaymentRequired, `{"code":"card_declined","message":"declined","details":{"decline_code":"stolen_card"}}`},
+		"/v1/payments/pay_2": {http.StatusPaymentRequired, `{"code":"card_declined","message":"declined","details":{"decline_code":"issuer_unavailable"}}`},
+		"/v1/payments/pay_3": {http.StatusTooManyRequests, `{"code":"rate_limited","message":"slow down"}`},
+		"/v1/payments/pay_4": {http.StatusBadGateway, `{"message":"upstream"}`},
+		"/v1/payments/pay_5": {http.StatusUnauthorized, `{"message":"bad key"}`},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := responses[r.URL.Path]
+		w.WriteHeader(response.status)
+		_, _ = w.Write([]byte(response.body))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	for id, want := range map[string]FailureClass{
+		"pay_1": NotRetryable,
+		"pay_2": Retryable,
+		"pay_3": RetryLater,
+		"pay_4": Retryable,
+		"pay_5": NotRetryable,
+	} {
+		_, err := client.Payments.Get(context.Background(), id)
+		require.Error(t, err)
+		require.Equal(t, want, ClassifyFailure(err), id)
+	}
+
+	server.Close()
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+	require.False(t, errors.Is(err, context.Canceled))
+	require.Equal(t, Retryable, ClassifyFailure(err))
+}
@@ -0,0 +1,213 @@
+package reevit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// urlExpirySkew treats signed URLs that are about to expire as already expired, so a
+// download does not start on a URL that lapses mid-request.
+const urlExpirySkew = 30 * time.Second
+
+// ErrChecksumMismatch is returned when downloaded contents do not match the checksum or
+// size advertised in the file metadata, typically because the transfer was truncated.
+var ErrChecksumMismatch = errors.New("reevit: checksum mismatch")
+
+// FilesService handles downloads of generated files such as reports, invoice PDFs and exports.
+type FilesService service
+
+// File describes a generated file. URL is a short-lived signed URL.
+type File struct {
+	ID          string `json:"id"`
+	Purpose     string `json:"purpose"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// SHA256 is the hex-encoded SHA-256 checksum of the file contents.
+	SHA256    string    `json:"sha256"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WithoutChecksum lets Files.Download and Balance.DownloadSettlementExport proceed when the
+// file metadata carries no SHA-256 checksum, e.g. for files generated before checksums were
+// recorded. Without it such downloads fail with ErrChecksumMismatch. The size is still
+// checked when it is known.
+func WithoutChecksum() RequestOption {
+	return func(req *http.Request) {
+		if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok {
+			settings.skipChecksum = true
+		}
+	}
+}
+
+// WithDownloadProgress makes Files.Download and Balance.DownloadSettlementExport call fn as
+// bytes are written, e.g. to render a progress bar. Stage is "download", Processed the
+// bytes written so far and Total the file size when the metadata carries it.
+func WithDownloadProgress(fn ProgressFunc) RequestOption {
+	return func(req *http.Request) {
+		if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok {
+			settings.onDownloadProgress = fn
+		}
+	}
+}
+
+// progressWriter reports the bytes written through it.
+type progressWriter struct {
+	w          io.Writer
+	tracker    *ProgressTracker
+	onProgress ProgressFunc
+	written    int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.onProgress(p.tracker.Update("download", p.written))
+	return n, err
+}
+
+// downloadSettings returns the settings opts apply to a request. Downloads fetch from
+// storage with a plain request, so the options are evaluated separately.
+func downloadSettings(opts []RequestOption) *requestSettings {
+	settings := &requestSettings{}
+	req, err := http.NewRequestWithContext(context.WithValue(context.Background(), requestSettingsKey{}, settings), http.MethodGet, "/", nil)
+	if err != nil {
+		return settings
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return settings
+}
+
+// Expired reports whether the signed URL has expired or is about to.
+func (f *File) Expired() bool {
+	return f.URL == "" || (!f.ExpiresAt.IsZero() && time.Now().Add(urlExpirySkew).After(f.ExpiresAt))
+}
+
+// Get retrieves file metadata together with a freshly signed URL.
+//
+// API Docs: GET /v1/files/{id}
+func (s *FilesService) Get(ctx context.Context, fileID string, opts ...RequestOption) (*File, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/files/%s", fileID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := s.client.do(httpRequest, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// SignedURL returns a usable signed URL for file, refreshing file in place when its URL
+// has expired.
+func (s *FilesService) SignedURL(ctx context.Context, file *File, opts ...RequestOption) (string, error) {
+	if !file.Expired() {
+		return file.URL, nil
+	}
+	fresh, err := s.Get(ctx, file.ID, opts...)
+	if err != nil {
+		return "", err
+	}
+	*file = *fresh
+	return file.URL, nil
+}
+
+// Download streams the contents of fileID to w and verifies them against the checksum and
+// size in the file metadata, returning ErrChecksumMismatch when they differ or when the
+// metadata has no checksum, unless WithoutChecksum is given. The signed URL is refreshed
+// once if storage rejects it as expired.
+//
+// The client-wide HTTP timeout does not apply to the transfer, which may take minutes for
+// large exports; bound it with ctx or WithRequestTimeout.
+//
+// Bytes are written to w as they arrive, so when Download fails w may hold a partial or
+// corrupt file; write to a temporary location and keep it only on success.
+func (s *FilesService) Download(ctx context.Context, fileID string, w io.Writer, opts ...RequestOption) (*File, error) {
+	file, err := s.Get(ctx, fileID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return file, s.download(ctx, file, w, opts...)
+}
+
+func (s *FilesService) download(ctx context.Context, file *File, w io.Writer, opts ...RequestOption) error {
+	settings := downloadSettings(opts)
+	if file.SHA256 == "" && !settings.skipChecksum {
+		return fmt.Errorf("%w: file %s has no sha256 checksum to verify against", ErrChecksumMismatch, file.ID)
+	}
+
+	if settings.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, settings.timeout)
+		defer cancel()
+	}
+	// Large files take longer than the client-wide timeout to stream, so the download is
+	// bounded by ctx and WithRequestTimeout instead.
+	httpClient := *s.client.httpClient
+	httpClient.Timeout = 0
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		signedURL, err := s.SignedURL(ctx, file, opts...)
+		if err != nil {
+			return err
+		}
+		// Signed URLs usually point at object storage, so the request carries no API credentials.
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, signedURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err = httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		if (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusGone) && attempt == 0 {
+			resp.Body.Close()
+			file.URL = ""
+			continue
+		}
+		break
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return newError(resp, body)
+	}
+
+	var digest hash.Hash
+	if file.SHA256 != "" {
+		digest = sha256.New()
+		w = io.MultiWriter(w, digest)
+	}
+	if settings.onDownloadProgress != nil {
+		w = &progressWriter{w: w, tracker: NewProgressTracker(file.Size, 0), onProgress: settings.onDownloadProgress}
+	}
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return err
+	}
+	if file.Size > 0 && written != file.Size {
+		return fmt.Errorf("%w: file %s: expected %d bytes, got %d", ErrChecksumMismatch, file.ID, file.Size, written)
+	}
+	if digest != nil {
+		if sum := hex.EncodeToString(digest.Sum(nil)); !strings.EqualFold(sum, file.SHA256) {
+			return fmt.Errorf("%w: file %s: expected sha256 %s, got %s", ErrChecksumMismatch, file.ID, file.SHA256, sum)
+		}
+	}
+	return nil
+}
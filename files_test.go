@@ -0,0 +1,149 @@
+package reevit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesDownload(t *testing.T) {
+	contents := []byte("id,amount\npay_1,100\n")
+	sum := sha256.Sum256(contents)
+	checksum := hex.EncodeToString(sum[:])
+
+	var server *httptest.Server
+	var signed, storageKeys int
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/files/file_1", "/v1/files/file_bad":
+			signed++
+			expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			fileChecksum := checksum
+			if r.URL.Path == "/v1/files/file_bad" {
+				fileChecksum = "00"
+			}
+			fmt.Fprintf(w, `{"id":"file_1","sha256":%q,"url":"%s/storage/%d","expires_at":%q}`, fileChecksum, server.URL, signed, expires)
+		case "/storage/1":
+			// The first signed URL is rejected as expired by storage.
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			if r.Header.Get("X-Reevit-Key") != "" {
+				storageKeys++
+			}
+			_, _ = w.Write(contents)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	var buf bytes.Buffer
+	file, err := client.Files.Download(context.Background(), "file_1", &buf)
+	require.NoError(t, err)
+	require.Equal(t, contents, buf.Bytes())
+	require.Equal(t, 2, signed)
+	require.Equal(t, server.URL+"/storage/2", file.URL)
+	require.Zero(t, storageKeys)
+
+	_, err = client.Files.Download(context.Background(), "file_bad", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestFilesDownloadWithoutChecksum(t *testing.T) {
+	contents := []byte("legacy report")
+	var server *httptest.Server
+	downloads := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/files/file_legacy" {
+			fmt.Fprintf(w, `{"id":"file_legacy","size":%d,"url":"%s/storage/legacy"}`, len(contents), server.URL)
+			return
+		}
+		downloads++
+		_, _ = w.Write(contents)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	var buf bytes.Buffer
+	_, err := client.Files.Download(context.Background(), "file_legacy", &buf)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.Zero(t, downloads)
+	require.Zero(t, buf.Len())
+
+	var updates []Progress
+	_, err = client.Files.Download(context.Background(), "file_legacy", &buf, WithoutChecksum(), WithDownloadProgress(func(p Progress) {
+		updates = append(updates, p)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, contents, buf.Bytes())
+	require.NotEmpty(t, updates)
+	last := updates[len(updates)-1]
+	require.Equal(t, "download", last.Stage)
+	require.Equal(t, int64(len(contents)), last.Processed)
+	require.Equal(t, float64(100), last.Percent())
+}
+
+func TestFileExpired(t *testing.T) {
+	require.True(t, (&File{}).Expired())
+	require.True(t, (&File{URL: "https://files", ExpiresAt: time.Now().Add(10 * time.Second)}).Expired())
+	require.False(t, (&File{URL: "https://files", ExpiresAt: time.Now().Add(time.Hour)}).Expired())
+}
+
+func TestDownloadSettlementExportDetectsTruncation(t *testing.T) {
+	full := []byte("settlement_id,payment_id,net_amount\nstl_1,pay_1,9700\n")
+	sum := sha256.Sum256(full)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/settlements/stl_1/export" {
+			fmt.Fprintf(w, `{"id":"file_stl_1","sha256":%q,"size":%d,"url":"%s/storage/stl_1"}`, hex.EncodeToString(sum[:]), len(full), server.URL)
+			return
+		}
+		_, _ = w.Write(full[:20])
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Balance.DownloadSettlementExport(context.Background(), "stl_1", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.ErrorContains(t, err, "expected 53 bytes, got 20")
+}
+
+func TestFilesDownloadOutlastsClientTimeout(t *testing.T) {
+	contents := []byte("id,amount\npay_1,100\npay_2,200\n")
+	sum := sha256.Sum256(contents)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/files/file_1" {
+			fmt.Fprintf(w, `{"id":"file_1","sha256":%q,"url":"%s/storage/1"}`, hex.EncodeToString(sum[:]), server.URL)
+			return
+		}
+		// The body streams for longer than the client's timeout.
+		_, _ = w.Write(contents[:10])
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(150 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write(contents[10:])
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+	var buf bytes.Buffer
+	_, err := client.Files.Download(context.Background(), "file_1", &buf)
+	require.NoError(t, err)
+	require.Equal(t, contents, buf.Bytes())
+
+	_, err = client.Files.Download(context.Background(), "file_1", &bytes.Buffer{}, WithRequestTimeout(50*time.Millisecond))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
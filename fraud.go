@@ -2,7 +2,11 @@ package reevit
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // FraudService handles communication with the fraud policy related methods of the Reevit API.
@@ -20,14 +24,14 @@ type FraudPolicy struct {
 // Get retrieves the current fraud policy.
 //
 // API Docs: GET /v1/policies/fraud
-func (s *FraudService) Get(ctx context.Context) (*FraudPolicy, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, "/v1/policies/fraud", nil)
+func (s *FraudService) Get(ctx context.Context, opts ...RequestOption) (*FraudPolicy, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/policies/fraud", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var policy FraudPolicy
-	if err := s.client.do(ctx, httpRequest, &policy); err != nil {
+	if err := s.client.do(httpRequest, &policy); err != nil {
 		return nil, err
 	}
 
@@ -38,19 +42,184 @@ func (s *FraudService) Get(ctx context.Context) (*FraudPolicy, error) {
 //
 // API Docs: POST /v1/policies/fraud
 func (s *FraudService) Update(ctx context.Context, policy *FraudPolicy, opts ...RequestOption) (*FraudPolicy, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/policies/fraud", policy)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/policies/fraud", policy, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var updatedPolicy FraudPolicy
-	if err := s.client.do(ctx, httpRequest, &updatedPolicy); err != nil {
+	if err := s.client.do(httpRequest, &updatedPolicy); err != nil {
 		return nil, err
 	}
 
 	return &updatedPolicy, nil
 }
+
+// Fraud actions reported by FraudDecision.
+const (
+	FraudActionAllow  = "allow"
+	FraudActionReview = "review"
+	FraudActionBlock  = "block"
+)
+
+// FraudDecision is the outcome of evaluating a payment against the fraud policy.
+type FraudDecision struct {
+	// Action is the action that would be taken: FraudActionAllow, FraudActionReview or FraudActionBlock.
+	Action       string           `json:"action"`
+	RiskScore    float64          `json:"risk_score"`
+	MatchedRules []FraudRuleMatch `json:"matched_rules"`
+}
+
+// FraudRuleMatch describes a policy rule that matched during evaluation.
+type FraudRuleMatch struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// Evaluate dry-runs the fraud policy against a payment without creating it. Set
+// req.Policy to preview a policy change before enabling it with Update.
+//
+// API Docs: POST /v1/policies/fraud/evaluate
+func (s *FraudService) Evaluate(ctx context.Context, req *PaymentIntentRequest, opts ...RequestOption) (*FraudDecision, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/policies/fraud/evaluate", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var decision FraudDecision
+	if err := s.client.do(httpRequest, &decision); err != nil {
+		return nil, err
+	}
+
+	return &decision, nil
+}
+
+// Block list entry types.
+const (
+	BlockTypeBIN         = "bin"
+	BlockTypeCustomer    = "customer"
+	BlockTypeEmailDomain = "email_domain"
+)
+
+// BlockEntry is a single entry of the fraud block list.
+type BlockEntry struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Value     string    `json:"value"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlockEntryListOptions contains filters for block list listing.
+type BlockEntryListOptions struct {
+	Limit  int
+	Offset int
+	Type   string
+}
+
+// AddBlockedBIN blocks card payments whose BIN (the first 6 to 8 digits) matches bin.
+//
+// API Docs: POST /v1/policies/fraud/blocklist
+func (s *FraudService) AddBlockedBIN(ctx context.Context, bin, reason string, opts ...RequestOption) (*BlockEntry, error) {
+	bin = strings.TrimSpace(bin)
+	if len(bin) < 6 || len(bin) > 8 || strings.Trim(bin, "0123456789") != "" {
+		return nil, fmt.Errorf("reevit: BIN must be 6 to 8 digits, got %q", bin)
+	}
+	return s.addBlockEntry(ctx, BlockTypeBIN, bin, reason, opts...)
+}
+
+// RemoveBlockedBIN removes bin from the block list.
+//
+// API Docs: DELETE /v1/policies/fraud/blocklist/bin/{bin}
+func (s *FraudService) RemoveBlockedBIN(ctx context.Context, bin string, opts ...RequestOption) error {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/policies/fraud/blocklist/bin/%s", strings.TrimSpace(bin)), nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(httpRequest, nil)
+}
+
+// BlockCustomer blocks all payments from a customer.
+//
+// API Docs: POST /v1/policies/fraud/blocklist
+func (s *FraudService) BlockCustomer(ctx context.Context, customerID, reason string, opts ...RequestOption) (*BlockEntry, error) {
+	return s.addBlockEntry(ctx, BlockTypeCustomer, strings.TrimSpace(customerID), reason, opts...)
+}
+
+// BlockEmailDomain blocks payments from customers whose email address is on domain.
+//
+// API Docs: POST /v1/policies/fraud/blocklist
+func (s *FraudService) BlockEmailDomain(ctx context.Context, domain, reason string, opts ...RequestOption) (*BlockEntry, error) {
+	domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+	return s.addBlockEntry(ctx, BlockTypeEmailDomain, domain, reason, opts...)
+}
+
+// RemoveBlockEntry removes an entry of any type from the block list.
+//
+// API Docs: DELETE /v1/policies/fraud/blocklist/{id}
+func (s *FraudService) RemoveBlockEntry(ctx context.Context, entryID string, opts ...RequestOption) error {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/policies/fraud/blocklist/%s", entryID), nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(httpRequest, nil)
+}
+
+// ListBlockEntries returns the block list.
+//
+// API Docs: GET /v1/policies/fraud/blocklist
+func (s *FraudService) ListBlockEntries(ctx context.Context, options *BlockEntryListOptions, opts ...RequestOption) ([]BlockEntry, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "type", options.Type)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/policies/fraud/blocklist", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[BlockEntry](s.client, raw, "entries")
+}
+
+// ListBlockEntriesAutoPaging returns an iterator over the whole block list.
+func (s *FraudService) ListBlockEntriesAutoPaging(ctx context.Context, options BlockEntryListOptions, opts ...RequestOption) *Iter[BlockEntry] {
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]BlockEntry, error) {
+		page := options
+		page.Limit = limit
+		page.Offset = offset
+		return s.ListBlockEntries(ctx, &page, opts...)
+	})
+}
+
+func (s *FraudService) addBlockEntry(ctx context.Context, entryType, value, reason string, opts ...RequestOption) (*BlockEntry, error) {
+	if value == "" {
+		return nil, fmt.Errorf("reevit: %s block entry requires a value", entryType)
+	}
+	body := map[string]interface{}{"type": entryType, "value": value}
+	if reason != "" {
+		body["reason"] = reason
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/policies/fraud/blocklist", body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry BlockEntry
+	if err := s.client.do(httpRequest, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
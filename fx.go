@@ -0,0 +1,122 @@
+package reevit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FXService handles communication with the FX rate endpoints of the Reevit API.
+type FXService service
+
+// FXRate is the exchange rate from Base to Quote effective on Date.
+type FXRate struct {
+	Base  string  `json:"base"`
+	Quote string  `json:"quote"`
+	Rate  float64 `json:"rate"`
+	Date  string  `json:"date"` // YYYY-MM-DD
+}
+
+// GetRate returns the exchange rate effective on the given date.
+//
+// API Docs: GET /v1/fx/rates
+func (s *FXService) GetRate(ctx context.Context, base, quote string, date time.Time, opts ...RequestOption) (*FXRate, error) {
+	values := url.Values{}
+	setString(values, "base", strings.ToUpper(base))
+	setString(values, "quote", strings.ToUpper(quote))
+	setString(values, "date", date.UTC().Format("2006-01-02"))
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/fx/rates", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rate FXRate
+	if err := s.client.do(httpRequest, &rate); err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// ReportingAmount is an amount in minor units recorded on a given date.
+type ReportingAmount struct {
+	Amount   int64
+	Currency string
+	Date     time.Time
+}
+
+// CurrencyConverter converts amounts into a single reporting currency using the
+// rate effective on each transaction date. Rates are cached per currency and day,
+// and a converter is safe for concurrent use.
+type CurrencyConverter struct {
+	fx        *FXService
+	reporting string
+
+	mu    sync.Mutex
+	rates map[string]float64
+}
+
+// NewConverter returns a converter into the given reporting currency.
+func (s *FXService) NewConverter(reportingCurrency string) *CurrencyConverter {
+	return &CurrencyConverter{
+		fx:        s,
+		reporting: strings.ToUpper(strings.TrimSpace(reportingCurrency)),
+		rates:     map[string]float64{},
+	}
+}
+
+// Convert converts an amount in minor units of currency into minor units of the reporting currency.
+func (c *CurrencyConverter) Convert(ctx context.Context, amount int64, currency string, date time.Time) (int64, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == c.reporting {
+		return amount, nil
+	}
+
+	rate, err := c.rate(ctx, currency, date)
+	if err != nil {
+		return 0, err
+	}
+
+	major := float64(amount) / math.Pow10(CurrencyDigits(currency))
+	return int64(math.Round(major * rate * math.Pow10(CurrencyDigits(c.reporting)))), nil
+}
+
+// Sum converts every amount into the reporting currency and returns the total.
+func (c *CurrencyConverter) Sum(ctx context.Context, amounts []ReportingAmount) (int64, error) {
+	var total int64
+	for _, amount := range amounts {
+		converted, err := c.Convert(ctx, amount.Amount, amount.Currency, amount.Date)
+		if err != nil {
+			return 0, err
+		}
+		total += converted
+	}
+	return total, nil
+}
+
+func (c *CurrencyConverter) rate(ctx context.Context, currency string, date time.Time) (float64, error) {
+	key := currency + "|" + date.UTC().Format("2006-01-02")
+
+	c.mu.Lock()
+	rate, ok := c.rates[key]
+	c.mu.Unlock()
+	if ok {
+		return rate, nil
+	}
+
+	fxRate, err := c.fx.GetRate(ctx, currency, c.reporting, date)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.rates[key] = fxRate.Rate
+	c.mu.Unlock()
+
+	return fxRate.Rate, nil
+}
@@ -0,0 +1,61 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyConverter(t *testing.T) {
+	rates := map[string]float64{"GHS": 0.08, "UGX": 0.00027}
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		require.Equal(t, "/v1/fx/rates", r.URL.Path)
+		query := r.URL.Query()
+		require.Equal(t, "USD", query.Get("quote"))
+		rate, ok := rates[query.Get("base")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"no rate"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"base":%q,"quote":"USD","rate":%v,"date":%q}`, query.Get("base"), rate, query.Get("date"))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	converter := client.FX.NewConverter(" usd ")
+	ctx := context.Background()
+	day := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+
+	converted, err := converter.Convert(ctx, 10000, "ghs", day)
+	require.NoError(t, err)
+	require.Equal(t, int64(800), converted)
+
+	converted, err = converter.Convert(ctx, 37000, "UGX", day)
+	require.NoError(t, err)
+	require.Equal(t, int64(999), converted)
+
+	converted, err = converter.Convert(ctx, 1234, "USD", day)
+	require.NoError(t, err)
+	require.Equal(t, int64(1234), converted)
+	require.Equal(t, 2, fetches)
+
+	total, err := converter.Sum(ctx, []ReportingAmount{
+		{Amount: 10000, Currency: "GHS", Date: day},
+		{Amount: 2500, Currency: "GHS", Date: day.Add(time.Hour)},
+		{Amount: 100, Currency: "USD", Date: day},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(800+200+100), total)
+	require.Equal(t, 3, fetches, "the next day's rate is fetched once")
+
+	_, err = converter.Sum(ctx, []ReportingAmount{{Amount: 100, Currency: "XOF", Date: day}})
+	require.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultGroupLimit bounds the steps a Group runs at once when the client has no rate limit.
+const defaultGroupLimit = 4
+
+// Group runs the steps of a multi-call workflow, such as creating a customer, creating a
+// payment intent for it and attaching metadata, as a unit. It behaves like errgroup: the
+// first failing step cancels the group's context and is returned by Wait. In addition,
+// every step gets an idempotency key derived from the workflow and step names, and the
+// rollbacks registered by completed steps run in reverse order when the workflow fails.
+//
+// The step keys include the 5-minute bucket of GenerateIdempotencyKey, so a workflow
+// re-run after a crash only avoids duplicates if it runs in the same bucket, or the
+// previous one with WithIdempotencyBucketGuard. Configure WithIdempotencyStore to keep
+// the keys of a workflow until they expire, whenever it is re-run.
+//
+//	g, ctx := client.NewGroup(ctx, "signup:"+userID)
+//	var customer *reevit.Customer
+//	g.Do(ctx, "customer", func(ctx context.Context, step *reevit.Step) error {
+//		var err error
+//		customer, err = client.Customers.Create(ctx, customerReq, step.Idempotency())
+//		if err == nil {
+//			step.OnRollback(func(ctx context.Context) error {
+//				return client.Customers.Delete(ctx, customer.ID)
+//			})
+//		}
+//		return err
+//	})
+//	g.Go(ctx, "intent", func(ctx context.Context, step *reevit.Step) error { ... })
+//	err := g.Wait()
+type Group struct {
+	client   *Client
+	workflow string
+	cancel   context.CancelFunc
+	sem      chan struct{}
+	wg       sync.WaitGroup
+
+	mu        sync.Mutex
+	err       error
+	rollbacks []func(context.Context) error
+}
+
+// Step is passed to each step of a Group.
+type Step struct {
+	// Name is the step name given to Go or Do.
+	Name string
+	// IdempotencyKey is derived from the workflow and step names through
+	// Client.IdempotencyKey, so it is coordinated by WithIdempotencyStore when configured.
+	IdempotencyKey string
+
+	group *Group
+}
+
+// Idempotency returns a RequestOption sending the step's idempotency key. Pass it to the
+// one call of the step that creates or changes data.
+func (s *Step) Idempotency() RequestOption {
+	return WithIdempotencyKey(s.IdempotencyKey)
+}
+
+// OnRollback registers fn to undo the step's effect if the workflow fails. Register it
+// once the effect has happened; rollbacks run in reverse order of registration, with a
+// context that is not cancelled by the failure.
+func (s *Step) OnRollback(fn func(ctx context.Context) error) {
+	s.group.mu.Lock()
+	defer s.group.mu.Unlock()
+	s.group.rollbacks = append(s.group.rollbacks, fn)
+}
+
+// NewGroup returns a Group for the workflow, named uniquely per business operation (for
+// example "signup:"+userID), and a context derived from ctx that is cancelled when a step
+// fails or Wait returns. Steps run at most as many at a time as the burst of WithRateLimit,
+// or 4 without a rate limit, so a workflow does not queue behind the client's limiter.
+func (c *Client) NewGroup(ctx context.Context, workflow string) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	limit := defaultGroupLimit
+	if c.limiter != nil {
+		limit = int(c.limiter.burst)
+	}
+	return &Group{
+		client:   c,
+		workflow: workflow,
+		cancel:   cancel,
+		sem:      make(chan struct{}, limit),
+	}, ctx
+}
+
+// SetLimit changes the number of steps run at once. It must not be called while steps run.
+func (g *Group) SetLimit(n int) {
+	if n < 1 {
+		n = 1
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs step fn in a new goroutine. The context passed to fn is cancelled as soon as
+// any step fails.
+func (g *Group) Go(ctx context.Context, name string, fn func(ctx context.Context, step *Step) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		_ = g.run(ctx, name, fn)
+	}()
+}
+
+// Do runs step fn synchronously, for steps that need the result of an earlier one. It
+// returns the group's first error, which is fn's own unless another step failed first.
+// Once the group has failed, fn is not called.
+func (g *Group) Do(ctx context.Context, name string, fn func(ctx context.Context, step *Step) error) error {
+	return g.run(ctx, name, fn)
+}
+
+// Wait waits for the steps started with Go and returns the first error. If a step failed,
+// the registered rollbacks run before Wait returns, and their errors are joined to it.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	err := g.err
+	rollbacks := g.rollbacks
+	g.rollbacks = nil
+	g.mu.Unlock()
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	ctx := context.Background()
+	for i := len(rollbacks) - 1; i >= 0; i-- {
+		if rollbackErr := rollbacks[i](ctx); rollbackErr != nil {
+			errs = append(errs, fmt.Errorf("reevit: rollback of workflow %q: %w", g.workflow, rollbackErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *Group) run(ctx context.Context, name string, fn func(ctx context.Context, step *Step) error) error {
+	if err := g.failed(); err != nil {
+		return err
+	}
+	select {
+	case g.sem <- struct{}{}:
+	case <-ctx.Done():
+		return g.fail(ctx.Err())
+	}
+	defer func() { <-g.sem }()
+
+	key, err := g.client.IdempotencyKey(ctx, g.workflow+":"+name, map[string]any{
+		"workflow": g.workflow,
+		"step":     name,
+	})
+	if err != nil {
+		return g.fail(err)
+	}
+	if err := fn(ctx, &Step{Name: name, IdempotencyKey: key, group: g}); err != nil {
+		return g.fail(fmt.Errorf("reevit: step %q of workflow %q: %w", name, g.workflow, err))
+	}
+	return nil
+}
+
+func (g *Group) failed() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// fail records the group's first error and cancels its context.
+func (g *Group) fail(err error) error {
+	g.mu.Lock()
+	if g.err == nil {
+		g.err = err
+		g.cancel()
+	}
+	first := g.err
+	g.mu.Unlock()
+	return first
+}
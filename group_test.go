@@ -0,0 +1,80 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Method+" "+r.URL.Path] = r.Header.Get("Idempotency-Key")
+		mu.Unlock()
+		if r.URL.Path == "/v1/payments/intents" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"bad_request","message":"currency not enabled"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"cus_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	g, ctx := client.NewGroup(context.Background(), "signup:u1")
+
+	var rolledBack []string
+	require.NoError(t, g.Do(ctx, "customer", func(ctx context.Context, step *Step) error {
+		customer, err := client.Customers.Create(ctx, &CreateCustomerRequest{Email: "a@example.com"}, step.Idempotency())
+		if err != nil {
+			return err
+		}
+		step.OnRollback(func(ctx context.Context) error {
+			rolledBack = append(rolledBack, customer.ID)
+			return client.Customers.Delete(ctx, customer.ID)
+		})
+		return nil
+	}))
+	g.Go(ctx, "intent", func(ctx context.Context, step *Step) error {
+		_, err := client.Payments.CreateIntent(ctx, &PaymentIntentRequest{Amount: 100, Currency: "GHS"}, step.Idempotency())
+		return err
+	})
+
+	err := g.Wait()
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Contains(t, err.Error(), `step "intent" of workflow "signup:u1"`)
+	require.Equal(t, []string{"cus_1"}, rolledBack)
+	require.Error(t, ctx.Err())
+
+	require.True(t, strings.HasPrefix(keys["POST /v1/customers"], "reevit_"))
+	require.NotEqual(t, keys["POST /v1/customers"], keys["POST /v1/payments/intents"])
+	require.Contains(t, keys, "DELETE /v1/customers/cus_1")
+
+	called := false
+	require.ErrorIs(t, g.Do(ctx, "metadata", func(ctx context.Context, step *Step) error {
+		called = true
+		return nil
+	}), apiErr)
+	require.False(t, called)
+}
+
+func TestGroupSuccessSkipsRollback(t *testing.T) {
+	client := NewClient("pfk_test", "org_1", WithRateLimit(10, 2))
+	g, ctx := client.NewGroup(context.Background(), "w")
+	require.Equal(t, 2, cap(g.sem))
+
+	g.Go(ctx, "a", func(ctx context.Context, step *Step) error {
+		step.OnRollback(func(ctx context.Context) error { return errors.New("must not run") })
+		return nil
+	})
+	require.NoError(t, g.Wait())
+}
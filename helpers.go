@@ -1,11 +1,14 @@
 package reevit
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func normalizePath(path string) string {
@@ -21,6 +24,25 @@ func isPublicPath(path string) bool {
 	return strings.HasPrefix(normalized, "/v1/pay/")
 }
 
+// pathf formats an API path, escaping each argument as a single path segment so IDs
+// containing reserved characters cannot change the route or smuggle in a query string.
+func pathf(format string, segments ...string) string {
+	escaped := make([]interface{}, len(segments))
+	for i, segment := range segments {
+		escaped[i] = escapeSegment(segment)
+	}
+	return fmt.Sprintf(format, escaped...)
+}
+
+func escapeSegment(segment string) string {
+	switch segment {
+	case ".", "..":
+		// Dot segments are otherwise resolved by servers and proxies.
+		return strings.ReplaceAll(segment, ".", "%2E")
+	}
+	return url.PathEscape(segment)
+}
+
 func buildPath(path string, values url.Values) string {
 	normalized := normalizePath(path)
 	encoded := values.Encode()
@@ -30,6 +52,12 @@ func buildPath(path string, values url.Values) string {
 	return normalized + "?" + encoded
 }
 
+func setHeader(header http.Header, key, value string) {
+	if value != "" {
+		header.Set(key, value)
+	}
+}
+
 func setString(values url.Values, key, value string) {
 	if trimmed := strings.TrimSpace(value); trimmed != "" {
 		values.Set(key, trimmed)
@@ -54,9 +82,18 @@ func setBool(values url.Values, key string, value *bool) {
 	}
 }
 
-func decodeArrayResponse[T any](body []byte, key string) ([]T, error) {
+func setTime(values url.Values, key string, value time.Time) {
+	if !value.IsZero() {
+		values.Set(key, value.UTC().Format(time.RFC3339))
+	}
+}
+
+func decodeArrayResponse[T any](c *Client, body []byte, key string) ([]T, error) {
 	var direct []T
-	if err := json.Unmarshal(body, &direct); err == nil {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := c.decodeJSON(trimmed, &direct); err != nil {
+			return nil, err
+		}
 		return direct, nil
 	}
 
@@ -70,7 +107,7 @@ func decodeArrayResponse[T any](body []byte, key string) ([]T, error) {
 		return nil, fmt.Errorf("reevit: response did not include %q", key)
 	}
 
-	if err := json.Unmarshal(raw, &direct); err != nil {
+	if err := c.decodeJSON(raw, &direct); err != nil {
 		return nil, err
 	}
 
@@ -1,36 +1,173 @@
 package reevit
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
-	"encoding/json"
 	"fmt"
-	"sort"
-	"strings"
+	"net/http"
+	"sync"
 	"time"
 )
 
+// IdempotencyBucket is the width of the time buckets that GenerateIdempotencyKey folds
+// into its keys: identical parameters give the same key within a bucket.
+const IdempotencyBucket = 5 * time.Minute
+
 // GenerateIdempotencyKey creates a deterministic idempotency key from input parameters.
-// It uses a stable key ordering and a 5-minute time bucket (matching JS SDK behavior).
+// The parameters are canonicalized as described in canonical.go, so the key is identical
+// to the one the JS SDK generates for the same logical request, and combined with a
+// 5-minute time bucket.
+//
+// A retry made after a bucket boundary gets a new key and can charge twice. Retries that
+// may straddle a boundary should use GenerateIdempotencyKeyAt with the time of the first
+// attempt, or go through Client.IdempotencyKey with WithIdempotencyBucketGuard.
 func GenerateIdempotencyKey(params map[string]any) string {
-	keys := make([]string, 0, len(params))
-	for key := range params {
-		keys = append(keys, key)
+	return GenerateIdempotencyKeyAt(params, time.Now())
+}
+
+// GenerateIdempotencyKeyAt is like GenerateIdempotencyKey but uses the bucket of
+// firstAttempt, so that every retry of a request passing the time of its first attempt
+// gets the same key, whenever it runs.
+func GenerateIdempotencyKeyAt(params map[string]any, firstAttempt time.Time) string {
+	sum := sha256.Sum256([]byte(canonicalParams(params)))
+	return idempotencyKey(sum, idempotencyBucket(firstAttempt))
+}
+
+func idempotencyBucket(t time.Time) int64 {
+	return t.Unix() / int64(IdempotencyBucket/time.Second)
+}
+
+func idempotencyKey(sum [sha256.Size]byte, bucket int64) string {
+	return fmt.Sprintf("reevit_%d_%x", bucket, sum)
+}
+
+// IdempotencyKeyFor derives an idempotency key from a request struct, such as a
+// *PaymentIntentRequest. The struct is encoded as the API receives it and its fields are
+// used as the parameters of GenerateIdempotencyKey, so the key only depends on the JSON
+// field values and matches the key other SDKs generate for the same request body. A
+// value that does not encode to a JSON object is used as the parameter "request".
+func IdempotencyKeyFor(req any) string {
+	return GenerateIdempotencyKey(idempotencyParams(req))
+}
+
+func idempotencyParams(req any) map[string]any {
+	switch value := normalizeCanonical(req).(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		return value
+	default:
+		return map[string]any{"request": value}
 	}
-	sort.Strings(keys)
+}
+
+// WithAutoIdempotency sets an idempotency key on every POST, PUT, PATCH and DELETE request
+// made without WithIdempotencyKey. The key is derived from the method, path and body like
+// IdempotencyKeyFor, so retrying a failed call with the same request within the 5-minute
+// bucket cannot apply it twice, while two calls to the same endpoint with different
+// bodies never share a key. Combine it with WithIdempotencyBucketGuard to keep keys
+// across bucket boundaries. Identical requests that are meant to be applied twice within
+// a bucket, such as two equal top-ups, need distinct keys set with WithIdempotencyKey.
+// Keys include the organization the request acts for, so the same request made for two
+// organizations with WithOrgOverride gets two keys.
+//
+// Action endpoints that are meant to be called again with the same request, such as
+// Payments.Retry, CustomDomains.Verify and the Sandbox outage controls, get a fresh key
+// on every call instead; retries of one call still share it.
+func WithAutoIdempotency() Option {
+	return func(c *Client) {
+		c.autoIdempotency = true
+	}
+}
+
+// autoIdempotencyKey returns the key WithAutoIdempotency sets on req.
+func (c *Client) autoIdempotencyKey(req *http.Request, path string, body interface{}) string {
+	if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok && settings.freshIdempotencyKey {
+		return randomIdempotencyKey()
+	}
+	org := req.Header.Get("Reevit-Account")
+	if org == "" {
+		org = req.Header.Get("X-Org-Id")
+	}
+	return c.generateIdempotencyKey(map[string]any{
+		"method": req.Method,
+		"path":   path,
+		"org":    org,
+		"body":   idempotencyParams(body),
+	})
+}
 
-	var builder strings.Builder
-	for i, key := range keys {
-		if i > 0 {
-			builder.WriteString("|")
+// withFreshIdempotencyKey marks requests to action endpoints, which WithAutoIdempotency
+// must not replay when they are called again with the same request.
+func withFreshIdempotencyKey() RequestOption {
+	return func(req *http.Request) {
+		if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok {
+			settings.freshIdempotencyKey = true
 		}
-		valueBytes, _ := json.Marshal(params[key])
-		builder.WriteString(key)
-		builder.WriteString(":")
-		builder.Write(valueBytes)
 	}
+}
+
+// randomIdempotencyKey returns a key that is never generated twice.
+func randomIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to the clock.
+		return fmt.Sprintf("reevit_%d", time.Now().UnixNano())
+	}
+	return fmt.Sprintf("reevit_%x", b)
+}
+
+// generateIdempotencyKey is GenerateIdempotencyKey, through the bucket guard when the
+// client has one.
+func (c *Client) generateIdempotencyKey(params map[string]any) string {
+	if c.bucketGuard != nil {
+		return c.bucketGuard.key(params, time.Now())
+	}
+	return GenerateIdempotencyKey(params)
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
 
-	sum := sha256.Sum256([]byte(builder.String()))
-	timeBucket := time.Now().Unix() / int64(5*60)
+// WithIdempotencyBucketGuard makes Client.IdempotencyKey remember the keys it generated
+// in the current and previous buckets. Parameters seen in the previous bucket reuse that
+// bucket's key, so a retry storm crossing a bucket boundary keeps one key instead of
+// flipping to a new one. Keys are remembered by this client only; replicas should share
+// keys through WithIdempotencyStore instead.
+//
+// With the guard, identical parameters share a key for 5 to 10 minutes, rather than
+// until the next boundary.
+func WithIdempotencyBucketGuard() Option {
+	return func(c *Client) {
+		c.bucketGuard = &bucketGuard{seen: make(map[[sha256.Size]byte]int64)}
+	}
+}
+
+// bucketGuard records the bucket in which each set of parameters was first seen.
+type bucketGuard struct {
+	mu   sync.Mutex
+	seen map[[sha256.Size]byte]int64
+}
 
-	return fmt.Sprintf("reevit_%d_%x", timeBucket, sum)
+func (g *bucketGuard) key(params map[string]any, now time.Time) string {
+	sum := sha256.Sum256([]byte(canonicalParams(params)))
+	bucket := idempotencyBucket(now)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for seenSum, seenBucket := range g.seen {
+		if seenBucket < bucket-1 {
+			delete(g.seen, seenSum)
+		}
+	}
+	if seenBucket, ok := g.seen[sum]; ok && seenBucket <= bucket {
+		return idempotencyKey(sum, seenBucket)
+	}
+	g.seen[sum] = bucket
+	return idempotencyKey(sum, bucket)
 }
@@ -0,0 +1,331 @@
+package reevit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long a coordinated idempotency key is kept when
+// WithIdempotencyStore is given a zero TTL. It matches the API's idempotency window.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStore shares idempotency keys between replicas. Operations are named by the
+// caller (for example "payout:invoice_123") so that a job retried on another pod reuses
+// the key of the original attempt instead of generating a new, time-bucketed one.
+type IdempotencyStore interface {
+	// Reserve returns the key stored for operation. If there is none, candidate is stored
+	// for ttl and returned. Reserve must be atomic across replicas.
+	Reserve(ctx context.Context, operation, candidate string, ttl time.Duration) (string, error)
+	// Forget removes the key of operation so the next attempt gets a fresh key.
+	Forget(ctx context.Context, operation string) error
+}
+
+// IdempotencyResultStore is an IdempotencyStore that also keeps the responses of requests
+// made with an idempotency key. When the store given to WithIdempotencyStore implements it,
+// Payments.CreateIntent returns the stored payment for a key it has already seen instead
+// of calling the API again, so duplicate submissions within the TTL cost no request. Keys
+// are scoped to the organization the request acts for, and values carry a fingerprint of
+// the request, so a key reused for a different payload is reported as a conflict.
+type IdempotencyResultStore interface {
+	IdempotencyStore
+	// LoadResult returns the response body stored for key, or nil when there is none.
+	LoadResult(ctx context.Context, key string) ([]byte, error)
+	// SaveResult stores the response body of the request made with key for ttl. A result
+	// already stored for key is kept.
+	SaveResult(ctx context.Context, key string, body []byte, ttl time.Duration) error
+}
+
+// WithIdempotencyStore coordinates the keys returned by Client.IdempotencyKey through store.
+// A zero ttl uses DefaultIdempotencyTTL. Results are cached as well when store is an
+// IdempotencyResultStore.
+func WithIdempotencyStore(store IdempotencyStore, ttl time.Duration) Option {
+	return func(c *Client) {
+		if ttl <= 0 {
+			ttl = DefaultIdempotencyTTL
+		}
+		c.idempotencyStore = store
+		c.idempotencyTTL = ttl
+	}
+}
+
+// IdempotencyKey returns the idempotency key for operation. Without a store it behaves like
+// GenerateIdempotencyKey(params), or reuses the previous bucket's key with
+// WithIdempotencyBucketGuard; with a store, the first key generated for operation on any
+// replica is returned until it expires or is forgotten.
+//
+//	key, err := client.IdempotencyKey(ctx, "charge:"+invoiceID, params)
+//	payment, err := client.Payments.CreateIntent(ctx, req, reevit.WithIdempotencyKey(key))
+func (c *Client) IdempotencyKey(ctx context.Context, operation string, params map[string]any) (string, error) {
+	candidate := c.generateIdempotencyKey(params)
+	if c.idempotencyStore == nil {
+		return candidate, nil
+	}
+	if operation == "" {
+		return "", errors.New("reevit: idempotency operation is required")
+	}
+	return c.idempotencyStore.Reserve(ctx, operation, candidate, c.idempotencyTTL)
+}
+
+// doIdempotent is do for requests whose results are cached by an IdempotencyResultStore.
+// Results are stored per organization, together with a fingerprint of the request; a key
+// reused for a different request fails with an IdempotencyConflictError without calling
+// the API. Store failures are logged and fall back to the API, whose own idempotency
+// handling still prevents duplicates.
+func (c *Client) doIdempotent(req *http.Request, v interface{}) error {
+	store, ok := c.idempotencyStore.(IdempotencyResultStore)
+	key := req.Header.Get("Idempotency-Key")
+	if !ok || key == "" {
+		return c.do(req, v)
+	}
+
+	ctx := req.Context()
+	storeKey := idempotencyResultKey(req, key)
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		return err
+	}
+	cached, err := store.LoadResult(ctx, storeKey)
+	if err != nil {
+		c.logIdempotencyStoreError(ctx, "load", err)
+	} else if len(cached) > 0 {
+		var result idempotencyResult
+		if err := json.Unmarshal(cached, &result); err != nil {
+			c.logIdempotencyStoreError(ctx, "load", err)
+		} else if result.Fingerprint != fingerprint {
+			return &IdempotencyConflictError{
+				ConflictError: &ConflictError{&APIError{
+					Code:    ErrorCodeIdempotencyConflict,
+					Message: "idempotency key " + key + " was already used for a different request",
+				}},
+				IdempotencyKey:      key,
+				OriginalFingerprint: result.Fingerprint,
+				RequestFingerprint:  fingerprint,
+			}
+		} else {
+			return c.decodeJSON(result.Body, v)
+		}
+	}
+
+	body, err := c.doRaw(req)
+	if err != nil {
+		return err
+	}
+	if encoded, err := json.Marshal(idempotencyResult{Fingerprint: fingerprint, Body: body}); err != nil {
+		c.logIdempotencyStoreError(ctx, "save", err)
+	} else if err := store.SaveResult(ctx, storeKey, encoded, c.idempotencyTTL); err != nil {
+		c.logIdempotencyStoreError(ctx, "save", err)
+	}
+	if v == nil || len(body) == 0 {
+		return nil
+	}
+	return c.decodeJSON(body, v)
+}
+
+// idempotencyResult is a response body kept by an IdempotencyResultStore, with the
+// fingerprint of the request that produced it.
+type idempotencyResult struct {
+	Fingerprint string `json:"fingerprint"`
+	Body        []byte `json:"body"`
+}
+
+// idempotencyResultKey scopes key to the organization req acts for, since the API keeps
+// idempotency keys per organization.
+func idempotencyResultKey(req *http.Request, key string) string {
+	org := req.Header.Get("Reevit-Account")
+	if org == "" {
+		org = req.Header.Get("X-Org-Id")
+	}
+	return org + ":" + key
+}
+
+// requestFingerprint returns a hash of the method, path and body of req.
+func requestFingerprint(req *http.Request) (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		if _, err := io.Copy(hash, body); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *Client) logIdempotencyStoreError(ctx context.Context, op string, err error) {
+	if c.logEnabled(LogLevelWarn) {
+		c.logger.Log(ctx, LogLevelWarn, "reevit: idempotency store failed", map[string]interface{}{"op": op, "error": err.Error()})
+	}
+}
+
+// MemoryIdempotencyStore is an IdempotencyResultStore for a single process and for tests.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryIdempotencyEntry
+	results map[string]memoryIdempotencyEntry
+}
+
+// memoryIdempotencyEntry holds a reserved key, or a result body in value.
+type memoryIdempotencyEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore returns an empty in-memory store.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		entries: make(map[string]memoryIdempotencyEntry),
+		results: make(map[string]memoryIdempotencyEntry),
+	}
+}
+
+// evictExpired deletes the entries of m that expired by now.
+func evictExpired(m map[string]memoryIdempotencyEntry, now time.Time) {
+	for key, entry := range m {
+		if !now.Before(entry.expiresAt) {
+			delete(m, key)
+		}
+	}
+}
+
+// Reserve implements IdempotencyStore. Expired reservations are evicted on every call.
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, operation, candidate string, ttl time.Duration) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	evictExpired(s.entries, now)
+	if entry, ok := s.entries[operation]; ok && now.Before(entry.expiresAt) {
+		return entry.key, nil
+	}
+	s.entries[operation] = memoryIdempotencyEntry{key: candidate, expiresAt: now.Add(ttl)}
+	return candidate, nil
+}
+
+// Forget implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) Forget(ctx context.Context, operation string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, operation)
+	return nil
+}
+
+// LoadResult implements IdempotencyResultStore.
+func (s *MemoryIdempotencyStore) LoadResult(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.results[key]; ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+	return nil, nil
+}
+
+// SaveResult implements IdempotencyResultStore. Expired results are evicted on every save
+// so that a long-running process does not accumulate them.
+func (s *MemoryIdempotencyStore) SaveResult(ctx context.Context, key string, body []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	evictExpired(s.results, now)
+	if entry, ok := s.results[key]; ok && now.Before(entry.expiresAt) {
+		return nil
+	}
+	s.results[key] = memoryIdempotencyEntry{value: append([]byte(nil), body...), expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// RedisClient is the subset of Redis commands used by RedisIdempotencyStore. It keeps the
+// SDK free of a Redis dependency; adapting go-redis takes a few lines:
+//
+//	type redisAdapter struct{ *redis.Client }
+//
+//	func (a redisAdapter) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+//		return a.Client.SetNX(ctx, key, value, ttl).Result()
+//	}
+//
+//	func (a redisAdapter) Get(ctx context.Context, key string) (string, error) {
+//		value, err := a.Client.Get(ctx, key).Result()
+//		if errors.Is(err, redis.Nil) {
+//			return "", nil
+//		}
+//		return value, err
+//	}
+//
+//	func (a redisAdapter) Del(ctx context.Context, key string) error {
+//		return a.Client.Del(ctx, key).Err()
+//	}
+type RedisClient interface {
+	// SetNX sets key to value with a TTL only if key does not exist, reporting whether it was set.
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	// Get returns the value of key, or "" and a nil error when the key does not exist.
+	Get(ctx context.Context, key string) (string, error)
+	Del(ctx context.Context, key string) error
+}
+
+// RedisIdempotencyStore is an IdempotencyResultStore backed by Redis, shared by all
+// replicas that use the same Redis instance and prefix. Reserved keys are kept under
+// prefix+"op:"+operation and results under prefix+"result:"+key, so that no operation
+// name can collide with a result.
+type RedisIdempotencyStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisIdempotencyStore returns a store that keeps keys under prefix, for example
+// "reevit:idempotency:".
+func NewRedisIdempotencyStore(client RedisClient, prefix string) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client, prefix: prefix}
+}
+
+// Reserve implements IdempotencyStore using SET NX so concurrent replicas agree on one key.
+func (s *RedisIdempotencyStore) Reserve(ctx context.Context, operation, candidate string, ttl time.Duration) (string, error) {
+	key := s.prefix + "op:" + operation
+	// The stored key can expire between SETNX and GET; one more round settles it.
+	for i := 0; i < 2; i++ {
+		set, err := s.client.SetNX(ctx, key, candidate, ttl)
+		if err != nil {
+			return "", err
+		}
+		if set {
+			return candidate, nil
+		}
+		existing, err := s.client.Get(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		if existing != "" {
+			return existing, nil
+		}
+	}
+	return "", errors.New("reevit: could not reserve idempotency key")
+}
+
+// Forget implements IdempotencyStore.
+func (s *RedisIdempotencyStore) Forget(ctx context.Context, operation string) error {
+	return s.client.Del(ctx, s.prefix+"op:"+operation)
+}
+
+// LoadResult implements IdempotencyResultStore.
+func (s *RedisIdempotencyStore) LoadResult(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Get(ctx, s.prefix+"result:"+key)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return []byte(value), nil
+}
+
+// SaveResult implements IdempotencyResultStore using SET NX, so the first result is kept.
+func (s *RedisIdempotencyStore) SaveResult(ctx context.Context, key string, body []byte, ttl time.Duration) error {
+	_, err := s.client.SetNX(ctx, s.prefix+"result:"+key, string(body), ttl)
+	return err
+}
@@ -0,0 +1,186 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (r *fakeRedis) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.values[key]; ok {
+		return false, nil
+	}
+	r.values[key] = value
+	return true, nil
+}
+
+func (r *fakeRedis) Get(ctx context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.values[key], nil
+}
+
+func (r *fakeRedis) Del(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.values, key)
+	return nil
+}
+
+func TestIdempotencyKeyIsSharedAcrossReplicas(t *testing.T) {
+	redis := &fakeRedis{values: map[string]string{}}
+	store := NewRedisIdempotencyStore(redis, "reevit:idem:")
+	ctx := context.Background()
+
+	podA := NewClient("pfk_test", "org_1", WithIdempotencyStore(store, time.Hour))
+	podB := NewClient("pfk_test", "org_1", WithIdempotencyStore(store, time.Hour))
+
+	first, err := podA.IdempotencyKey(ctx, "charge:inv_1", map[string]any{"amount": 100})
+	require.NoError(t, err)
+	require.Equal(t, first, redis.values["reevit:idem:op:charge:inv_1"])
+
+	// A retry with different inputs, e.g. after the time bucket rolled over, keeps the key.
+	second, err := podB.IdempotencyKey(ctx, "charge:inv_1", map[string]any{"amount": 100, "attempt": 2})
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	require.NoError(t, store.Forget(ctx, "charge:inv_1"))
+	third, err := podB.IdempotencyKey(ctx, "charge:inv_1", map[string]any{"amount": 100, "attempt": 2})
+	require.NoError(t, err)
+	require.NotEqual(t, first, third)
+
+	_, err = podA.IdempotencyKey(ctx, "", nil)
+	require.Error(t, err)
+}
+
+func TestMemoryIdempotencyStoreExpires(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	ctx := context.Background()
+
+	key, err := store.Reserve(ctx, "op", "key_1", time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, "key_1", key)
+	time.Sleep(5 * time.Millisecond)
+	key, err = store.Reserve(ctx, "op", "key_2", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "key_2", key)
+
+	_, err = store.Reserve(ctx, "op_short", "key_3", time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = store.Reserve(ctx, "op_other", "key_4", time.Hour)
+	require.NoError(t, err)
+	require.NotContains(t, store.entries, "op_short")
+	require.Len(t, store.entries, 2)
+}
+
+func TestRedisIdempotencyStoreSeparatesOperationsFromResults(t *testing.T) {
+	redis := &fakeRedis{values: map[string]string{}}
+	store := NewRedisIdempotencyStore(redis, "reevit:idem:")
+	ctx := context.Background()
+
+	require.NoError(t, store.SaveResult(ctx, "key_1", []byte(`{"id":"pay_1"}`), time.Hour))
+	key, err := store.Reserve(ctx, "result:key_1", "key_2", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "key_2", key)
+	result, err := store.LoadResult(ctx, "key_1")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"pay_1"}`, string(result))
+}
+
+func TestIdempotencyResultStoreShortCircuitsCreateIntent(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if r.Header.Get("Idempotency-Key") == "key_fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"pay_%d","status":"pending"}`, n)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	req := &PaymentIntentRequest{Amount: 1000, Currency: "GHS", Country: "GH", Method: MethodCard}
+	for _, store := range []IdempotencyResultStore{
+		NewMemoryIdempotencyStore(),
+		NewRedisIdempotencyStore(&fakeRedis{values: map[string]string{}}, "reevit:idem:"),
+	} {
+		calls.Store(0)
+		podA := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithIdempotencyStore(store, time.Hour), WithMaxRetries(0))
+		podB := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithIdempotencyStore(store, time.Hour), WithMaxRetries(0))
+
+		first, err := podA.Payments.CreateIntent(ctx, req, WithIdempotencyKey("key_1"))
+		require.NoError(t, err)
+		second, err := podB.Payments.CreateIntent(ctx, req, WithIdempotencyKey("key_1"))
+		require.NoError(t, err)
+		require.Equal(t, first.ID, second.ID)
+		require.EqualValues(t, 1, calls.Load())
+
+		_, err = podA.Payments.CreateIntent(ctx, req)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, calls.Load())
+
+		// Failures are not cached.
+		_, err = podA.Payments.CreateIntent(ctx, req, WithIdempotencyKey("key_fail"))
+		require.Error(t, err)
+		_, err = podA.Payments.CreateIntent(ctx, req, WithIdempotencyKey("key_fail"))
+		require.Error(t, err)
+		require.EqualValues(t, 4, calls.Load())
+	}
+}
+
+func TestIdempotencyResultStoreChecksRequest(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		fmt.Fprintf(w, `{"id":"pay_%d","status":"pending"}`, n)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithIdempotencyStore(NewMemoryIdempotencyStore(), time.Hour), WithMaxRetries(0))
+	req := &PaymentIntentRequest{Amount: 1000, Currency: "GHS", Country: "GH", Method: MethodCard}
+
+	first, err := client.Payments.CreateIntent(ctx, req, WithIdempotencyKey("key_1"))
+	require.NoError(t, err)
+
+	// The same key for another amount is a conflict, not the first payment.
+	_, err = client.Payments.CreateIntent(ctx, &PaymentIntentRequest{Amount: 2000, Currency: "GHS", Country: "GH", Method: MethodCard}, WithIdempotencyKey("key_1"))
+	var conflict *IdempotencyConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.True(t, conflict.PayloadMismatch())
+	require.Equal(t, "key_1", conflict.IdempotencyKey)
+	require.EqualValues(t, 1, calls.Load())
+
+	// Another organization has its own keys.
+	other, err := client.Payments.CreateIntent(ctx, req, WithIdempotencyKey("key_1"), WithOrgOverride("org_2"))
+	require.NoError(t, err)
+	require.NotEqual(t, first.ID, other.ID)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestMemoryIdempotencyStoreEvictsResults(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	ctx := context.Background()
+
+	require.NoError(t, store.SaveResult(ctx, "key_1", []byte("{}"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, store.SaveResult(ctx, "key_2", []byte("{}"), time.Hour))
+	require.Len(t, store.results, 1)
+	require.Contains(t, store.results, "key_2")
+}
@@ -0,0 +1,175 @@
+package reevit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalParamsGoldenVectors(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "idempotency_vectors.json"))
+	require.NoError(t, err)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var vectors []struct {
+		Name      string         `json:"name"`
+		Params    map[string]any `json:"params"`
+		Canonical string         `json:"canonical"`
+		SHA256    string         `json:"sha256"`
+	}
+	require.NoError(t, decoder.Decode(&vectors))
+	require.NotEmpty(t, vectors)
+
+	for _, vector := range vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			canonical := canonicalParams(vector.Params)
+			require.Equal(t, vector.Canonical, canonical)
+			sum := sha256.Sum256([]byte(canonical))
+			require.Equal(t, vector.SHA256, hex.EncodeToString(sum[:]))
+			require.True(t, strings.HasSuffix(GenerateIdempotencyKey(vector.Params), "_"+vector.SHA256))
+		})
+	}
+}
+
+func TestCanonicalParamsGoValues(t *testing.T) {
+	type item struct {
+		SKU      string  `json:"sku"`
+		Quantity int     `json:"qty"`
+		Note     *string `json:"note"`
+	}
+	require.Equal(t,
+		`amount:9007199254740992|items:[{"qty":2,"sku":"a"}]|nan:null|negative_zero:0|ratio:0.5`,
+		canonicalParams(map[string]any{
+			"amount":        int64(9007199254740993),
+			"items":         []item{{SKU: "a", Quantity: 2}},
+			"negative_zero": math.Copysign(0, -1),
+			"ratio":         float32(0.5),
+			"missing":       nil,
+			"nan":           math.NaN(),
+		}),
+	)
+}
+
+func TestGenerateIdempotencyKeyAt(t *testing.T) {
+	params := map[string]any{"amount": 45000, "currency": "GHS"}
+	first := time.Date(2026, 3, 1, 12, 4, 59, 0, time.UTC)
+
+	require.Equal(t, GenerateIdempotencyKeyAt(params, first), GenerateIdempotencyKeyAt(params, first))
+	require.NotEqual(t, GenerateIdempotencyKeyAt(params, first), GenerateIdempotencyKeyAt(params, first.Add(time.Second)))
+}
+
+func TestIdempotencyBucketGuard(t *testing.T) {
+	guard := &bucketGuard{seen: make(map[[sha256.Size]byte]int64)}
+	params := map[string]any{"amount": 45000, "currency": "GHS"}
+	first := time.Date(2026, 3, 1, 12, 4, 59, 0, time.UTC)
+
+	key := guard.key(params, first)
+	require.Equal(t, GenerateIdempotencyKeyAt(params, first), key)
+	// A retry one second later lands in the next bucket but keeps the key.
+	require.Equal(t, key, guard.key(params, first.Add(time.Second)))
+	require.Equal(t, key, guard.key(params, first.Add(IdempotencyBucket)))
+
+	// Other parameters are unaffected, and two buckets later the key moves on.
+	require.Equal(t, GenerateIdempotencyKeyAt(map[string]any{"amount": 1}, first.Add(time.Second)), guard.key(map[string]any{"amount": 1}, first.Add(time.Second)))
+	later := first.Add(2*IdempotencyBucket + time.Second)
+	require.Equal(t, GenerateIdempotencyKeyAt(params, later), guard.key(params, later))
+}
+
+func TestIdempotencyKeyFor(t *testing.T) {
+	req := &PaymentIntentRequest{Amount: 45000, Currency: "GHS", Method: MethodMobileMoney, Country: "GH", Metadata: map[string]interface{}{"order": "ord_1"}}
+	params := idempotencyParams(req)
+	require.Equal(t, canonicalParams(map[string]any{
+		"amount":   45000,
+		"currency": "GHS",
+		"method":   "momo",
+		"country":  "GH",
+		"metadata": map[string]any{"order": "ord_1"},
+	}), canonicalParams(params))
+
+	// The struct and a map with the same JSON fields give the same parameters.
+	require.Equal(t, canonicalParams(params), canonicalParams(idempotencyParams(map[string]any{
+		"metadata": map[string]any{"order": "ord_1"}, "country": "GH", "method": "momo", "currency": "GHS", "amount": 45000,
+	})))
+	require.Equal(t, `request:["a","b"]`, canonicalParams(idempotencyParams([]string{"a", "b"})))
+	require.Nil(t, idempotencyParams(nil))
+}
+
+func TestWithAutoIdempotency(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	// The bucket guard keeps the test stable across a bucket boundary.
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithAutoIdempotency(), WithIdempotencyBucketGuard())
+	ctx := context.Background()
+	req := &PaymentIntentRequest{Amount: 1000, Currency: "GHS", Country: "GH", Method: MethodCard}
+
+	_, err := client.Payments.CreateIntent(ctx, req)
+	require.NoError(t, err)
+	_, err = client.Payments.CreateIntent(ctx, req)
+	require.NoError(t, err)
+	_, err = client.Payments.CreateIntent(ctx, &PaymentIntentRequest{Amount: 2000, Currency: "GHS", Country: "GH", Method: MethodCard})
+	require.NoError(t, err)
+	_, err = client.Payments.CreateIntent(ctx, req, WithIdempotencyKey("explicit"))
+	require.NoError(t, err)
+	_, err = client.Payments.Cancel(ctx, "pay_1")
+	require.NoError(t, err)
+	_, err = client.Payments.Get(ctx, "pay_1")
+	require.NoError(t, err)
+
+	require.Len(t, keys, 6)
+	require.True(t, strings.HasPrefix(keys[0], "reevit_"))
+	require.Equal(t, keys[0], keys[1])
+	require.NotEqual(t, keys[0], keys[2])
+	require.Equal(t, "explicit", keys[3])
+	require.NotEmpty(t, keys[4])
+	require.NotEqual(t, keys[0], keys[4])
+	require.Empty(t, keys[5])
+}
+
+func TestWithAutoIdempotencyActionsAndOrgs(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithAutoIdempotency(), WithIdempotencyBucketGuard())
+	ctx := context.Background()
+	req := &PaymentIntentRequest{Amount: 1000, Currency: "GHS", Country: "GH", Method: MethodCard}
+
+	// Retrying a payment twice must reach the API twice.
+	_, err := client.Payments.Retry(ctx, "pay_1")
+	require.NoError(t, err)
+	_, err = client.Payments.Retry(ctx, "pay_1")
+	require.NoError(t, err)
+	_, err = client.Payments.Retry(ctx, "pay_1", WithIdempotencyKey("explicit"))
+	require.NoError(t, err)
+
+	_, err = client.Payments.CreateIntent(ctx, req)
+	require.NoError(t, err)
+	_, err = client.Payments.CreateIntent(ctx, req, WithOrgOverride("org_2"))
+	require.NoError(t, err)
+
+	require.Len(t, keys, 5)
+	require.NotEmpty(t, keys[0])
+	require.NotEqual(t, keys[0], keys[1])
+	require.Equal(t, "explicit", keys[2])
+	require.NotEqual(t, keys[3], keys[4])
+}
@@ -0,0 +1,88 @@
+package reevit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownIDPrefix is returned for IDs whose prefix does not name a known resource, or
+// by Retrieve for resources it cannot fetch.
+var ErrUnknownIDPrefix = errors.New("reevit: unrecognized ID prefix")
+
+// ErrWrongIDKind is returned by service methods given the ID of a different kind of
+// resource, e.g. a customer ID passed to Payments.Get.
+var ErrWrongIDKind = errors.New("reevit: wrong kind of ID")
+
+// IDKind names the kind of resource an ID refers to.
+type IDKind string
+
+// ID kinds recognized by ParseID.
+const (
+	IDKindPayment          IDKind = "payment"
+	IDKindRefund           IDKind = "refund"
+	IDKindSubscription     IDKind = "subscription"
+	IDKindConnection       IDKind = "connection"
+	IDKindCustomer         IDKind = "customer"
+	IDKindPlan             IDKind = "plan"
+	IDKindEvent            IDKind = "event"
+	IDKindTransferSchedule IDKind = "transfer_schedule"
+	IDKindOrganization     IDKind = "organization"
+)
+
+// idPrefixes maps each ID prefix to its kind.
+var idPrefixes = map[string]IDKind{
+	"pay":  IDKindPayment,
+	"rfnd": IDKindRefund,
+	"sub":  IDKindSubscription,
+	"conn": IDKindConnection,
+	"cus":  IDKindCustomer,
+	"plan": IDKindPlan,
+	"evt":  IDKindEvent,
+	"tsch": IDKindTransferSchedule,
+	"org":  IDKindOrganization,
+}
+
+// ParseID splits a Reevit ID such as "pay_8f3k2" into its kind and the suffix after the
+// prefix. It fails for IDs without a recognized prefix or with an empty suffix.
+func ParseID(id string) (IDKind, string, error) {
+	prefix, suffix, found := strings.Cut(id, "_")
+	kind, known := idPrefixes[prefix]
+	if !found || !known || suffix == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrUnknownIDPrefix, id)
+	}
+	return kind, suffix, nil
+}
+
+// IsPaymentID reports whether id is a payment ID.
+func IsPaymentID(id string) bool { return isIDKind(id, IDKindPayment) }
+
+// IsRefundID reports whether id is a refund ID.
+func IsRefundID(id string) bool { return isIDKind(id, IDKindRefund) }
+
+// IsSubscriptionID reports whether id is a subscription ID.
+func IsSubscriptionID(id string) bool { return isIDKind(id, IDKindSubscription) }
+
+// IsConnectionID reports whether id is a connection ID.
+func IsConnectionID(id string) bool { return isIDKind(id, IDKindConnection) }
+
+// IsCustomerID reports whether id is a customer ID.
+func IsCustomerID(id string) bool { return isIDKind(id, IDKindCustomer) }
+
+// IsPlanID reports whether id is a plan ID.
+func IsPlanID(id string) bool { return isIDKind(id, IDKindPlan) }
+
+func isIDKind(id string, want IDKind) bool {
+	kind, _, err := ParseID(id)
+	return err == nil && kind == want
+}
+
+// checkID rejects IDs that are recognizably of another kind than want. IDs without a
+// known prefix are let through for the API to judge.
+func checkID(want IDKind, id string) error {
+	kind, _, err := ParseID(id)
+	if err != nil || kind == want {
+		return nil
+	}
+	return fmt.Errorf("%w: %q is a %s ID, not a %s ID", ErrWrongIDKind, id, kind, want)
+}
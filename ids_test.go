@@ -0,0 +1,40 @@
+package reevit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseID(t *testing.T) {
+	kind, suffix, err := ParseID("pay_8f3k2")
+	require.NoError(t, err)
+	require.Equal(t, IDKindPayment, kind)
+	require.Equal(t, "8f3k2", suffix)
+
+	for _, id := range []string{"", "pay_", "pay", "xyz_123"} {
+		_, _, err := ParseID(id)
+		require.ErrorIs(t, err, ErrUnknownIDPrefix, id)
+	}
+
+	require.True(t, IsPaymentID("pay_1"))
+	require.False(t, IsPaymentID("cus_1"))
+	require.True(t, IsCustomerID("cus_1"))
+	require.True(t, IsRefundID("rfnd_1"))
+}
+
+func TestServiceMethodsRejectWrongIDKind(t *testing.T) {
+	client := NewClient("pfk_test", "org_1", WithBaseURL("http://127.0.0.1:0"))
+
+	_, err := client.Payments.Get(context.Background(), "cus_123")
+	require.ErrorIs(t, err, ErrWrongIDKind)
+	require.EqualError(t, err, `reevit: wrong kind of ID: "cus_123" is a customer ID, not a payment ID`)
+
+	err = client.Connections.Delete(context.Background(), "pay_1")
+	require.ErrorIs(t, err, ErrWrongIDKind)
+
+	// IDs without a recognized prefix are left for the API to judge.
+	_, err = client.Payments.Get(context.Background(), "legacy-42")
+	require.NotErrorIs(t, err, ErrWrongIDKind)
+}
@@ -0,0 +1,7 @@
+// Package apitypes holds the request and response structs generated from the Reevit
+// OpenAPI spec in openapi/openapi.json. They are not used by the SDK directly: the
+// contract tests compare them with the hand-written types, so that a field added,
+// removed or retyped in the API fails the build until the SDK follows.
+package apitypes
+
+//go:generate go run ../openapigen -spec ../../openapi/openapi.json -out zz_generated.go -package apitypes
@@ -0,0 +1,162 @@
+// Code generated by openapigen. DO NOT EDIT.
+
+package apitypes
+
+import "time"
+
+// FraudPolicyInput is a fraud policy override for a single payment.
+type FraudPolicyInput struct {
+	AllowedBins          []string `json:"allowed_bins,omitempty"`
+	BlockedBins          []string `json:"blocked_bins,omitempty"`
+	MaxAmount            int64    `json:"max_amount,omitempty"`
+	Prefer               []string `json:"prefer,omitempty"`
+	VelocityMaxPerMinute int      `json:"velocity_max_per_minute,omitempty"`
+}
+
+// MobileMoneyRecipient is a mobile money wallet receiving a transfer.
+type MobileMoneyRecipient struct {
+	Country string `json:"country"`
+	Name    string `json:"name,omitempty"`
+	Network string `json:"network,omitempty"`
+	Phone   string `json:"phone"`
+}
+
+// Payment is a payment.
+type Payment struct {
+	Amount           int64                  `json:"amount"`
+	AuthorizedAmount int64                  `json:"authorized_amount,omitempty"`
+	CaptureCount     int                    `json:"capture_count,omitempty"`
+	CapturedAmount   int64                  `json:"captured_amount,omitempty"`
+	ClientSecret     string                 `json:"client_secret"`
+	ConnectionID     string                 `json:"connection_id"`
+	CreatedAt        time.Time              `json:"created_at"`
+	Currency         string                 `json:"currency"`
+	CustomerID       string                 `json:"customer_id"`
+	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`
+	FailureCode      string                 `json:"failure_code,omitempty"`
+	FailureMessage   string                 `json:"failure_message,omitempty"`
+	FeeAmount        int64                  `json:"fee_amount"`
+	FeeCurrency      string                 `json:"fee_currency"`
+	ID               string                 `json:"id"`
+	Metadata         map[string]interface{} `json:"metadata"`
+	Method           string                 `json:"method"`
+	NetAmount        int64                  `json:"net_amount"`
+	Provider         string                 `json:"provider"`
+	ProviderRefID    string                 `json:"provider_ref_id"`
+	Reference        string                 `json:"reference"`
+	Refunds          []RefundSummary        `json:"refunds"`
+	Route            []PaymentRouteAttempt  `json:"route"`
+	ScheduledAt      *time.Time             `json:"scheduled_at,omitempty"`
+	Status           string                 `json:"status"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+// PaymentIntentRequest is the body of a payment intent creation request.
+type PaymentIntentRequest struct {
+	Amount                 int64                  `json:"amount"`
+	AutoCancelAfterSeconds int64                  `json:"auto_cancel_after_seconds,omitempty"`
+	Country                string                 `json:"country"`
+	Currency               string                 `json:"currency"`
+	CustomerID             string                 `json:"customer_id,omitempty"`
+	ExpiresAt              *time.Time             `json:"expires_at,omitempty"`
+	Metadata               map[string]interface{} `json:"metadata,omitempty"`
+	Method                 string                 `json:"method"`
+	Phone                  string                 `json:"phone,omitempty"`
+	Policy                 *FraudPolicyInput      `json:"policy,omitempty"`
+	Reference              string                 `json:"reference,omitempty"`
+	ScheduleAt             *time.Time             `json:"schedule_at,omitempty"`
+	Split                  *SplitConfig           `json:"split,omitempty"`
+}
+
+// PaymentRouteAttempt is a routing attempt of a payment.
+type PaymentRouteAttempt struct {
+	ConnectionID string        `json:"connection_id"`
+	Error        string        `json:"error"`
+	Labels       []string      `json:"labels"`
+	Provider     string        `json:"provider"`
+	RoutingHints *RoutingHints `json:"routing_hints"`
+	Status       string        `json:"status"`
+}
+
+// Refund is a refund.
+type Refund struct {
+	Amount     int64                  `json:"amount"`
+	CreatedAt  time.Time              `json:"created_at"`
+	Currency   string                 `json:"currency"`
+	ID         string                 `json:"id"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	PaymentID  string                 `json:"payment_id"`
+	Reason     string                 `json:"reason"`
+	ReasonCode string                 `json:"reason_code"`
+	Status     string                 `json:"status"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+// RefundRequest is the body of a refund request; a missing amount refunds the remaining balance.
+type RefundRequest struct {
+	Amount     int64                  `json:"amount,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Reason     string                 `json:"reason,omitempty"`
+	ReasonCode string                 `json:"reason_code,omitempty"`
+}
+
+// RefundSummary is the refund state embedded in a payment.
+type RefundSummary struct {
+	Amount     int64     `json:"amount"`
+	CreatedAt  time.Time `json:"created_at"`
+	Currency   string    `json:"currency"`
+	ID         string    `json:"id"`
+	ReasonCode string    `json:"reason_code"`
+	Status     string    `json:"status"`
+}
+
+// RoutingHints is routing preferences.
+type RoutingHints struct {
+	CountryPreference []string          `json:"country_preference"`
+	FallbackOnly      bool              `json:"fallback_only"`
+	MethodBias        map[string]string `json:"method_bias"`
+}
+
+// Split is one sub-merchant's share of a payment.
+type Split struct {
+	Amount        int64  `json:"amount,omitempty"`
+	BasisPoints   int    `json:"basis_points,omitempty"`
+	DestinationID string `json:"destination_id"`
+	Reference     string `json:"reference,omitempty"`
+}
+
+// SplitConfig is how a payment is divided between sub-merchants.
+type SplitConfig struct {
+	FeeBearer string  `json:"fee_bearer,omitempty"`
+	Splits    []Split `json:"splits"`
+}
+
+// Transfer is a transfer of funds to a destination account.
+type Transfer struct {
+	Amount         int64                  `json:"amount"`
+	AmountReversed int64                  `json:"amount_reversed"`
+	CreatedAt      time.Time              `json:"created_at"`
+	Currency       string                 `json:"currency"`
+	Description    string                 `json:"description"`
+	DestinationID  string                 `json:"destination_id"`
+	ID             string                 `json:"id"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	PaymentID      string                 `json:"payment_id,omitempty"`
+	Reference      string                 `json:"reference"`
+	ReversedAt     *time.Time             `json:"reversed_at,omitempty"`
+	ScheduleID     string                 `json:"schedule_id,omitempty"`
+	Status         string                 `json:"status"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// TransferRequest is the body of a transfer creation request.
+type TransferRequest struct {
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Description   string                 `json:"description,omitempty"`
+	DestinationID string                 `json:"destination_id,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	PaymentID     string                 `json:"payment_id,omitempty"`
+	Recipient     *MobileMoneyRecipient  `json:"recipient,omitempty"`
+	Reference     string                 `json:"reference,omitempty"`
+}
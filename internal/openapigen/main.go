@@ -0,0 +1,168 @@
+// Command openapigen generates Go structs from the component schemas of the Reevit
+// OpenAPI spec. It is run through go:generate in internal/apitypes; the contract tests
+// compare the generated structs with the SDK's hand-written types.
+//
+//	go run ./internal/openapigen -spec openapi/openapi.json -out internal/apitypes/zz_generated.go -package apitypes
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+type document struct {
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Nullable             bool               `json:"nullable"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+}
+
+// initialisms are upper-cased in field names, following Go naming.
+var initialisms = map[string]bool{"id": true, "url": true, "otp": true, "cvv": true, "api": true}
+
+func main() {
+	specPath := flag.String("spec", "openapi/openapi.json", "path of the OpenAPI spec")
+	out := flag.String("out", "zz_generated.go", "path of the generated file")
+	pkg := flag.String("package", "apitypes", "package of the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		log.Fatalf("openapigen: parsing %s: %v", *specPath, err)
+	}
+	source, err := generate(&doc, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(doc *document, pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by openapigen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+
+	var body bytes.Buffer
+	usesTime := false
+	names := sortedKeys(doc.Components.Schemas)
+	for _, name := range names {
+		s := doc.Components.Schemas[name]
+		if s.Type != "object" || s.Properties == nil {
+			return nil, fmt.Errorf("openapigen: schema %s is not an object with properties", name)
+		}
+		if s.Description != "" {
+			fmt.Fprintf(&body, "// %s is %s\n", name, lowerFirst(s.Description))
+		}
+		fmt.Fprintf(&body, "type %s struct {\n", name)
+		required := make(map[string]bool, len(s.Required))
+		for _, property := range s.Required {
+			required[property] = true
+		}
+		for _, property := range sortedKeys(s.Properties) {
+			typ, err := goType(s.Properties[property])
+			if err != nil {
+				return nil, fmt.Errorf("openapigen: %s.%s: %w", name, property, err)
+			}
+			if strings.Contains(typ, "time.Time") {
+				usesTime = true
+			}
+			tag := property
+			if !required[property] {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&body, "\t%s %s `json:%q`\n", fieldName(property), typ, tag)
+		}
+		body.WriteString("}\n\n")
+	}
+
+	if usesTime {
+		buf.WriteString("import \"time\"\n\n")
+	}
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+func goType(s *schema) (string, error) {
+	pointer := ""
+	if s.Nullable {
+		pointer = "*"
+	}
+	switch {
+	case s.Ref != "":
+		return pointer + s.Ref[strings.LastIndex(s.Ref, "/")+1:], nil
+	case s.Type == "string" && s.Format == "date-time":
+		return pointer + "time.Time", nil
+	case s.Type == "string":
+		return "string", nil
+	case s.Type == "integer" && s.Format == "int32":
+		return "int", nil
+	case s.Type == "integer":
+		return "int64", nil
+	case s.Type == "number":
+		return "float64", nil
+	case s.Type == "boolean":
+		return "bool", nil
+	case s.Type == "array" && s.Items != nil:
+		item, err := goType(s.Items)
+		return "[]" + item, err
+	case s.Type == "object" && s.Properties == nil:
+		var values schema
+		if json.Unmarshal(s.AdditionalProperties, &values) == nil && values.Type != "" {
+			value, err := goType(&values)
+			return "map[string]" + value, err
+		}
+		return "map[string]interface{}", nil
+	}
+	return "", fmt.Errorf("unsupported schema (type %q, format %q); move inline objects to components", s.Type, s.Format)
+}
+
+func fieldName(property string) string {
+	var name strings.Builder
+	for _, word := range strings.Split(property, "_") {
+		if initialisms[word] {
+			name.WriteString(strings.ToUpper(word))
+		} else if word != "" {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return name.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,7 +2,6 @@ package reevit
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -13,15 +12,17 @@ type InvoicesService service
 
 // Invoice represents an invoice resource.
 type Invoice struct {
-	ID         string                 `json:"id"`
-	CustomerID string                 `json:"customer_id"`
-	Status     string                 `json:"status"`
-	Amount     int64                  `json:"amount"`
-	Currency   string                 `json:"currency"`
-	DueDate    *time.Time             `json:"due_date"`
-	Metadata   map[string]interface{} `json:"metadata"`
-	CreatedAt  time.Time              `json:"created_at"`
-	UpdatedAt  time.Time              `json:"updated_at"`
+	ID         string     `json:"id"`
+	CustomerID string     `json:"customer_id"`
+	Status     string     `json:"status"`
+	Amount     int64      `json:"amount"`
+	Currency   string     `json:"currency"`
+	DueDate    *time.Time `json:"due_date"`
+	// PDFFileID identifies the rendered invoice; fetch it with Files.Download.
+	PDFFileID string                 `json:"pdf_file_id"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
 }
 
 // InvoiceListOptions contains supported list filters.
@@ -40,37 +41,37 @@ type InvoiceUpdateRequest struct {
 }
 
 // List returns invoices for the current org.
-func (s *InvoicesService) List(ctx context.Context, options ...InvoiceListOptions) ([]Invoice, error) {
+func (s *InvoicesService) List(ctx context.Context, options *InvoiceListOptions, opts ...RequestOption) ([]Invoice, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
-		setString(values, "status", options[0].Status)
-		setString(values, "customer_id", options[0].CustomerID)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+		setString(values, "customer_id", options.CustomerID)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/invoices", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/invoices", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[Invoice](raw, "invoices")
+	return decodeArrayResponse[Invoice](s.client, raw, "invoices")
 }
 
 // Get fetches an invoice by ID.
-func (s *InvoicesService) Get(ctx context.Context, invoiceID string) (*Invoice, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/invoices/%s", invoiceID), nil)
+func (s *InvoicesService) Get(ctx context.Context, invoiceID string, opts ...RequestOption) (*Invoice, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/invoices/%s", invoiceID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var invoice Invoice
-	if err := s.client.do(ctx, httpRequest, &invoice); err != nil {
+	if err := s.client.do(httpRequest, &invoice); err != nil {
 		return nil, err
 	}
 
@@ -79,17 +80,13 @@ func (s *InvoicesService) Get(ctx context.Context, invoiceID string) (*Invoice,
 
 // Update updates an invoice.
 func (s *InvoicesService) Update(ctx context.Context, invoiceID string, req *InvoiceUpdateRequest, opts ...RequestOption) (*Invoice, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/invoices/%s", invoiceID), req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/invoices/%s", invoiceID), req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var invoice Invoice
-	if err := s.client.do(ctx, httpRequest, &invoice); err != nil {
+	if err := s.client.do(httpRequest, &invoice); err != nil {
 		return nil, err
 	}
 
@@ -98,17 +95,13 @@ func (s *InvoicesService) Update(ctx context.Context, invoiceID string, req *Inv
 
 // Cancel cancels an invoice.
 func (s *InvoicesService) Cancel(ctx context.Context, invoiceID string, opts ...RequestOption) (*Invoice, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/invoices/%s/cancel", invoiceID), map[string]interface{}{})
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/invoices/%s/cancel", invoiceID), map[string]interface{}{}, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var invoice Invoice
-	if err := s.client.do(ctx, httpRequest, &invoice); err != nil {
+	if err := s.client.do(httpRequest, &invoice); err != nil {
 		return nil, err
 	}
 
@@ -117,17 +110,13 @@ func (s *InvoicesService) Cancel(ctx context.Context, invoiceID string, opts ...
 
 // Retry retries invoice collection.
 func (s *InvoicesService) Retry(ctx context.Context, invoiceID string, opts ...RequestOption) (*Invoice, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/invoices/%s/retry", invoiceID), map[string]interface{}{})
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/invoices/%s/retry", invoiceID), map[string]interface{}{}, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var invoice Invoice
-	if err := s.client.do(ctx, httpRequest, &invoice); err != nil {
+	if err := s.client.do(httpRequest, &invoice); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,177 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// latencySmoothing is the weight of the newest observation in the smoothed statistics.
+// At 0.05 the estimates follow roughly the last few dozen calls to an endpoint.
+const latencySmoothing = 0.05
+
+// EndpointStats summarizes the recent latency and failures of one endpoint (method and
+// route, e.g. "GET /v1/payments/{id}"). Latencies and ErrorRate are exponentially
+// smoothed, so they track recent behavior rather than the lifetime of the client.
+// Errors are transport failures and 5xx responses; 4xx responses count as successes.
+type EndpointStats struct {
+	Endpoint string
+	// Requests and Errors count every attempt since the client was created.
+	Requests uint64
+	Errors   uint64
+	// ErrorRate is the smoothed fraction of recent attempts that failed.
+	ErrorRate float64
+	Mean      time.Duration
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+}
+
+// WithStatsHook calls hook after every attempt with the updated statistics of the
+// endpoint, e.g. to feed a metrics system or raise an alert. The hook runs synchronously
+// on the request path and must not block.
+func WithStatsHook(hook func(EndpointStats)) Option {
+	return func(c *Client) {
+		c.latency.hook = hook
+	}
+}
+
+// Stats returns the latency statistics of every endpoint the client has called, keyed by
+// endpoint.
+func (c *Client) Stats() map[string]EndpointStats {
+	return c.latency.snapshot()
+}
+
+type latencyTracker struct {
+	hook func(EndpointStats)
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointLatency
+}
+
+// endpointLatency holds the smoothed estimates of one endpoint, in seconds. Quantiles
+// are tracked with stochastic gradient steps scaled by the smoothed deviation, which
+// needs constant memory and adapts when the latency profile shifts.
+type endpointLatency struct {
+	requests  uint64
+	errors    uint64
+	errorRate float64
+	mean      float64
+	deviation float64
+	p50       float64
+	p95       float64
+	p99       float64
+}
+
+func (e *endpointLatency) observe(seconds float64, failed bool) {
+	failure := 0.0
+	if failed {
+		failure = 1
+		e.errors++
+	}
+	e.requests++
+	if e.requests == 1 {
+		e.mean, e.p50, e.p95, e.p99 = seconds, seconds, seconds, seconds
+		e.deviation = seconds / 2
+		e.errorRate = failure
+		return
+	}
+
+	e.errorRate += latencySmoothing * (failure - e.errorRate)
+	diff := seconds - e.mean
+	if diff < 0 {
+		diff = -diff
+	}
+	e.deviation += latencySmoothing * (diff - e.deviation)
+	e.mean += latencySmoothing * (seconds - e.mean)
+
+	step := 8 * latencySmoothing * e.deviation
+	e.p50 = stepQuantile(e.p50, seconds, 0.50, step)
+	e.p95 = stepQuantile(e.p95, seconds, 0.95, step)
+	e.p99 = stepQuantile(e.p99, seconds, 0.99, step)
+}
+
+// stepQuantile moves the estimate q of quantile p towards the sample x. At equilibrium a
+// fraction p of samples falls below q.
+func stepQuantile(q, x, p, step float64) float64 {
+	if x > q {
+		return q + step*p
+	}
+	if x < q {
+		q -= step * (1 - p)
+		if q < 0 {
+			q = 0
+		}
+	}
+	return q
+}
+
+func (e *endpointLatency) stats(endpoint string) EndpointStats {
+	// The estimators run independently and can cross briefly; report them in order.
+	p95 := math.Max(e.p95, e.p50)
+	p99 := math.Max(e.p99, p95)
+	return EndpointStats{
+		Endpoint:  endpoint,
+		Requests:  e.requests,
+		Errors:    e.errors,
+		ErrorRate: e.errorRate,
+		Mean:      fromSeconds(e.mean),
+		P50:       fromSeconds(e.p50),
+		P95:       fromSeconds(p95),
+		P99:       fromSeconds(p99),
+	}
+}
+
+func fromSeconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// record adds one attempt at req to the statistics. Attempts abandoned because the
+// caller canceled the context say nothing about the endpoint and are skipped.
+func (t *latencyTracker) record(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if errors.Is(req.Context().Err(), context.Canceled) {
+		return
+	}
+	failed := err != nil || (resp != nil && resp.StatusCode >= 500)
+	endpoint := endpointKey(req)
+
+	t.mu.Lock()
+	if t.endpoints == nil {
+		t.endpoints = make(map[string]*endpointLatency)
+	}
+	e, ok := t.endpoints[endpoint]
+	if !ok {
+		e = &endpointLatency{}
+		t.endpoints[endpoint] = e
+	}
+	e.observe(elapsed.Seconds(), failed)
+	stats := e.stats(endpoint)
+	t.mu.Unlock()
+
+	if t.hook != nil {
+		t.hook(stats)
+	}
+}
+
+func (t *latencyTracker) endpoint(endpoint string) (EndpointStats, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.endpoints[endpoint]
+	if !ok {
+		return EndpointStats{}, false
+	}
+	return e.stats(endpoint), true
+}
+
+func (t *latencyTracker) snapshot() map[string]EndpointStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := make(map[string]EndpointStats, len(t.endpoints))
+	for endpoint, e := range t.endpoints {
+		stats[endpoint] = e.stats(endpoint)
+	}
+	return stats
+}
@@ -0,0 +1,95 @@
+package reevit
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointLatencyQuantiles(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	var e endpointLatency
+	// Uniform latencies between 100ms and 200ms.
+	for i := 0; i < 20000; i++ {
+		e.observe(0.1+0.1*random.Float64(), i%10 == 0)
+	}
+
+	stats := e.stats("GET /v1/payments")
+	require.InDelta(t, 150*time.Millisecond, stats.P50, float64(20*time.Millisecond))
+	require.InDelta(t, 195*time.Millisecond, stats.P95, float64(15*time.Millisecond))
+	require.GreaterOrEqual(t, stats.P99, stats.P95)
+	require.InDelta(t, 0.1, stats.ErrorRate, 0.1)
+	require.Equal(t, uint64(2000), stats.Errors)
+}
+
+func TestClientStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/payments/pay_2" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	var observed []EndpointStats
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithStatsHook(func(stats EndpointStats) {
+		observed = append(observed, stats)
+	}))
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+	require.NoError(t, err)
+	_, err = client.Payments.Get(context.Background(), "pay_2")
+	require.Error(t, err)
+
+	stats := client.Stats()
+	require.Len(t, stats, 1)
+	get := stats["GET /v1/payments/{id}"]
+	require.Equal(t, uint64(2), get.Requests)
+	require.Equal(t, uint64(1), get.Errors)
+	require.Positive(t, get.P50)
+	require.Len(t, observed, 2)
+	require.Equal(t, get, observed[1])
+}
+
+func TestAdaptiveTimeout(t *testing.T) {
+	slow := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/payments/pay_9" {
+			select {
+			case <-slow:
+			case <-r.Context().Done():
+			}
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+	defer close(slow)
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithAdaptiveTimeout(AdaptiveTimeout{
+		Floor:      20 * time.Millisecond,
+		Ceiling:    time.Second,
+		MinSamples: 3,
+	}))
+	require.Zero(t, client.httpClient.Timeout)
+
+	request, err := client.newRequest(context.Background(), http.MethodGet, "/v1/payments/pay_1", nil)
+	require.NoError(t, err)
+	require.Equal(t, time.Second, client.timeoutFor(request))
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Payments.Get(context.Background(), "pay_1")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 20*time.Millisecond, client.timeoutFor(request))
+
+	// The endpoint has been fast, so a stalled call is cut off at the floor.
+	start := time.Now()
+	_, err = client.Payments.Get(context.Background(), "pay_9")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
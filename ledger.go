@@ -0,0 +1,69 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// LedgerService handles the org's ledger: every movement of funds, such as captured
+// payments, refunds, fees, transfers and payouts, recorded as a signed entry.
+type LedgerService service
+
+// LedgerEntry is a single movement of funds. Amount is in minor units, positive for
+// credits and negative for debits.
+type LedgerEntry struct {
+	ID string `json:"id"`
+	// Type is the kind of movement, e.g. "payment", "refund", "fee", "transfer",
+	// "payout" or "adjustment".
+	Type     string `json:"type"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// BalanceAfter is the available balance in Currency once the entry was applied.
+	BalanceAfter int64     `json:"balance_after"`
+	PaymentID    string    `json:"payment_id,omitempty"`
+	RefundID     string    `json:"refund_id,omitempty"`
+	TransferID   string    `json:"transfer_id,omitempty"`
+	SettlementID string    `json:"settlement_id,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LedgerEntryListOptions contains filters for ledger entry listing.
+type LedgerEntryListOptions struct {
+	Limit int
+	// Cursor is the ID of the last entry of the previous page.
+	Cursor        string
+	Type          string
+	Currency      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// ListEntries returns ledger entries matching the filters, oldest first.
+//
+// API Docs: GET /v1/ledger/entries
+func (s *LedgerService) ListEntries(ctx context.Context, options *LedgerEntryListOptions, opts ...RequestOption) ([]LedgerEntry, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setString(values, "cursor", options.Cursor)
+		setString(values, "type", options.Type)
+		setString(values, "currency", options.Currency)
+		setTime(values, "created_after", options.CreatedAfter)
+		setTime(values, "created_before", options.CreatedBefore)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/ledger/entries", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[LedgerEntry](s.client, raw, "entries")
+}
@@ -0,0 +1,203 @@
+package reevit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LogLevel is the severity of a log entry.
+type LogLevel int
+
+// Log levels, from most to least verbose.
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	default:
+		return "error"
+	}
+}
+
+// Logger receives structured log entries from the client. Fields never contain
+// API keys, authorization headers or card data.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, fields map[string]interface{})
+}
+
+// WithLogger logs every request at or above the given level. Completed requests are logged
+// at info, 4xx responses at warn and transport errors or 5xx responses at error. At debug,
+// redacted request headers and bodies are logged as well.
+func WithLogger(logger Logger, level LogLevel) Option {
+	return func(c *Client) {
+		c.logger = logger
+		c.logLevel = level
+	}
+}
+
+// NewSlogLogger adapts a *slog.Logger to the Logger interface.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Log(ctx context.Context, level LogLevel, msg string, fields map[string]interface{}) {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for key, value := range fields {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+	slogLevel := slog.LevelError
+	switch level {
+	case LogLevelDebug:
+		slogLevel = slog.LevelDebug
+	case LogLevelInfo:
+		slogLevel = slog.LevelInfo
+	case LogLevelWarn:
+		slogLevel = slog.LevelWarn
+	}
+	l.logger.LogAttrs(ctx, slogLevel, msg, attrs...)
+}
+
+const redactedValue = "[REDACTED]"
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization":      true,
+	"X-Reevit-Key":       true,
+	"X-Reevit-Signature": true,
+	"Cookie":             true,
+}
+
+var sensitiveFields = fieldSet([]string{
+	"api_key", "secret", "secret_key", "client_secret", "session_secret", "password", "credentials",
+	"card_number", "number", "pan", "cvv", "cvc", "expiry", "bin", "blocked_bins", "allowed_bins",
+})
+
+// maskSensitive redacts credential and card fields, plus any string that looks like a card number.
+func maskSensitive(field string, value interface{}) interface{} {
+	if sensitiveFields[strings.ToLower(field)] {
+		return redactedValue
+	}
+	if str, ok := value.(string); ok && looksLikePAN(str) {
+		return redactedValue
+	}
+	return value
+}
+
+func looksLikePAN(value string) bool {
+	digits := 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == ' ' || r == '-':
+		default:
+			return false
+		}
+	}
+	return digits >= 13 && digits <= 19
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(key)] {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = header.Get(key)
+	}
+	return redacted
+}
+
+// redactLogBody returns a redacted representation of a JSON body for debug logging.
+func redactLogBody(body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return "[non-JSON body omitted]"
+	}
+	return redactValue(decoded, []RedactFunc{maskSensitive})
+}
+
+func (c *Client) logEnabled(level LogLevel) bool {
+	return c.logger != nil && level >= c.logLevel
+}
+
+func (c *Client) logRequest(req *http.Request) {
+	if !c.logEnabled(LogLevelDebug) {
+		return
+	}
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"path":    req.URL.Path,
+		"headers": redactHeaders(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			raw, _ := io.ReadAll(body)
+			body.Close()
+			fields["body"] = redactLogBody(raw)
+		}
+	}
+	c.logger.Log(req.Context(), LogLevelDebug, "reevit: sending request", fields)
+}
+
+func (c *Client) logResponse(req *http.Request, resp *http.Response, body []byte, start time.Time, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+	level := LogLevelInfo
+	msg := "reevit: request completed"
+	switch {
+	case err != nil:
+		level = LogLevelError
+		msg = "reevit: request failed"
+		fields["error"] = err.Error()
+	case resp.StatusCode >= 500:
+		level = LogLevelError
+	case resp.StatusCode >= 400:
+		level = LogLevelWarn
+	}
+	if resp != nil {
+		fields["status"] = resp.StatusCode
+		if requestID := resp.Header.Get("X-Request-ID"); requestID != "" {
+			fields["request_id"] = requestID
+		}
+		if c.logEnabled(LogLevelDebug) {
+			fields["body"] = redactLogBody(body)
+		}
+	}
+	if !c.logEnabled(level) {
+		return
+	}
+	c.logger.Log(req.Context(), level, msg, fields)
+}
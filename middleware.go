@@ -0,0 +1,27 @@
+package reevit
+
+import "net/http"
+
+// RequestMiddleware is called with every outgoing request before it is sent.
+// It may add headers or reject the request by returning an error.
+type RequestMiddleware func(*http.Request) error
+
+// ResponseMiddleware is called with every response before it is decoded. The body
+// has already been read and is replayable, so middleware may inspect it freely.
+// Returning an error aborts the call with that error.
+type ResponseMiddleware func(*http.Response) error
+
+// WithRequestMiddleware adds middleware that runs on every outgoing request,
+// for example to inject tracing headers or custom authentication.
+func WithRequestMiddleware(middleware ...RequestMiddleware) Option {
+	return func(c *Client) {
+		c.requestMiddleware = append(c.requestMiddleware, middleware...)
+	}
+}
+
+// WithResponseMiddleware adds middleware that runs on every response, for example for audit logging.
+func WithResponseMiddleware(middleware ...ResponseMiddleware) Option {
+	return func(c *Client) {
+		c.responseMiddleware = append(c.responseMiddleware, middleware...)
+	}
+}
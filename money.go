@@ -0,0 +1,173 @@
+package reevit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned by Money arithmetic on values of different currencies.
+var ErrCurrencyMismatch = errors.New("reevit: currency mismatch")
+
+// Money is an amount in minor units of an ISO 4217 currency. It encodes to JSON as
+// {"amount": 123450, "currency": "GHS"}, the same shape the API uses for the amount and
+// currency fields of payments, refunds and subscriptions, which expose their amounts as
+// Money through the Money methods.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney returns amount minor units of currency.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(strings.TrimSpace(currency))}
+}
+
+// ParseMoney parses user input such as "₵1,234.50" with ParseAmount.
+func ParseMoney(input, currency, locale string) (Money, error) {
+	amount, err := ParseAmount(input, currency, locale)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(amount, currency), nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool { return m.Amount == 0 }
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// Add returns m + other.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns m - other.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by n.
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Cmp compares m and other, returning -1, 0 or +1.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Allocate splits m in proportion to ratios without losing minor units: the remainder
+// left by rounding down is handed out one unit at a time from the first share, e.g.
+// splitting 100 three ways yields 34, 33 and 33.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errors.New("reevit: allocation ratios must not be negative")
+		}
+		total += ratio
+	}
+	if total == 0 {
+		return nil, errors.New("reevit: allocation ratios must not all be zero")
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := m.Amount
+	for i, ratio := range ratios {
+		shares[i] = Money{Amount: m.Amount * ratio / total, Currency: m.Currency}
+		remainder -= shares[i].Amount
+	}
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].Amount += step
+		remainder -= step
+	}
+	return shares, nil
+}
+
+// Decimal renders the amount in major units without symbol or grouping, using the
+// currency's precision, e.g. "1234.50" for GHS or "1234" for UGX.
+func (m Money) Decimal() string {
+	digits := CurrencyDigits(m.Currency)
+	magnitude := m.Amount
+	sign := ""
+	if magnitude < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+	text := strconv.FormatInt(magnitude, 10)
+	if digits == 0 {
+		return sign + text
+	}
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	return sign + text[:len(text)-digits] + "." + text[len(text)-digits:]
+}
+
+// Format renders m for display in locale with FormatAmount.
+func (m Money) Format(locale string) string {
+	return FormatAmount(m.Amount, m.Currency, locale)
+}
+
+// String implements fmt.Stringer using English formatting.
+func (m Money) String() string {
+	return m.Format("en")
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if !strings.EqualFold(m.Currency, other.Currency) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
+
+// Money returns the payment amount.
+func (p *Payment) Money() Money { return NewMoney(p.Amount, p.Currency) }
+
+// FeeMoney returns the fee charged on the payment.
+func (p *Payment) FeeMoney() Money { return NewMoney(p.FeeAmount, p.FeeCurrency) }
+
+// NetMoney returns the payment amount net of fees.
+func (p *Payment) NetMoney() Money { return NewMoney(p.NetAmount, p.Currency) }
+
+// Money returns the payment amount.
+func (p *PaymentSummary) Money() Money { return NewMoney(p.Amount, p.Currency) }
+
+// Money returns the refunded amount.
+func (r *Refund) Money() Money { return NewMoney(r.Amount, r.Currency) }
+
+// Money returns the refunded amount.
+func (r *RefundSummary) Money() Money { return NewMoney(r.Amount, r.Currency) }
+
+// Money returns the amount charged each billing interval.
+func (s *Subscription) Money() Money { return NewMoney(s.Amount, s.Currency) }
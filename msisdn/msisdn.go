@@ -0,0 +1,153 @@
+// Package msisdn normalizes and validates mobile money phone numbers.
+package msisdn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Mobile networks recognised by Reevit mobile money connections.
+const (
+	NetworkMTN        = "mtn"
+	NetworkTelecel    = "telecel"
+	NetworkAirtelTigo = "airteltigo"
+	NetworkAirtel     = "airtel"
+	NetworkGlo        = "glo"
+	Network9mobile    = "9mobile"
+	NetworkSafaricom  = "safaricom"
+)
+
+var (
+	// ErrInvalidNumber is returned when a phone number cannot be normalized for a country.
+	ErrInvalidNumber = errors.New("msisdn: invalid phone number")
+	// ErrUnsupportedCountry is returned for countries without numbering data.
+	ErrUnsupportedCountry = errors.New("msisdn: unsupported country")
+	// ErrUnknownNetwork is returned when a valid number does not match any known network prefix.
+	ErrUnknownNetwork = errors.New("msisdn: unknown network")
+)
+
+type numberingPlan struct {
+	country     string
+	callingCode string
+	length      int               // length of the national significant number
+	prefixes    map[string]string // national significant number prefix -> network
+}
+
+var plans = map[string]numberingPlan{
+	"GH": {
+		country:     "GH",
+		callingCode: "233",
+		length:      9,
+		prefixes: map[string]string{
+			"24": NetworkMTN, "25": NetworkMTN, "53": NetworkMTN, "54": NetworkMTN, "55": NetworkMTN, "59": NetworkMTN,
+			"20": NetworkTelecel, "50": NetworkTelecel,
+			"26": NetworkAirtelTigo, "27": NetworkAirtelTigo, "56": NetworkAirtelTigo, "57": NetworkAirtelTigo,
+		},
+	},
+	"NG": {
+		country:     "NG",
+		callingCode: "234",
+		length:      10,
+		prefixes: map[string]string{
+			"703": NetworkMTN, "706": NetworkMTN, "803": NetworkMTN, "806": NetworkMTN, "810": NetworkMTN, "813": NetworkMTN,
+			"814": NetworkMTN, "816": NetworkMTN, "903": NetworkMTN, "906": NetworkMTN, "913": NetworkMTN, "916": NetworkMTN,
+			"705": NetworkGlo, "805": NetworkGlo, "807": NetworkGlo, "811": NetworkGlo, "815": NetworkGlo, "905": NetworkGlo, "915": NetworkGlo,
+			"701": NetworkAirtel, "708": NetworkAirtel, "802": NetworkAirtel, "808": NetworkAirtel, "812": NetworkAirtel,
+			"901": NetworkAirtel, "902": NetworkAirtel, "907": NetworkAirtel, "912": NetworkAirtel,
+			"809": Network9mobile, "817": Network9mobile, "818": Network9mobile, "908": Network9mobile, "909": Network9mobile,
+		},
+	},
+	"KE": {
+		country:     "KE",
+		callingCode: "254",
+		length:      9,
+		prefixes: map[string]string{
+			"70": NetworkSafaricom, "71": NetworkSafaricom, "72": NetworkSafaricom, "74": NetworkSafaricom, "79": NetworkSafaricom,
+			"757": NetworkSafaricom, "758": NetworkSafaricom, "759": NetworkSafaricom, "768": NetworkSafaricom, "769": NetworkSafaricom,
+			"110": NetworkSafaricom, "111": NetworkSafaricom, "112": NetworkSafaricom, "113": NetworkSafaricom, "114": NetworkSafaricom, "115": NetworkSafaricom,
+			"73": NetworkAirtel, "78": NetworkAirtel, "762": NetworkAirtel,
+			"750": NetworkAirtel, "751": NetworkAirtel, "752": NetworkAirtel, "753": NetworkAirtel, "754": NetworkAirtel, "755": NetworkAirtel, "756": NetworkAirtel,
+			"100": NetworkAirtel, "101": NetworkAirtel, "102": NetworkAirtel,
+		},
+	},
+	"UG": {
+		country:     "UG",
+		callingCode: "256",
+		length:      9,
+		prefixes: map[string]string{
+			"76": NetworkMTN, "77": NetworkMTN, "78": NetworkMTN, "39": NetworkMTN,
+			"70": NetworkAirtel, "74": NetworkAirtel, "75": NetworkAirtel, "20": NetworkAirtel,
+		},
+	},
+}
+
+// Normalize converts a local or international phone number into E.164 format
+// (for example "024 123 4567" in GH becomes "+233241234567").
+func Normalize(phone, country string) (string, error) {
+	plan, ok := plans[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	nsn, err := nationalNumber(phone, plan)
+	if err != nil {
+		return "", err
+	}
+	return "+" + plan.callingCode + nsn, nil
+}
+
+// Validate reports whether the phone number is a valid mobile number on a known network.
+func Validate(phone, country string) error {
+	_, err := Network(phone, country)
+	return err
+}
+
+// Network returns the mobile network that a phone number belongs to.
+func Network(phone, country string) (string, error) {
+	plan, ok := plans[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCountry, country)
+	}
+
+	nsn, err := nationalNumber(phone, plan)
+	if err != nil {
+		return "", err
+	}
+
+	// Prefer the longest matching prefix so "757" wins over "75".
+	for size := 3; size >= 2; size-- {
+		if network, ok := plan.prefixes[nsn[:size]]; ok {
+			return network, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownNetwork, phone)
+}
+
+func nationalNumber(phone string, plan numberingPlan) (string, error) {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0, r == ' ', r == '-', r == '(', r == ')', r == '.':
+		default:
+			return "", fmt.Errorf("%w: %s", ErrInvalidNumber, phone)
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(number, "00"+plan.callingCode):
+		number = number[2+len(plan.callingCode):]
+	case strings.HasPrefix(number, plan.callingCode) && len(number) == len(plan.callingCode)+plan.length:
+		number = number[len(plan.callingCode):]
+	case strings.HasPrefix(number, "0") && len(number) == plan.length+1:
+		number = number[1:]
+	}
+
+	if len(number) != plan.length || number[0] == '0' {
+		return "", fmt.Errorf("%w: %s", ErrInvalidNumber, phone)
+	}
+	return number, nil
+}
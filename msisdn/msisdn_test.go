@@ -0,0 +1,46 @@
+package msisdn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		phone, country, want string
+	}{
+		{"024 123 4567", "GH", "+233241234567"},
+		{"+233 24 123 4567", "gh", "+233241234567"},
+		{"00233241234567", "GH", "+233241234567"},
+		{"0803-123-4567", "NG", "+2348031234567"},
+		{"254712345678", "KE", "+254712345678"},
+		{"0772 123456", "UG", "+256772123456"},
+	}
+	for _, tc := range cases {
+		got, err := Normalize(tc.phone, tc.country)
+		require.NoError(t, err, tc.phone)
+		require.Equal(t, tc.want, got)
+	}
+
+	_, err := Normalize("02412345", "GH")
+	require.ErrorIs(t, err, ErrInvalidNumber)
+	_, err = Normalize("0241234567", "ZZ")
+	require.ErrorIs(t, err, ErrUnsupportedCountry)
+}
+
+func TestNetwork(t *testing.T) {
+	network, err := Network("0241234567", "GH")
+	require.NoError(t, err)
+	require.Equal(t, NetworkMTN, network)
+
+	network, err = Network("+254757123456", "KE")
+	require.NoError(t, err)
+	require.Equal(t, NetworkSafaricom, network)
+
+	network, err = Network("0751123456", "KE")
+	require.NoError(t, err)
+	require.Equal(t, NetworkAirtel, network)
+
+	require.ErrorIs(t, Validate("0311234567", "GH"), ErrUnknownNetwork)
+}
@@ -0,0 +1,115 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// NotificationsService handles the email and SMS notifications Reevit sends on the
+// organization's behalf, such as payment receipts, payout notices and failed-renewal
+// alerts.
+type NotificationsService service
+
+// NotificationSetting is the configuration of one notification type.
+type NotificationSetting struct {
+	Type     NotificationType      `json:"type"`
+	Enabled  bool                  `json:"enabled"`
+	Channels []NotificationChannel `json:"channels"`
+	// Recipients receive the notifications addressed to the organization, such as payout
+	// notices and failed-renewal alerts. Receipts go to the customer instead.
+	Recipients []string  `json:"recipients"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SendsVia reports whether the notification is enabled and delivered over channel.
+func (s *NotificationSetting) SendsVia(channel NotificationChannel) bool {
+	if s == nil || !s.Enabled {
+		return false
+	}
+	for _, c := range s.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationSettingUpdate represents a partial notification setting update. Nil and
+// empty fields are left unchanged.
+type NotificationSettingUpdate struct {
+	Enabled    *bool                 `json:"enabled,omitempty"`
+	Channels   []NotificationChannel `json:"channels,omitempty"`
+	Recipients []string              `json:"recipients,omitempty"`
+}
+
+// List returns the settings of every notification type for the current org.
+//
+// API Docs: GET /v1/notifications/settings
+func (s *NotificationsService) List(ctx context.Context, opts ...RequestOption) ([]NotificationSetting, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/notifications/settings", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[NotificationSetting](s.client, raw, "settings")
+}
+
+// Get fetches the settings of a notification type.
+//
+// API Docs: GET /v1/notifications/settings/{type}
+func (s *NotificationsService) Get(ctx context.Context, notificationType NotificationType, opts ...RequestOption) (*NotificationSetting, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/notifications/settings/%s", string(notificationType)), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var setting NotificationSetting
+	if err := s.client.do(httpRequest, &setting); err != nil {
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+// Update changes the settings of a notification type.
+//
+// API Docs: PATCH /v1/notifications/settings/{type}
+func (s *NotificationsService) Update(ctx context.Context, notificationType NotificationType, req *NotificationSettingUpdate, opts ...RequestOption) (*NotificationSetting, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/notifications/settings/%s", string(notificationType)), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var setting NotificationSetting
+	if err := s.client.do(httpRequest, &setting); err != nil {
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+// UpdateAll changes the settings of several notification types at once, e.g. to apply a
+// platform's defaults when onboarding a sub-merchant. The update is atomic: if one type
+// is rejected none is changed. It returns the settings of every notification type.
+//
+// API Docs: PATCH /v1/notifications/settings
+func (s *NotificationsService) UpdateAll(ctx context.Context, updates map[NotificationType]*NotificationSettingUpdate, opts ...RequestOption) ([]NotificationSetting, error) {
+	body := map[string]interface{}{"settings": updates}
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/notifications/settings", body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[NotificationSetting](s.client, raw, "settings")
+}
@@ -0,0 +1,216 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// orgSettingsCacheTTL controls how long WithOrgDefaults reuses the org settings before
+// fetching them again.
+const orgSettingsCacheTTL = 10 * time.Minute
+
+// OrgSettingsService handles the organization-level defaults of the Reevit API.
+type OrgSettingsService service
+
+// OrgSettings holds the defaults of the organization. Reevit applies them to requests that
+// leave the corresponding fields unset; WithOrgDefaults makes the SDK fill them in before
+// sending, so that they are visible to validation and logs.
+type OrgSettings struct {
+	// DefaultCurrency is the ISO 4217 code used for payments and links without a currency.
+	DefaultCurrency string `json:"default_currency"`
+	// DefaultCountry is the ISO 3166-1 alpha-2 code used for payments without a country.
+	DefaultCountry string `json:"default_country"`
+	// DefaultFeeBearer is who pays the processing fee of split payments: "platform" or
+	// "splits".
+	DefaultFeeBearer string `json:"default_fee_bearer"`
+	// ReceiptSender is the email address receipts are sent from.
+	ReceiptSender string `json:"receipt_sender"`
+	// Timezone is the IANA timezone used for reports and settlement cut-offs.
+	Timezone  string    `json:"timezone"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrgSettingsUpdateRequest represents a partial org settings update.
+type OrgSettingsUpdateRequest struct {
+	DefaultCurrency  string `json:"default_currency,omitempty"`
+	DefaultCountry   string `json:"default_country,omitempty"`
+	DefaultFeeBearer string `json:"default_fee_bearer,omitempty"`
+	ReceiptSender    string `json:"receipt_sender,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
+}
+
+// Location returns the time.Location of Timezone, or UTC when it is not set.
+func (s *OrgSettings) Location() (*time.Location, error) {
+	if s == nil || s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// Get returns the settings of the current org.
+//
+// API Docs: GET /v1/org/settings
+func (s *OrgSettingsService) Get(ctx context.Context, opts ...RequestOption) (*OrgSettings, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/org/settings", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings OrgSettings
+	if err := s.client.do(httpRequest, &settings); err != nil {
+		return nil, err
+	}
+
+	s.client.orgDefaults.store(s.client.requestOrg(opts), &settings)
+	return &settings, nil
+}
+
+// Update changes the settings of the current org. Fields left empty are unchanged.
+//
+// API Docs: PATCH /v1/org/settings
+func (s *OrgSettingsService) Update(ctx context.Context, req *OrgSettingsUpdateRequest, opts ...RequestOption) (*OrgSettings, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, "/v1/org/settings", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings OrgSettings
+	if err := s.client.do(httpRequest, &settings); err != nil {
+		return nil, err
+	}
+
+	s.client.orgDefaults.store(s.client.requestOrg(opts), &settings)
+	return &settings, nil
+}
+
+// WithOrgDefaults makes the client fill unset fields of requests with the org settings:
+// the currency and country of payment intents, the fee bearer of their splits and the
+// currency of payment links. The settings are fetched on first use and cached for ten
+// minutes per organization, so that requests made with WithOrgOverride are filled with the
+// settings of the organization they act for; OrgSettings.Update refreshes them
+// immediately. Requests whose fields are all set never trigger a fetch, and the caller's
+// request structs are not modified.
+func WithOrgDefaults() Option {
+	return func(c *Client) {
+		c.orgDefaults = &orgDefaults{entries: map[string]orgSettingsEntry{}}
+	}
+}
+
+// orgDefaults caches the org settings used by WithOrgDefaults, keyed by organization ID.
+type orgDefaults struct {
+	mu      sync.Mutex
+	entries map[string]orgSettingsEntry
+}
+
+type orgSettingsEntry struct {
+	settings  *OrgSettings
+	expiresAt time.Time
+}
+
+// store caches the settings of org; it is a no-op when WithOrgDefaults is off.
+func (d *orgDefaults) store(org string, settings *OrgSettings) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries[org] = orgSettingsEntry{settings: settings, expiresAt: time.Now().Add(orgSettingsCacheTTL)}
+}
+
+func (d *orgDefaults) cached(org string) *OrgSettings {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[org]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry.settings
+}
+
+// requestOrg returns the organization a request made with opts acts for: the one set with
+// WithOrgOverride, or the client's own.
+func (c *Client) requestOrg(opts []RequestOption) string {
+	if override := orgOverride(opts); override != "" {
+		return override
+	}
+	return c.orgID
+}
+
+// orgOverride returns the organization set with WithOrgOverride in opts, or "" when there
+// is none.
+func orgOverride(opts []RequestOption) string {
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		return ""
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req.Header.Get("Reevit-Account")
+}
+
+// orgSettings returns the settings of the organization a request made with opts acts for,
+// or nil when WithOrgDefaults is off.
+func (c *Client) orgSettings(ctx context.Context, opts []RequestOption) (*OrgSettings, error) {
+	if c.orgDefaults == nil {
+		return nil, nil
+	}
+	if settings := c.orgDefaults.cached(c.requestOrg(opts)); settings != nil {
+		return settings, nil
+	}
+	var fetchOpts []RequestOption
+	if override := orgOverride(opts); override != "" {
+		fetchOpts = append(fetchOpts, WithOrgOverride(override))
+	}
+	settings, err := c.OrgSettings.Get(ctx, fetchOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("reevit: loading org defaults: %w", err)
+	}
+	return settings, nil
+}
+
+// withIntentDefaults returns req with its unset currency, country and split fee bearer
+// taken from the settings of the organization it is made for.
+func (c *Client) withIntentDefaults(ctx context.Context, req *PaymentIntentRequest, opts []RequestOption) (*PaymentIntentRequest, error) {
+	if c.orgDefaults == nil || req == nil {
+		return req, nil
+	}
+	if req.Currency != "" && req.Country != "" && (req.Split == nil || req.Split.FeeBearer != "") {
+		return req, nil
+	}
+	settings, err := c.orgSettings(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	filled := *req
+	if filled.Currency == "" {
+		filled.Currency = settings.DefaultCurrency
+	}
+	if filled.Country == "" {
+		filled.Country = settings.DefaultCountry
+	}
+	if filled.Split != nil && filled.Split.FeeBearer == "" {
+		split := *filled.Split
+		split.FeeBearer = settings.DefaultFeeBearer
+		filled.Split = &split
+	}
+	return &filled, nil
+}
+
+// withPaymentLinkDefaults returns req with its unset currency taken from the settings of
+// the organization it is made for.
+func (c *Client) withPaymentLinkDefaults(ctx context.Context, req *CreatePaymentLinkRequest, opts []RequestOption) (*CreatePaymentLinkRequest, error) {
+	if c.orgDefaults == nil || req == nil || req.Currency != "" {
+		return req, nil
+	}
+	settings, err := c.orgSettings(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	filled := *req
+	filled.Currency = settings.DefaultCurrency
+	return &filled, nil
+}
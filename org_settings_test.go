@@ -0,0 +1,108 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOrgDefaults(t *testing.T) {
+	settingsFetches := 0
+	var intent, link map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/org/settings":
+			settingsFetches++
+			_, _ = w.Write([]byte(`{"default_currency":"GHS","default_country":"GH","default_fee_bearer":"splits","timezone":"Africa/Accra"}`))
+		case "/v1/payments/intents":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&intent))
+			_, _ = w.Write([]byte(`{"id":"pay_1","status":"pending"}`))
+		case "/v1/payment-links":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&link))
+			_, _ = w.Write([]byte(`{"id":"plink_1"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithOrgDefaults())
+	ctx := context.Background()
+
+	req := &PaymentIntentRequest{Amount: 1000, Method: MethodMobileMoney, Split: &SplitConfig{Splits: []Split{{DestinationID: "dest_1", BasisPoints: 1000}}}}
+	_, err := client.Payments.CreateIntent(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "GHS", intent["currency"])
+	require.Equal(t, "GH", intent["country"])
+	require.Equal(t, "splits", intent["split"].(map[string]interface{})["fee_bearer"])
+	require.Empty(t, req.Currency)
+	require.Empty(t, req.Split.FeeBearer)
+
+	_, err = client.Payments.CreateIntent(ctx, &PaymentIntentRequest{Amount: 1000, Currency: "NGN", Country: "NG", Method: MethodCard})
+	require.NoError(t, err)
+	require.Equal(t, "NGN", intent["currency"])
+
+	_, err = client.PaymentLinks.Create(ctx, &CreatePaymentLinkRequest{Name: "Donation", Amount: 500})
+	require.NoError(t, err)
+	require.Equal(t, "GHS", link["currency"])
+	require.Equal(t, 1, settingsFetches)
+
+	without := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err = without.PaymentLinks.Create(ctx, &CreatePaymentLinkRequest{Name: "Donation", Amount: 500})
+	require.NoError(t, err)
+	require.Equal(t, "", link["currency"])
+	require.Equal(t, 1, settingsFetches)
+}
+
+func TestOrgSettingsLocation(t *testing.T) {
+	location, err := (&OrgSettings{Timezone: "Africa/Lagos"}).Location()
+	require.NoError(t, err)
+	require.Equal(t, "Africa/Lagos", location.String())
+
+	location, err = (*OrgSettings)(nil).Location()
+	require.NoError(t, err)
+	require.Equal(t, "UTC", location.String())
+}
+
+func TestWithOrgDefaultsOrgOverride(t *testing.T) {
+	var intent map[string]interface{}
+	var settingsOrgs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/org/settings":
+			settingsOrgs = append(settingsOrgs, r.Header.Get("Reevit-Account"))
+			if r.Header.Get("Reevit-Account") == "org_ng" {
+				_, _ = w.Write([]byte(`{"default_currency":"NGN","default_country":"NG"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"default_currency":"GHS","default_country":"GH"}`))
+		case "/v1/payments/intents":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&intent))
+			_, _ = w.Write([]byte(`{"id":"pay_1","status":"pending"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithOrgDefaults())
+	ctx := context.Background()
+	req := &PaymentIntentRequest{Amount: 1000, Method: MethodCard}
+
+	_, err := client.Payments.CreateIntent(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "GHS", intent["currency"])
+
+	_, err = client.Payments.CreateIntent(ctx, req, WithOrgOverride("org_ng"))
+	require.NoError(t, err)
+	require.Equal(t, "NGN", intent["currency"])
+	require.Equal(t, "NG", intent["country"])
+
+	_, err = client.Payments.CreateIntent(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "GHS", intent["currency"])
+	_, err = client.Payments.CreateIntent(ctx, req, WithOrgOverride("org_ng"))
+	require.NoError(t, err)
+	require.Equal(t, "NGN", intent["currency"])
+	require.Equal(t, []string{"", "org_ng"}, settingsOrgs)
+}
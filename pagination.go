@@ -0,0 +1,75 @@
+package reevit
+
+import "context"
+
+// defaultPageSize is used by auto-paging iterators when no limit is specified.
+const defaultPageSize = 100
+
+// Iter iterates over the results of a paginated list endpoint, fetching
+// subsequent pages transparently until a page comes back empty.
+//
+//	iter := client.Payments.ListAutoPaging(ctx, reevit.PaymentListOptions{Status: "succeeded"})
+//	for iter.Next() {
+//		payment := iter.Current()
+//	}
+//	if err := iter.Err(); err != nil {
+//		return err
+//	}
+type Iter[T any] struct {
+	ctx     context.Context
+	fetch   func(ctx context.Context, limit, offset int) ([]T, error)
+	limit   int
+	offset  int
+	page    []T
+	index   int
+	current T
+	err     error
+	done    bool
+}
+
+func newIter[T any](ctx context.Context, limit, offset int, fetch func(ctx context.Context, limit, offset int) ([]T, error)) *Iter[T] {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	return &Iter[T]{ctx: ctx, fetch: fetch, limit: limit, offset: offset}
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when the results are exhausted or an error occurred.
+func (it *Iter[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		page, err := it.fetch(it.ctx, it.limit, it.offset)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.offset += len(page)
+		// A short page does not mean the end: the API may cap the page size below limit.
+		if len(page) == 0 {
+			it.done = true
+			return false
+		}
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Current returns the item the iterator is positioned on.
+func (it *Iter[T]) Current() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,126 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentsListAutoPaging(t *testing.T) {
+	var offsets, statuses []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statuses = append(statuses, r.URL.Query().Get("status"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		var page []PaymentSummary
+		for i := offset; i < offset+2 && i < 5; i++ {
+			page = append(page, PaymentSummary{ID: "pay_" + strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	iter := client.Payments.ListAutoPaging(context.Background(), PaymentListOptions{Limit: 2, Status: "succeeded"})
+
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []string{"pay_0", "pay_1", "pay_2", "pay_3", "pay_4"}, ids)
+	require.Equal(t, []string{"", "2", "4", "5"}, offsets)
+	require.Equal(t, []string{"succeeded", "succeeded", "succeeded", "succeeded"}, statuses)
+}
+
+func TestPaymentsListAutoPagingCursor(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		after := -1
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			after, _ = strconv.Atoi(cursor[len("pay_"):])
+		}
+		var page []PaymentSummary
+		for i := after + 1; i <= after+2 && i < 6; i++ {
+			page = append(page, PaymentSummary{ID: "pay_" + strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	iter := client.Payments.ListAutoPaging(context.Background(), PaymentListOptions{Limit: 2, Cursor: "pay_0"})
+
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []string{"pay_1", "pay_2", "pay_3", "pay_4", "pay_5"}, ids)
+	require.Equal(t, []string{
+		"/v1/payments?cursor=pay_0&limit=2",
+		"/v1/payments?cursor=pay_2&limit=2",
+		"/v1/payments?cursor=pay_4&limit=2",
+		"/v1/payments?cursor=pay_5&limit=2",
+	}, paths)
+}
+
+func TestCustomerListSubscriptionsAutoPaging(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := []Subscription{}
+		for i := offset; i < offset+2 && i < 3; i++ {
+			page = append(page, Subscription{ID: "sub_" + strconv.Itoa(i), CustomerID: "cus_1"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"subscriptions": page})
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	iter := client.Customers.ListSubscriptionsAutoPaging(context.Background(), "cus_1", PaginationOptions{Limit: 2})
+
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []string{"sub_0", "sub_1", "sub_2"}, ids)
+	require.Equal(t, []string{
+		"/v1/customers/cus_1/subscriptions?limit=2",
+		"/v1/customers/cus_1/subscriptions?limit=2&offset=2",
+		"/v1/customers/cus_1/subscriptions?limit=2&offset=3",
+	}, paths)
+}
+
+func TestAutoPagingWithCappedPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server returns at most 2 payments whatever the requested limit.
+		require.Equal(t, "500", r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := []PaymentSummary{}
+		for i := offset; i < offset+2 && i < 5; i++ {
+			page = append(page, PaymentSummary{ID: "pay_" + strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	iter := client.Payments.ListAutoPaging(context.Background(), PaymentListOptions{Limit: 500})
+
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []string{"pay_0", "pay_1", "pay_2", "pay_3", "pay_4"}, ids)
+	require.False(t, iter.Next())
+}
@@ -0,0 +1,61 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// PaymentEvent is an entry in the timeline of a payment, as shown in the dashboard.
+type PaymentEvent struct {
+	ID        string           `json:"id"`
+	PaymentID string           `json:"payment_id"`
+	Type      PaymentEventType `json:"type"`
+	// Status is the status of the payment after the event.
+	Status PaymentStatus `json:"status"`
+	// ConnectionID and Provider are set on routing and provider events.
+	ConnectionID string   `json:"connection_id,omitempty"`
+	Provider     Provider `json:"provider,omitempty"`
+	// Amount is the amount involved in minor units, e.g. the refunded amount.
+	Amount      int64  `json:"amount,omitempty"`
+	FailureCode string `json:"failure_code,omitempty"`
+	// Message is a human-readable description of the event, e.g. the PSP's response.
+	Message  string                 `json:"message,omitempty"`
+	Actor    PaymentEventActor      `json:"actor"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// OccurredAt is when the event happened, which for provider events may be earlier
+	// than when Reevit learned about it.
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// PaymentEventActor identifies who or what caused a payment event.
+type PaymentEventActor struct {
+	// Type is "api_key", "user", "provider" or "system".
+	Type string `json:"type"`
+	// ID is the API key ID, dashboard user ID or provider name, empty for "system".
+	ID string `json:"id,omitempty"`
+	// Name is a display name, such as the dashboard user's email address.
+	Name string `json:"name,omitempty"`
+}
+
+// ListEvents returns the timeline of a payment, oldest event first, so that support
+// tools can show how a payment was routed, attempted and settled.
+//
+// API Docs: GET /v1/payments/{id}/events
+func (s *PaymentsService) ListEvents(ctx context.Context, paymentID string, opts ...RequestOption) ([]PaymentEvent, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/payments/%s/events", paymentID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[PaymentEvent](s.client, raw, "events")
+}
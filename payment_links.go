@@ -2,7 +2,6 @@ package reevit
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -60,40 +59,41 @@ type PaymentLinkStats struct {
 }
 
 // List returns payment links for the current org.
-func (s *PaymentLinksService) List(ctx context.Context, options ...PaymentLinkListOptions) ([]PaymentLink, error) {
+func (s *PaymentLinksService) List(ctx context.Context, options *PaymentLinkListOptions, opts ...RequestOption) ([]PaymentLink, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
-		setString(values, "status", options[0].Status)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/payment-links", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/payment-links", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[PaymentLink](raw, "payment_links")
+	return decodeArrayResponse[PaymentLink](s.client, raw, "payment_links")
 }
 
 // Create creates a payment link.
 func (s *PaymentLinksService) Create(ctx context.Context, req *CreatePaymentLinkRequest, opts ...RequestOption) (*PaymentLink, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/payment-links", req)
+	req, err := s.client.withPaymentLinkDefaults(ctx, req, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/payment-links", req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var link PaymentLink
-	if err := s.client.do(ctx, httpRequest, &link); err != nil {
+	if err := s.client.do(httpRequest, &link); err != nil {
 		return nil, err
 	}
 
@@ -101,14 +101,14 @@ func (s *PaymentLinksService) Create(ctx context.Context, req *CreatePaymentLink
 }
 
 // Get fetches a payment link by ID.
-func (s *PaymentLinksService) Get(ctx context.Context, paymentLinkID string) (*PaymentLink, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/payment-links/%s", paymentLinkID), nil)
+func (s *PaymentLinksService) Get(ctx context.Context, paymentLinkID string, opts ...RequestOption) (*PaymentLink, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/payment-links/%s", paymentLinkID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var link PaymentLink
-	if err := s.client.do(ctx, httpRequest, &link); err != nil {
+	if err := s.client.do(httpRequest, &link); err != nil {
 		return nil, err
 	}
 
@@ -117,17 +117,13 @@ func (s *PaymentLinksService) Get(ctx context.Context, paymentLinkID string) (*P
 
 // Update updates a payment link.
 func (s *PaymentLinksService) Update(ctx context.Context, paymentLinkID string, req *UpdatePaymentLinkRequest, opts ...RequestOption) (*PaymentLink, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/payment-links/%s", paymentLinkID), req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/payment-links/%s", paymentLinkID), req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var link PaymentLink
-	if err := s.client.do(ctx, httpRequest, &link); err != nil {
+	if err := s.client.do(httpRequest, &link); err != nil {
 		return nil, err
 	}
 
@@ -136,27 +132,23 @@ func (s *PaymentLinksService) Update(ctx context.Context, paymentLinkID string,
 
 // Delete removes a payment link.
 func (s *PaymentLinksService) Delete(ctx context.Context, paymentLinkID string, opts ...RequestOption) error {
-	httpRequest, err := s.client.newRequest(http.MethodDelete, fmt.Sprintf("/v1/payment-links/%s", paymentLinkID), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/payment-links/%s", paymentLinkID), nil, opts...)
 	if err != nil {
 		return err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
-	return s.client.do(ctx, httpRequest, nil)
+	return s.client.do(httpRequest, nil)
 }
 
 // GetStats returns aggregate stats for a payment link.
-func (s *PaymentLinksService) GetStats(ctx context.Context, paymentLinkID string) (*PaymentLinkStats, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/payment-links/%s/stats", paymentLinkID), nil)
+func (s *PaymentLinksService) GetStats(ctx context.Context, paymentLinkID string, opts ...RequestOption) (*PaymentLinkStats, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/payment-links/%s/stats", paymentLinkID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var stats PaymentLinkStats
-	if err := s.client.do(ctx, httpRequest, &stats); err != nil {
+	if err := s.client.do(httpRequest, &stats); err != nil {
 		return nil, err
 	}
 
@@ -164,35 +156,35 @@ func (s *PaymentLinksService) GetStats(ctx context.Context, paymentLinkID string
 }
 
 // ListPayments lists payments created from a payment link.
-func (s *PaymentLinksService) ListPayments(ctx context.Context, paymentLinkID string, options ...PaginationOptions) ([]PaymentSummary, error) {
+func (s *PaymentLinksService) ListPayments(ctx context.Context, paymentLinkID string, options *PaginationOptions, opts ...RequestOption) ([]PaymentSummary, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath(fmt.Sprintf("/v1/payment-links/%s/payments", paymentLinkID), values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/payment-links/%s/payments", paymentLinkID), values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[PaymentSummary](raw, "payments")
+	return decodeArrayResponse[PaymentSummary](s.client, raw, "payments")
 }
 
 // GetByCode resolves a public payment link by code.
-func (s *PaymentLinksService) GetByCode(ctx context.Context, code string) (*PaymentLink, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/pay/%s", code), nil)
+func (s *PaymentLinksService) GetByCode(ctx context.Context, code string, opts ...RequestOption) (*PaymentLink, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/pay/%s", code), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var link PaymentLink
-	if err := s.client.do(ctx, httpRequest, &link); err != nil {
+	if err := s.client.do(httpRequest, &link); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,65 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PaymentSearchOptions controls paging and facets of a payment search.
+type PaymentSearchOptions struct {
+	Limit int
+	// Cursor is the NextCursor of the previous result page.
+	Cursor string
+	// Facets lists the fields to aggregate counts for, e.g. "status" or "provider".
+	Facets []string
+}
+
+// PaymentSearchResult is one page of payment search results.
+type PaymentSearchResult struct {
+	Data []PaymentSummary `json:"data"`
+	// Facets maps each requested facet field to its value counts across all matches.
+	Facets     map[string][]SearchFacetBucket `json:"facets"`
+	TotalCount int64                          `json:"total_count"`
+	HasMore    bool                           `json:"has_more"`
+	NextCursor string                         `json:"next_cursor"`
+}
+
+// SearchFacetBucket is the number of matches sharing a facet value.
+type SearchFacetBucket struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Search finds payments matching a query written in the search DSL, for example
+// `status:failed AND amount>5000 AND created:>2024-01-01`. Pass the NextCursor of a
+// result in options to fetch the following page.
+//
+// API Docs: GET /v1/payments/search
+func (s *PaymentsService) Search(ctx context.Context, query string, options *PaymentSearchOptions, opts ...RequestOption) (*PaymentSearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("reevit: search query is required")
+	}
+
+	values := url.Values{}
+	values.Set("query", query)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setString(values, "cursor", options.Cursor)
+		setString(values, "facets", strings.Join(options.Facets, ","))
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/payments/search", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PaymentSearchResult
+	if err := s.client.do(httpRequest, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,249 @@
+package reevit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is how often ConfirmAndWait re-fetches a payment when no
+// notification arrives.
+const DefaultPollInterval = 2 * time.Second
+
+// IsTerminalPaymentStatus reports whether a payment in status will not change any more
+// without further action. It is equivalent to PaymentStatus(status).IsTerminal().
+func IsTerminalPaymentStatus(status string) bool {
+	return PaymentStatus(status).IsTerminal()
+}
+
+// PaymentNotifier tells waiting callers that a payment may have changed status, typically
+// because a webhook or event stream delivered an update for it. Notifications only trigger
+// an early re-fetch; the payment returned to the caller always comes from the API.
+type PaymentNotifier interface {
+	// Notify returns a channel that receives a value whenever paymentID may have changed.
+	// The subscription ends when ctx is done.
+	Notify(ctx context.Context, paymentID string) <-chan struct{}
+}
+
+// WithPaymentNotifier makes ConfirmAndWait react to notifier instead of relying on
+// polling alone.
+func WithPaymentNotifier(notifier PaymentNotifier) Option {
+	return func(c *Client) {
+		c.paymentNotifier = notifier
+	}
+}
+
+// WaitOptions configures ConfirmAndWait.
+type WaitOptions struct {
+	// PollInterval is the delay between re-fetches. It defaults to DefaultPollInterval.
+	PollInterval time.Duration
+	// OnProgress, if set, is called after every fetch of the payment; see
+	// PollOptions.OnProgress.
+	OnProgress ProgressFunc
+}
+
+// ConfirmAndWait confirms a payment and blocks until it reaches a terminal status,
+// returning the final payment. Updates are picked up from the notifier configured with
+// WithPaymentNotifier, falling back to polling. Bound the wait with the context.
+func (s *PaymentsService) ConfirmAndWait(ctx context.Context, paymentID string, wait *WaitOptions, opts ...RequestOption) (*Payment, error) {
+	interval := DefaultPollInterval
+	if wait != nil && wait.PollInterval > 0 {
+		interval = wait.PollInterval
+	}
+
+	// Subscribe before confirming so that a notification sent right after the confirm
+	// response is not missed.
+	subscription, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var notifications <-chan struct{}
+	if s.client.paymentNotifier != nil {
+		notifications = s.client.paymentNotifier.Notify(subscription, paymentID)
+	}
+
+	tracker := NewProgressTracker(0, 0)
+	payment, err := s.Confirm(ctx, paymentID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	reportPaymentProgress(wait.progressFunc(), tracker, payment)
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for !payment.Status.IsTerminal() {
+		select {
+		case <-ctx.Done():
+			return payment, ctx.Err()
+		case _, ok := <-notifications:
+			if !ok {
+				notifications = nil
+				continue
+			}
+		case <-timer.C:
+		}
+
+		if payment, err = s.Get(ctx, paymentID, opts...); err != nil {
+			return nil, err
+		}
+		reportPaymentProgress(wait.progressFunc(), tracker, payment)
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(interval)
+	}
+	return payment, nil
+}
+
+// PollOptions configures WaitForStatus.
+type PollOptions struct {
+	// InitialInterval is the delay before the first re-fetch. It defaults to one second.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between re-fetches. It defaults to DefaultPollInterval
+	// times five.
+	MaxInterval time.Duration
+	// Multiplier grows the delay after every re-fetch. It defaults to 1.5.
+	Multiplier float64
+	// OnRouteAttempt, if set, is called once for every route attempt that appears on the
+	// payment while waiting, e.g. to tell the customer another provider is being tried.
+	OnRouteAttempt func(PaymentRouteAttempt)
+	// OnProgress, if set, is called after every fetch of the payment, e.g. to log a
+	// heartbeat. Stage is the payment status and Processed the number of route attempts
+	// so far; Total and ETA are unknown.
+	OnProgress ProgressFunc
+}
+
+// WaitForStatus polls a payment with backoff until its status is one of targets or
+// terminal, and returns it. It is typically called after the customer comes back from a
+// redirect flow. Like ConfirmAndWait it re-fetches early on notifications from
+// WithPaymentNotifier. When ctx is done it returns the last payment fetched with the
+// context's error.
+func (s *PaymentsService) WaitForStatus(ctx context.Context, paymentID string, targets []PaymentStatus, options PollOptions, opts ...RequestOption) (*Payment, error) {
+	interval := options.InitialInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := options.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 5 * DefaultPollInterval
+	}
+	multiplier := options.Multiplier
+	if multiplier < 1 {
+		multiplier = 1.5
+	}
+
+	subscription, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var notifications <-chan struct{}
+	if s.client.paymentNotifier != nil {
+		notifications = s.client.paymentNotifier.Notify(subscription, paymentID)
+	}
+
+	var payment *Payment
+	seenAttempts := 0
+	tracker := NewProgressTracker(0, 0)
+	for {
+		latest, err := s.Get(ctx, paymentID, opts...)
+		if err != nil {
+			return payment, err
+		}
+		payment = latest
+		reportPaymentProgress(options.OnProgress, tracker, payment)
+		if options.OnRouteAttempt != nil {
+			for ; seenAttempts < len(payment.Route); seenAttempts++ {
+				options.OnRouteAttempt(payment.Route[seenAttempts])
+			}
+		}
+		if payment.Status.IsTerminal() || hasStatus(targets, payment.Status) {
+			return payment, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return payment, ctx.Err()
+		case _, ok := <-notifications:
+			if !ok {
+				notifications = nil
+			}
+		case <-timer.C:
+		}
+		timer.Stop()
+
+		interval = time.Duration(float64(interval) * multiplier)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func (w *WaitOptions) progressFunc() ProgressFunc {
+	if w == nil {
+		return nil
+	}
+	return w.OnProgress
+}
+
+func reportPaymentProgress(onProgress ProgressFunc, tracker *ProgressTracker, payment *Payment) {
+	if onProgress != nil {
+		onProgress(tracker.Update(string(payment.Status), int64(len(payment.Route))))
+	}
+}
+
+func hasStatus(statuses []PaymentStatus, status PaymentStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentSignals is an in-process PaymentNotifier. Call Publish from a webhook handler
+// for every payment event received, and pass the signals to WithPaymentNotifier.
+type PaymentSignals struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+// NewPaymentSignals returns an empty PaymentSignals.
+func NewPaymentSignals() *PaymentSignals {
+	return &PaymentSignals{subscribers: make(map[string]map[chan struct{}]struct{})}
+}
+
+// Publish wakes every caller waiting on paymentID.
+func (p *PaymentSignals) Publish(paymentID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for ch := range p.subscribers[paymentID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// A notification is already pending; the waiter will re-fetch anyway.
+		}
+	}
+}
+
+// Notify implements PaymentNotifier.
+func (p *PaymentSignals) Notify(ctx context.Context, paymentID string) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	p.mu.Lock()
+	if p.subscribers[paymentID] == nil {
+		p.subscribers[paymentID] = make(map[chan struct{}]struct{})
+	}
+	p.subscribers[paymentID][ch] = struct{}{}
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.subscribers[paymentID], ch)
+		if len(p.subscribers[paymentID]) == 0 {
+			delete(p.subscribers, paymentID)
+		}
+	}()
+	return ch
+}
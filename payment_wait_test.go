@@ -0,0 +1,99 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmAndWaitPolls(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"id":"pay_1","status":"processing"}`))
+			return
+		}
+		if gets.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"id":"pay_1","status":"processing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"succeeded"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	payment, err := client.Payments.ConfirmAndWait(context.Background(), "pay_1", &WaitOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, PaymentStatusSucceeded, payment.Status)
+	require.Equal(t, int32(3), gets.Load())
+}
+
+func TestConfirmAndWaitNotifier(t *testing.T) {
+	signals := NewPaymentSignals()
+	var confirmed atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			confirmed.Store(true)
+			_, _ = w.Write([]byte(`{"id":"pay_1","status":"processing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"failed"}`))
+	}))
+	defer server.Close()
+
+	go func() {
+		for !confirmed.Load() {
+			time.Sleep(time.Millisecond)
+		}
+		signals.Publish("pay_1")
+	}()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithPaymentNotifier(signals))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	payment, err := client.Payments.ConfirmAndWait(ctx, "pay_1", &WaitOptions{PollInterval: time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, PaymentStatusFailed, payment.Status)
+}
+
+func TestWaitForStatus(t *testing.T) {
+	responses := []string{
+		`{"id":"pay_1","status":"processing","route":[{"connection_id":"conn_1","status":"failed"}]}`,
+		`{"id":"pay_1","status":"processing","route":[{"connection_id":"conn_1","status":"failed"},{"connection_id":"conn_2","status":"pending"}]}`,
+		`{"id":"pay_1","status":"requires_action","route":[{"connection_id":"conn_1","status":"failed"},{"connection_id":"conn_2","status":"pending"}]}`,
+	}
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(responses[gets.Add(1)-1]))
+	}))
+	defer server.Close()
+
+	var attempts, stages []string
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	payment, err := client.Payments.WaitForStatus(context.Background(), "pay_1", []PaymentStatus{PaymentStatusRequiresAction}, PollOptions{
+		InitialInterval: time.Millisecond,
+		OnRouteAttempt: func(attempt PaymentRouteAttempt) {
+			attempts = append(attempts, attempt.ConnectionID)
+		},
+		OnProgress: func(p Progress) {
+			stages = append(stages, fmt.Sprintf("%s/%d", p.Stage, p.Processed))
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentStatusRequiresAction, payment.Status)
+	require.Equal(t, []string{"conn_1", "conn_2"}, attempts)
+	require.Equal(t, []string{"processing/1", "processing/2", "requires_action/2"}, stages)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	gets.Store(0)
+	payment, err = client.Payments.WaitForStatus(ctx, "pay_1", nil, PollOptions{InitialInterval: time.Hour})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, PaymentStatusProcessing, payment.Status)
+}
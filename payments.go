@@ -3,7 +3,6 @@ package reevit
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -16,12 +15,72 @@ type PaymentsService service
 type PaymentIntentRequest struct {
 	Amount     int64                  `json:"amount"`
 	Currency   string                 `json:"currency"`
-	Method     string                 `json:"method"`
+	Method     PaymentMethod          `json:"method"`
 	Country    string                 `json:"country"`
 	CustomerID string                 `json:"customer_id,omitempty"`
 	Reference  string                 `json:"reference,omitempty"`
 	Policy     *FraudPolicyInput      `json:"policy,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	// Phone is the mobile money wallet to charge, in local or international format for
+	// Country. CreateIntent sends it in E.164 format.
+	Phone string `json:"phone,omitempty"`
+	// ScheduleAt defers the charge to a future time, e.g. for pre-orders. The payment stays
+	// "scheduled" until then and can be canceled with Cancel before it executes.
+	ScheduleAt *time.Time `json:"schedule_at,omitempty"`
+	// Split divides the payment between sub-merchants once it succeeds.
+	Split *SplitConfig `json:"split,omitempty"`
+	// ExpiresAt cancels the intent if it has not completed by then, e.g. when the
+	// checkout session it was created for is abandoned.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// AutoCancelAfter cancels the intent if it has not completed this long after it was
+	// created. It is sent in whole seconds; when both are set the earlier deadline wins.
+	AutoCancelAfter time.Duration `json:"-"`
+}
+
+type paymentIntentRequest PaymentIntentRequest
+
+// paymentIntentRequestJSON is the wire format of PaymentIntentRequest.
+type paymentIntentRequestJSON struct {
+	paymentIntentRequest
+	AutoCancelAfterSeconds int64 `json:"auto_cancel_after_seconds,omitempty"`
+}
+
+// MarshalJSON encodes AutoCancelAfter as auto_cancel_after_seconds.
+func (r PaymentIntentRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(paymentIntentRequestJSON{paymentIntentRequest(r), int64(r.AutoCancelAfter / time.Second)})
+}
+
+// UnmarshalJSON decodes auto_cancel_after_seconds into AutoCancelAfter.
+func (r *PaymentIntentRequest) UnmarshalJSON(data []byte) error {
+	var decoded paymentIntentRequestJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*r = PaymentIntentRequest(decoded.paymentIntentRequest)
+	r.AutoCancelAfter = time.Duration(decoded.AutoCancelAfterSeconds) * time.Second
+	return nil
+}
+
+// ConfirmRequest carries the PSP callback data used to confirm a payment.
+type ConfirmRequest struct {
+	// ProviderReference is the PSP's reference for the charge, e.g. from the callback URL.
+	ProviderReference string `json:"provider_reference,omitempty"`
+	// OTP is the one-time password entered by the customer, for card and mobile money
+	// flows that require one.
+	OTP string `json:"otp,omitempty"`
+	// ThreeDS is the outcome of a 3-D Secure challenge.
+	ThreeDS *ThreeDSResult `json:"three_ds,omitempty"`
+	// ProviderPayload passes the raw callback body through for PSP-specific fields.
+	ProviderPayload map[string]interface{} `json:"provider_payload,omitempty"`
+}
+
+// ThreeDSResult is the outcome of a 3-D Secure authentication.
+type ThreeDSResult struct {
+	TransactionID string `json:"transaction_id,omitempty"`
+	Status        string `json:"status,omitempty"`
+	ECI           string `json:"eci,omitempty"`
+	CAVV          string `json:"cavv,omitempty"`
+	Version       string `json:"version,omitempty"`
 }
 
 // PaymentIntentUpdateRequest represents a partial update to a payment intent.
@@ -43,10 +102,10 @@ type FraudPolicyInput struct {
 type Payment struct {
 	ID            string                 `json:"id"`
 	ConnectionID  string                 `json:"connection_id"`
-	Provider      string                 `json:"provider"`
+	Provider      Provider               `json:"provider"`
 	ProviderRefID string                 `json:"provider_ref_id"`
-	Method        string                 `json:"method"`
-	Status        string                 `json:"status"`
+	Method        PaymentMethod          `json:"method"`
+	Status        PaymentStatus          `json:"status"`
 	Amount        int64                  `json:"amount"`
 	Currency      string                 `json:"currency"`
 	FeeAmount     int64                  `json:"fee_amount"`
@@ -56,18 +115,39 @@ type Payment struct {
 	ClientSecret  string                 `json:"client_secret"`
 	Metadata      map[string]interface{} `json:"metadata"`
 	Route         []PaymentRouteAttempt  `json:"route"`
+	Refunds       []RefundSummary        `json:"refunds"`
 	Reference     string                 `json:"reference"`
 	CreatedAt     time.Time              `json:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// AuthorizedAmount, CapturedAmount and CaptureCount describe authorize-then-capture
+	// payments; they are zero for payments captured immediately.
+	AuthorizedAmount int64 `json:"authorized_amount,omitempty"`
+	CapturedAmount   int64 `json:"captured_amount,omitempty"`
+	CaptureCount     int   `json:"capture_count,omitempty"`
+
+	// ScheduledAt is when a scheduled payment will be, or was, charged.
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	// ExpiresAt is when an intent that has not completed is canceled automatically.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// FailureCode and FailureMessage explain why a failed payment was declined. See
+	// ClassifyFailure for what to do about it.
+	FailureCode    string `json:"failure_code,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
 }
 
 // PaymentSummary represents a summary of a payment object.
 type PaymentSummary struct {
 	ID           string                 `json:"id"`
 	ConnectionID string                 `json:"connection_id"`
-	Provider     string                 `json:"provider"`
-	Method       string                 `json:"method"`
-	Status       string                 `json:"status"`
+	Provider     Provider               `json:"provider"`
+	Method       PaymentMethod          `json:"method"`
+	Status       PaymentStatus          `json:"status"`
 	Amount       int64                  `json:"amount"`
 	Currency     string                 `json:"currency"`
 	FeeAmount    int64                  `json:"fee_amount"`
@@ -77,12 +157,17 @@ type PaymentSummary struct {
 	Metadata     map[string]interface{} `json:"metadata"`
 	Reference    string                 `json:"reference"`
 	CreatedAt    time.Time              `json:"created_at"`
+	ExpiresAt    *time.Time             `json:"expires_at,omitempty"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
 }
 
 // PaymentRouteAttempt represents a routing attempt.
 type PaymentRouteAttempt struct {
 	ConnectionID string        `json:"connection_id"`
-	Provider     string        `json:"provider"`
+	Provider     Provider      `json:"provider"`
 	Status       string        `json:"status"`
 	Error        string        `json:"error"`
 	Labels       []string      `json:"labels"`
@@ -96,22 +181,38 @@ type RoutingHints struct {
 	FallbackOnly      bool              `json:"fallback_only"`
 }
 
-// RefundRequest represents a payment refund request.
-type RefundRequest struct {
-	Amount int64  `json:"amount,omitempty"`
-	Reason string `json:"reason,omitempty"`
+// PaymentListOptions contains filters for payment listing.
+type PaymentListOptions struct {
+	Limit  int
+	Offset int
+	// Cursor is the ID of the last payment of the previous page. When set it takes
+	// precedence over Offset on the backend.
+	Cursor        string
+	Status        PaymentStatus
+	Provider      Provider
+	Method        PaymentMethod
+	Currency      string
+	CustomerID    string
+	Reference     string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// ExpiresBefore selects the intents that have not completed and expire before then.
+	ExpiresBefore time.Time
 }
 
-// Refund represents a refund record returned by the API.
-type Refund struct {
-	ID        string    `json:"id"`
-	PaymentID string    `json:"payment_id"`
-	Status    string    `json:"status"`
-	Amount    int64     `json:"amount"`
-	Currency  string    `json:"currency"`
-	Reason    string    `json:"reason"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+func (o PaymentListOptions) encode(values url.Values) {
+	setInt(values, "limit", o.Limit)
+	setInt(values, "offset", o.Offset)
+	setString(values, "cursor", o.Cursor)
+	setString(values, "status", string(o.Status))
+	setString(values, "provider", string(o.Provider))
+	setString(values, "method", string(o.Method))
+	setString(values, "currency", o.Currency)
+	setString(values, "customer_id", o.CustomerID)
+	setString(values, "reference", o.Reference)
+	setTime(values, "created_after", o.CreatedAfter)
+	setTime(values, "created_before", o.CreatedBefore)
+	setTime(values, "expires_before", o.ExpiresBefore)
 }
 
 // PaymentStatsOptions contains filters for payment stats queries.
@@ -140,59 +241,112 @@ type PaymentStats struct {
 	AdditionalStats map[string]interface{} `json:"-"`
 }
 
-// CreateIntent creates a new payment intent.
+// CreateIntent creates a new payment intent. With an IdempotencyResultStore, a request
+// whose idempotency key has already succeeded returns the stored payment without calling
+// the API.
 //
 // API Docs: POST /v1/payments/intents
 func (s *PaymentsService) CreateIntent(ctx context.Context, req *PaymentIntentRequest, opts ...RequestOption) (*Payment, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/payments/intents", req)
+	req, err := s.client.withIntentDefaults(ctx, req, opts)
 	if err != nil {
 		return nil, err
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req = req.withNormalizedPhone()
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/payments/intents", req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.doIdempotent(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
 	return &payment, nil
 }
 
-// List returns a list of payments.
+// List returns a list of payments matching the filters.
 //
 // API Docs: GET /v1/payments
-func (s *PaymentsService) List(ctx context.Context, limit, offset int) ([]PaymentSummary, error) {
+func (s *PaymentsService) List(ctx context.Context, options *PaymentListOptions, opts ...RequestOption) ([]PaymentSummary, error) {
 	values := url.Values{}
-	setInt(values, "limit", limit)
-	setInt(values, "offset", offset)
+	if options != nil {
+		options.encode(values)
+	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/payments", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/payments", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var payments []PaymentSummary
-	if err := s.client.do(ctx, httpRequest, &payments); err != nil {
+	if err := s.client.do(httpRequest, &payments); err != nil {
 		return nil, err
 	}
 
 	return payments, nil
 }
 
+// ListAutoPaging returns an iterator over all payments matching the filters,
+// fetching options.Limit payments per page. When options.Cursor is set, each page
+// starts after the last payment of the previous one instead of at an offset.
+func (s *PaymentsService) ListAutoPaging(ctx context.Context, options PaymentListOptions, opts ...RequestOption) *Iter[PaymentSummary] {
+	cursor := options.Cursor
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]PaymentSummary, error) {
+		page := options
+		page.Limit = limit
+		page.Offset = offset
+		if cursor != "" {
+			page.Cursor = cursor
+			page.Offset = 0
+		}
+		payments, err := s.List(ctx, &page, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if cursor != "" && len(payments) > 0 {
+			cursor = payments[len(payments)-1].ID
+		}
+		return payments, nil
+	})
+}
+
+// ListExpiring returns the intents that have not completed and expire within the given
+// duration, following every page, so that jobs can clean up or remind customers about
+// checkout sessions before they lapse.
+//
+// API Docs: GET /v1/payments?expires_before={time}
+func (s *PaymentsService) ListExpiring(ctx context.Context, within time.Duration, opts ...RequestOption) ([]PaymentSummary, error) {
+	it := s.ListAutoPaging(ctx, PaymentListOptions{ExpiresBefore: time.Now().Add(within)}, opts...)
+	var payments []PaymentSummary
+	for it.Next() {
+		payments = append(payments, it.Current())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
 // Get retrieves a payment by ID.
 //
 // API Docs: GET /v1/payments/{id}
-func (s *PaymentsService) Get(ctx context.Context, paymentID string) (*Payment, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/payments/%s", paymentID), nil)
+func (s *PaymentsService) Get(ctx context.Context, paymentID string, opts ...RequestOption) (*Payment, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/payments/%s", paymentID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.do(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
@@ -203,17 +357,17 @@ func (s *PaymentsService) Get(ctx context.Context, paymentID string) (*Payment,
 //
 // API Docs: PATCH /v1/payments/intents/{id}
 func (s *PaymentsService) UpdateIntent(ctx context.Context, paymentID string, req *PaymentIntentUpdateRequest, opts ...RequestOption) (*Payment, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/payments/intents/%s", paymentID), req)
-	if err != nil {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/payments/intents/%s", paymentID), req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.do(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
@@ -224,17 +378,31 @@ func (s *PaymentsService) UpdateIntent(ctx context.Context, paymentID string, re
 //
 // API Docs: POST /v1/payments/{id}/confirm
 func (s *PaymentsService) Confirm(ctx context.Context, paymentID string, opts ...RequestOption) (*Payment, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/payments/%s/confirm", paymentID), map[string]interface{}{})
-	if err != nil {
+	return s.ConfirmWithParams(ctx, paymentID, nil, opts...)
+}
+
+// ConfirmWithParams confirms a payment, passing the data the PSP returned to the
+// customer or the callback URL, such as an OTP or a 3-D Secure result. A nil req is
+// equivalent to Confirm.
+//
+// API Docs: POST /v1/payments/{id}/confirm
+func (s *PaymentsService) ConfirmWithParams(ctx context.Context, paymentID string, req *ConfirmRequest, opts ...RequestOption) (*Payment, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	var body interface{} = map[string]interface{}{}
+	if req != nil {
+		body = req
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/payments/%s/confirm", paymentID), body, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.do(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
@@ -245,45 +413,47 @@ func (s *PaymentsService) Confirm(ctx context.Context, paymentID string, opts ..
 //
 // API Docs: POST /v1/payments/{id}/confirm-intent
 func (s *PaymentsService) ConfirmIntent(ctx context.Context, paymentID, clientSecret string, opts ...RequestOption) (*Payment, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
 	values := url.Values{}
 	setString(values, "client_secret", clientSecret)
 
 	httpRequest, err := s.client.newRequest(
+		ctx,
 		http.MethodPost,
-		buildPath(fmt.Sprintf("/v1/payments/%s/confirm-intent", paymentID), values),
+		buildPath(pathf("/v1/payments/%s/confirm-intent", paymentID), values),
 		map[string]interface{}{},
+		opts...,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.do(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
 	return &payment, nil
 }
 
-// Cancel cancels a payment.
+// Cancel cancels a payment. Scheduled payments can be canceled until they execute.
 //
 // API Docs: POST /v1/payments/{id}/cancel
 func (s *PaymentsService) Cancel(ctx context.Context, paymentID string, opts ...RequestOption) (*Payment, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/payments/%s/cancel", paymentID), map[string]interface{}{})
-	if err != nil {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/payments/%s/cancel", paymentID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.do(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
@@ -294,48 +464,35 @@ func (s *PaymentsService) Cancel(ctx context.Context, paymentID string, opts ...
 //
 // API Docs: POST /v1/payments/{id}/retry
 func (s *PaymentsService) Retry(ctx context.Context, paymentID string, opts ...RequestOption) (*Payment, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/payments/%s/retry", paymentID), map[string]interface{}{})
-	if err != nil {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	opts = append([]RequestOption{withFreshIdempotencyKey()}, opts...)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/payments/%s/retry", paymentID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var payment Payment
-	if err := s.client.do(ctx, httpRequest, &payment); err != nil {
+	if err := s.client.do(httpRequest, &payment); err != nil {
 		return nil, err
 	}
 
 	return &payment, nil
 }
 
-// Refund creates a refund for a payment.
+// Refund creates a refund for a payment. It is equivalent to Refunds.Create.
 //
 // API Docs: POST /v1/payments/{id}/refund
 func (s *PaymentsService) Refund(ctx context.Context, paymentID string, req *RefundRequest, opts ...RequestOption) (*Refund, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/payments/%s/refund", paymentID), req)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
-	var refund Refund
-	if err := s.client.do(ctx, httpRequest, &refund); err != nil {
-		return nil, err
-	}
-
-	return &refund, nil
+	return s.client.Refunds.Create(ctx, paymentID, req, opts...)
 }
 
 // GetStats returns aggregated payment stats.
 //
 // API Docs: GET /v1/payments/stats
-func (s *PaymentsService) GetStats(ctx context.Context, options *PaymentStatsOptions) (*PaymentStats, error) {
+func (s *PaymentsService) GetStats(ctx context.Context, options *PaymentStatsOptions, opts ...RequestOption) (*PaymentStats, error) {
 	values := url.Values{}
 	if options != nil {
 		setString(values, "from", options.From)
@@ -347,12 +504,12 @@ func (s *PaymentsService) GetStats(ctx context.Context, options *PaymentStatsOpt
 		setString(values, "interval", options.Interval)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/payments/stats", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/payments/stats", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
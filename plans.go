@@ -0,0 +1,171 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PlansService handles communication with the subscription plan related methods of the Reevit API.
+type PlansService service
+
+// Plan represents a managed subscription plan.
+type Plan struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Interval      Interval               `json:"interval"`
+	IntervalCount int                    `json:"interval_count"`
+	TrialDays     int                    `json:"trial_days"`
+	Status        string                 `json:"status"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// PlanRequest represents a request to create a plan.
+type PlanRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Amount      int64    `json:"amount"`
+	Currency    string   `json:"currency"`
+	Interval    Interval `json:"interval"`
+	// IntervalCount bills every IntervalCount intervals, e.g. 3 with "monthly" for quarterly plans.
+	IntervalCount int                    `json:"interval_count,omitempty"`
+	TrialDays     int                    `json:"trial_days,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PlanUpdateRequest represents a partial update to a plan. Pricing cannot be changed once
+// a plan exists; create a new plan and move subscriptions to it instead.
+type PlanUpdateRequest struct {
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	TrialDays   *int                   `json:"trial_days,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PlanListOptions contains list filters for plans.
+type PlanListOptions struct {
+	Limit    int
+	Offset   int
+	Status   string
+	Currency string
+	Interval Interval
+}
+
+// Create creates a new plan.
+//
+// API Docs: POST /v1/plans
+func (s *PlansService) Create(ctx context.Context, req *PlanRequest, opts ...RequestOption) (*Plan, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/plans", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan Plan
+	if err := s.client.do(httpRequest, &plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// List returns a list of plans.
+//
+// API Docs: GET /v1/plans
+func (s *PlansService) List(ctx context.Context, options *PlanListOptions, opts ...RequestOption) ([]Plan, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+		setString(values, "currency", options.Currency)
+		setString(values, "interval", string(options.Interval))
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/plans", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[Plan](s.client, raw, "plans")
+}
+
+// Get retrieves a plan by ID.
+//
+// API Docs: GET /v1/plans/{id}
+func (s *PlansService) Get(ctx context.Context, planID string, opts ...RequestOption) (*Plan, error) {
+	if err := checkID(IDKindPlan, planID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/plans/%s", planID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan Plan
+	if err := s.client.do(httpRequest, &plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// Update updates a plan.
+//
+// API Docs: PATCH /v1/plans/{id}
+func (s *PlansService) Update(ctx context.Context, planID string, req *PlanUpdateRequest, opts ...RequestOption) (*Plan, error) {
+	if err := checkID(IDKindPlan, planID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/plans/%s", planID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan Plan
+	if err := s.client.do(httpRequest, &plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// Archive archives a plan. Existing subscriptions keep renewing on it, but no new
+// subscriptions can be created from an archived plan.
+//
+// API Docs: POST /v1/plans/{id}/archive
+func (s *PlansService) Archive(ctx context.Context, planID string, opts ...RequestOption) (*Plan, error) {
+	if err := checkID(IDKindPlan, planID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/plans/%s/archive", planID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan Plan
+	if err := s.client.do(httpRequest, &plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// ImportByID fetches an existing plan so it can be adopted by an external state
+// manager such as a Terraform provider.
+func (s *PlansService) ImportByID(ctx context.Context, planID string, opts ...RequestOption) (*Plan, error) {
+	return importByID(ctx, "plan", planID, s.Get, opts...)
+}
@@ -0,0 +1,85 @@
+package reevit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// PreconnectOptions configures Preconnect.
+type PreconnectOptions struct {
+	// Connections is the number of connections to open in parallel, matching the
+	// concurrency expected right after startup. It defaults to 1. Connections beyond the
+	// transport's MaxIdleConnsPerHost (2 for the default transport) are not kept.
+	Connections int
+	// Prefetch also loads the fraud policy and the connection list and returns them, so
+	// that the first payments do not wait for them.
+	Prefetch bool
+}
+
+// PreconnectResult holds the data loaded by Preconnect with Prefetch set.
+type PreconnectResult struct {
+	FraudPolicy *FraudPolicy
+	Connections []Connection
+}
+
+// Preconnect resolves the API host and establishes TLS connections ahead of the first
+// request, removing the latency spike seen after a deploy. Call it during service
+// startup, bounded by ctx. The warm-up requests carry no credentials; any HTTP response
+// counts as success.
+func (c *Client) Preconnect(ctx context.Context, options *PreconnectOptions) (*PreconnectResult, error) {
+	connections := 1
+	prefetch := false
+	if options != nil {
+		if options.Connections > 1 {
+			connections = options.Connections
+		}
+		prefetch = options.Prefetch
+	}
+
+	errs := make(chan error, connections)
+	var wg sync.WaitGroup
+	for i := 0; i < connections; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.warmConnection(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &PreconnectResult{}
+	if !prefetch {
+		return result, nil
+	}
+	var err error
+	if result.FraudPolicy, err = c.Fraud.Get(ctx); err != nil {
+		return nil, err
+	}
+	if result.Connections, err = c.Connections.List(ctx, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) warmConnection(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURL+"/", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgentHeader())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	// Drain the body so the connection returns to the idle pool.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
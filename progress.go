@@ -0,0 +1,70 @@
+package reevit
+
+import "time"
+
+// Progress describes how far a long-running operation, such as a backfill or a wait for a
+// payment to settle, has come, so that CLIs can render progress bars and jobs can log
+// heartbeats.
+type Progress struct {
+	// Stage names the current phase, e.g. the resource being backfilled or the status of
+	// the payment being waited on.
+	Stage string
+	// Processed counts the items handled so far, including those of earlier runs of a
+	// resumed operation.
+	Processed int64
+	// Total is the number of items expected, or zero when it is unknown.
+	Total int64
+	// Elapsed is the time since the operation started.
+	Elapsed time.Duration
+	// ETA estimates the time left from the rate so far, or is zero when it cannot be
+	// estimated.
+	ETA time.Duration
+}
+
+// Percent returns Processed as a percentage of Total, or -1 when Total is unknown.
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	if p.Processed >= p.Total {
+		return 100
+	}
+	return float64(p.Processed) * 100 / float64(p.Total)
+}
+
+// ProgressFunc receives progress updates. It is called synchronously from the operation,
+// so it should return quickly.
+type ProgressFunc func(Progress)
+
+// ProgressTracker turns item counts into Progress values with an elapsed time and ETA.
+// It is not safe for concurrent use.
+type ProgressTracker struct {
+	start time.Time
+	base  int64
+	total int64
+}
+
+// NewProgressTracker starts tracking an operation expected to handle total items, zero
+// when unknown, of which processed were already handled by an earlier run. Only the
+// items handled from now on count towards the rate behind the ETA.
+func NewProgressTracker(total, processed int64) *ProgressTracker {
+	return &ProgressTracker{start: time.Now(), base: processed, total: total}
+}
+
+// SetTotal updates the number of items expected, e.g. once it has been counted.
+func (t *ProgressTracker) SetTotal(total int64) {
+	t.total = total
+}
+
+// Update returns the progress after processed items in stage.
+func (t *ProgressTracker) Update(stage string, processed int64) Progress {
+	return t.progress(stage, processed, time.Now())
+}
+
+func (t *ProgressTracker) progress(stage string, processed int64, now time.Time) Progress {
+	p := Progress{Stage: stage, Processed: processed, Total: t.total, Elapsed: now.Sub(t.start)}
+	if done := processed - t.base; t.total > 0 && done > 0 && processed < t.total {
+		p.ETA = time.Duration(float64(p.Elapsed) * float64(t.total-processed) / float64(done))
+	}
+	return p
+}
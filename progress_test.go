@@ -0,0 +1,113 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker(t *testing.T) {
+	tracker := NewProgressTracker(100, 20)
+	start := tracker.start
+
+	p := tracker.progress("payments", 20, start.Add(time.Second))
+	require.Zero(t, p.ETA)
+	require.Equal(t, float64(20), p.Percent())
+
+	p = tracker.progress("payments", 60, start.Add(10*time.Second))
+	require.Equal(t, 10*time.Second, p.Elapsed)
+	require.Equal(t, 10*time.Second, p.ETA)
+	require.Equal(t, float64(60), p.Percent())
+
+	tracker.SetTotal(0)
+	p = tracker.progress("payments", 60, start.Add(10*time.Second))
+	require.Zero(t, p.ETA)
+	require.Equal(t, float64(-1), p.Percent())
+}
+
+func TestTransfersCreateBulkProgress(t *testing.T) {
+	var created int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["reference"] == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid destination"}`))
+			return
+		}
+		created++
+		_, _ = fmt.Fprintf(w, `{"id":"tr_%d","status":"pending"}`, created)
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	reqs := []*TransferRequest{
+		{Amount: 1000, Currency: "GHS", DestinationID: "dest_1", Reference: "ok"},
+		{Amount: 1000, Currency: "GHS", DestinationID: "dest_2", Reference: "bad"},
+		{Amount: 1000, Currency: "GHS", DestinationID: "dest_3", Reference: "ok"},
+	}
+
+	var updates []Progress
+	results, err := client.Transfers.CreateBulk(context.Background(), reqs, &BulkTransferOptions{
+		OnProgress: func(p Progress) { updates = append(updates, p) },
+	})
+	require.ErrorContains(t, err, "1 of 3 transfers failed")
+	require.Len(t, results, 3)
+	require.Equal(t, "tr_1", results[0].Transfer.ID)
+	require.Error(t, results[1].Err)
+	require.Equal(t, "tr_2", results[2].Transfer.ID)
+	require.Len(t, updates, 3)
+	for i, p := range updates {
+		require.Equal(t, "transfers", p.Stage)
+		require.Equal(t, int64(i+1), p.Processed)
+		require.Equal(t, int64(3), p.Total)
+	}
+
+	results, err = client.Transfers.CreateBulk(context.Background(), reqs, &BulkTransferOptions{StopOnError: true})
+	require.ErrorContains(t, err, "1 of 2 transfers failed")
+	require.Len(t, results, 2)
+}
+
+func TestReportsWaitUntilReadyProgress(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/reports/rep_1", r.URL.Path)
+		polls++
+		switch polls {
+		case 1:
+			_, _ = w.Write([]byte(`{"id":"rep_1","status":"pending"}`))
+		case 2:
+			_, _ = w.Write([]byte(`{"id":"rep_1","status":"processing","rows_processed":400,"rows_total":1000}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"rep_1","status":"completed","rows_processed":1000,"rows_total":1000,"file_id":"file_1"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	var updates []Progress
+	report, err := client.Reports.WaitUntilReady(context.Background(), "rep_1", &ReportWaitOptions{
+		PollInterval: time.Millisecond,
+		OnProgress:   func(p Progress) { updates = append(updates, p) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, "file_1", report.FileID)
+	require.Len(t, updates, 3)
+	require.Equal(t, ReportPending, updates[0].Stage)
+	require.Equal(t, float64(40), updates[1].Percent())
+	require.Equal(t, ReportCompleted, updates[2].Stage)
+	require.Equal(t, int64(1000), updates[2].Processed)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"rep_1","status":"failed","failure_reason":"range too large"}`))
+	})
+	_, err = client.Reports.WaitUntilReady(context.Background(), "rep_1", nil)
+	require.ErrorIs(t, err, ErrReportFailed)
+	require.ErrorContains(t, err, "range too large")
+}
@@ -0,0 +1,97 @@
+package reevit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the client to rps requests per second with bursts of up to burst
+// requests, so bulk jobs such as backfills stay under the API's limits. Calls wait for a
+// token instead of failing; a cancelled context aborts the wait.
+//
+// When the API still answers 429, the limiter holds every caller until the Retry-After
+// delay has passed, and the request is retried if WithMaxRetries allows it.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter is a token bucket. Tokens may go negative: each caller reserves a token and
+// waits until the bucket has refilled past its reservation, which keeps callers in order.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if paused := l.pausedUntil.Sub(now); paused > delay {
+		delay = paused
+	}
+	l.mu.Unlock()
+
+	if err := sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// pause holds all callers for d, used when the server reports that the limit was hit anyway.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
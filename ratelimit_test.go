@@ -0,0 +1,104 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := newRateLimiter(50, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, limiter.wait(ctx))
+	}
+	// Two tokens are available immediately; the other two take 20ms each.
+	require.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	limiter.pause(time.Second)
+	require.ErrorIs(t, limiter.wait(cancelled), context.Canceled)
+}
+
+func TestRetriesRateLimitedRequests(t *testing.T) {
+	var attempts int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(buf)
+		bodies = append(bodies, string(buf))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"code":"rate_limited","message":"slow down"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithRateLimit(100, 1), WithMaxRetries(2))
+	payment, err := client.Payments.CreateIntent(context.Background(), &PaymentIntentRequest{Amount: 100, Currency: "GHS"})
+	require.NoError(t, err)
+	require.Equal(t, "pay_1", payment.ID)
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	require.Equal(t, bodies[0], bodies[1])
+
+	atomic.StoreInt32(&attempts, 0)
+	noRetry := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err = noRetry.Payments.Get(context.Background(), "pay_1")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.True(t, apiErr.IsRateLimited())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.Zero(t, delay)
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
+
+func TestRetryBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"code":"rate_limited","message":"slow down"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1",
+		WithBaseURL(server.URL),
+		WithMaxRetries(5),
+		WithRetryBudget(RetryBudget{Ratio: 0.2, MinRetries: 2, Window: time.Minute}),
+	)
+	_, err := client.Payments.Get(context.Background(), "pay_1")
+	var exhausted *RetryBudgetExhaustedError
+	require.ErrorAs(t, err, &exhausted)
+	require.Equal(t, "GET /v1/payments/{id}", exhausted.Endpoint)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.True(t, apiErr.IsRateLimited())
+	require.Equal(t, RetryStats{Requests: 1, Retries: 2, BudgetExhausted: 1}, client.RetryStats())
+
+	budget := &retryBudget{config: RetryBudget{Ratio: 1, PerEndpoint: 1, Window: time.Minute}}
+	budget.recordRequest()
+	budget.recordRequest()
+	require.True(t, budget.allowRetry("GET /v1/payments/{id}"))
+	require.False(t, budget.allowRetry("GET /v1/payments/{id}"))
+	require.True(t, budget.allowRetry("GET /v1/refunds/{id}"))
+}
@@ -0,0 +1,46 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+)
+
+// NewRequest builds a request for an endpoint the SDK does not wrap yet. path is relative
+// to the base URL and may carry a query string; body, when not nil, is sent as JSON. The
+// request carries the same authentication and identification headers as the SDK's own
+// calls, and the RequestOptions can be applied to it directly:
+//
+//	req, err := client.NewRequest(http.MethodPost, "/v1/payouts", payout)
+//	reevit.WithIdempotencyKey(key)(req)
+//	var result map[string]interface{}
+//	resp, err := client.Do(ctx, req, &result)
+//
+// Credentials from a CredentialsProvider or TokenSource are loaded without a deadline.
+func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	return c.newRequest(context.Background(), method, path, body)
+}
+
+// Do sends a request built with NewRequest, bound to ctx, with the client's rate limiting,
+// retries, middleware and error handling, and decodes a JSON response body into v unless
+// v is nil. The returned Response is also set when the API answers with an error.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if settings, ok := req.Context().Value(requestSettingsKey{}).(*requestSettings); ok {
+		ctx = context.WithValue(ctx, requestSettingsKey{}, settings)
+	}
+	if _, ok := ctx.Value(responseCaptureKey{}).(*responseCapture); !ok {
+		ctx = WithResponseCapture(ctx)
+	}
+	req = req.WithContext(ctx)
+
+	body, err := c.doRaw(req)
+	response := ResponseFromContext(ctx)
+	if err != nil {
+		return response, err
+	}
+	if v != nil && len(body) > 0 {
+		if err := c.decodeJSON(body, v); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,125 @@
+package reevit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// RedactFunc rewrites a single field of an outgoing JSON request body.
+// It receives the field name and its decoded value and returns the value to send instead.
+type RedactFunc func(field string, value interface{}) interface{}
+
+// WithRedaction installs a redaction policy that is applied to every request body
+// before it is sent. Multiple policies run in the order they were configured.
+func WithRedaction(fns ...RedactFunc) Option {
+	return func(c *Client) {
+		c.redactors = append(c.redactors, fns...)
+	}
+}
+
+// HashFields replaces the string values of the named fields with their SHA-256 hex digest.
+// Values are trimmed and lower-cased first so equal inputs always hash to the same digest.
+func HashFields(fields ...string) RedactFunc {
+	set := fieldSet(fields)
+	return func(field string, value interface{}) interface{} {
+		str, ok := value.(string)
+		if !ok || !set[field] || str == "" {
+			return value
+		}
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(str))))
+		return hex.EncodeToString(sum[:])
+	}
+}
+
+// TruncateFields keeps only the last keep characters of the named string fields,
+// masking the rest with '*'.
+func TruncateFields(keep int, fields ...string) RedactFunc {
+	set := fieldSet(fields)
+	return func(field string, value interface{}) interface{} {
+		str, ok := value.(string)
+		if !ok || !set[field] {
+			return value
+		}
+		runes := []rune(str)
+		if keep < 0 || len(runes) <= keep {
+			return value
+		}
+		masked := strings.Repeat("*", len(runes)-keep)
+		return masked + string(runes[len(runes)-keep:])
+	}
+}
+
+// HashEmails hashes the email field of request bodies.
+func HashEmails() RedactFunc {
+	return HashFields("email")
+}
+
+// TruncatePhones masks all but the last four digits of phone fields.
+func TruncatePhones() RedactFunc {
+	return TruncateFields(4, "phone", "msisdn", "phone_number")
+}
+
+func fieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		set[field] = true
+	}
+	return set
+}
+
+// redactBody applies the configured redaction policies to an encoded JSON body.
+func (c *Client) redactBody(body []byte) ([]byte, error) {
+	if len(c.redactors) == 0 || len(body) == 0 {
+		return body, nil
+	}
+
+	// UseNumber keeps large minor-unit amounts from being rounded through float64.
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	redacted, err := json.Marshal(redactValue(decoded, c.redactors))
+	if err != nil {
+		return nil, err
+	}
+	return append(redacted, '\n'), nil
+}
+
+func redactValue(value interface{}, redactors []RedactFunc) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range typed {
+			typed[key] = redactField(key, inner, redactors)
+		}
+		return typed
+	case []interface{}:
+		for i, inner := range typed {
+			typed[i] = redactValue(inner, redactors)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// redactField redacts the value of a field. Array elements are treated as values of the
+// enclosing field, so a policy on "phones" also applies to each phone in the list.
+func redactField(field string, value interface{}, redactors []RedactFunc) interface{} {
+	if elements, ok := value.([]interface{}); ok {
+		for i, element := range elements {
+			elements[i] = redactField(field, element, redactors)
+		}
+	} else {
+		value = redactValue(value, redactors)
+	}
+	for _, redact := range redactors {
+		value = redact(field, value)
+	}
+	return value
+}
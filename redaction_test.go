@@ -0,0 +1,75 @@
+package reevit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactFuncs(t *testing.T) {
+	sum := sha256.Sum256([]byte("ama@example.com"))
+	emailDigest := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name   string
+		redact RedactFunc
+		field  string
+		value  interface{}
+		want   interface{}
+	}{
+		{"hash normalizes", HashEmails(), "email", "  Ama@Example.com ", emailDigest},
+		{"hash skips other fields", HashEmails(), "name", "Ama", "Ama"},
+		{"hash skips empty", HashEmails(), "email", "", ""},
+		{"hash skips non-strings", HashEmails(), "email", 42, 42},
+		{"truncate phone", TruncatePhones(), "phone", "+233241234567", "*********4567"},
+		{"truncate msisdn", TruncatePhones(), "msisdn", "0241234567", "******4567"},
+		{"truncate short value", TruncatePhones(), "phone", "123", "123"},
+		{"truncate other field", TruncatePhones(), "reference", "ref_123456", "ref_123456"},
+		{"truncate negative keep", TruncateFields(-1, "card"), "card", "4111", "4111"},
+		{"truncate multibyte", TruncateFields(1, "name"), "name", "Kọfí", "***í"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.redact(tt.field, tt.value))
+		})
+	}
+}
+
+func TestWithRedaction(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(raw)
+		_, _ = w.Write([]byte(`{"id":"cus_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithRedaction(HashFields("email"), TruncatePhones()))
+	req, err := client.NewRequest(http.MethodPost, "/v1/customers", map[string]interface{}{
+		"email":  "ama@example.com",
+		"amount": int64(9007199254740993),
+		"contacts": []interface{}{
+			map[string]interface{}{"phone": "0241234567"},
+		},
+		"phone": []interface{}{"0241234567", "0201234567"},
+	})
+	require.NoError(t, err)
+	_, err = client.Do(context.Background(), req, nil)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("ama@example.com"))
+	require.JSONEq(t, `{
+		"email": "`+hex.EncodeToString(sum[:])+`",
+		"amount": 9007199254740993,
+		"contacts": [{"phone": "******4567"}],
+		"phone": ["******4567", "******4567"]
+	}`, body)
+	require.Contains(t, body, "9007199254740993")
+}
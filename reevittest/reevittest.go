@@ -0,0 +1,694 @@
+// Package reevittest provides an in-memory fake of the Reevit API for hermetic tests of
+// code that uses the SDK. It covers payments, connections and subscriptions, records
+// every request and can inject failures.
+//
+//	server := reevittest.NewServer()
+//	defer server.Close()
+//	server.AddConnection(reevit.Connection{ID: "conn_1", Provider: reevit.ProviderPaystack})
+//	server.InjectFailure(reevittest.Failure{Method: http.MethodPost, Path: "/v1/payments/*/confirm", Status: http.StatusBadGateway, Times: 1})
+//
+//	client := server.Client()
+//	// exercise the code under test with client
+//
+// The fake implements the happy paths and the most common state checks of the real API
+// (confirming a canceled payment is a conflict, unknown IDs are not found); it is not a
+// substitute for integration tests against the sandbox.
+package reevittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+)
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Failure makes matching requests fail with an error response instead of reaching the fake.
+type Failure struct {
+	// Method matches the HTTP method; empty matches any method.
+	Method string
+	// Path is matched with path.Match, so "*" matches one path segment,
+	// e.g. "/v1/payments/*/confirm".
+	Path   string
+	Status int
+	// Code and Message fill the error envelope. Message defaults to the status text.
+	Code    string
+	Message string
+	// RetryAfter sets the Retry-After header, e.g. "1" for rate-limit tests.
+	RetryAfter string
+	// Times limits how many requests fail; zero fails every matching request.
+	Times int
+}
+
+// Server is a fake Reevit API backed by httptest.Server.
+type Server struct {
+	// URL is the base URL of the fake, for use with reevit.WithBaseURL.
+	URL string
+
+	server *httptest.Server
+
+	mu            sync.Mutex
+	payments      collection[reevit.Payment]
+	connections   collection[reevit.Connection]
+	subscriptions collection[reevit.Subscription]
+	requests      []Request
+	failures      []*Failure
+	nextID        int
+}
+
+// NewServer starts a fake API server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		payments:      newCollection[reevit.Payment](),
+		connections:   newCollection[reevit.Connection](),
+		subscriptions: newCollection[reevit.Subscription](),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client configured for the fake. opts are applied after the base URL.
+func (s *Server) Client(opts ...reevit.Option) *reevit.Client {
+	opts = append([]reevit.Option{reevit.WithBaseURL(s.URL)}, opts...)
+	return reevit.NewClient("pfk_test_reevittest", "org_test", opts...)
+}
+
+// AddPayment stores a payment fixture, assigning an ID when it has none.
+func (s *Server) AddPayment(payment reevit.Payment) reevit.Payment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if payment.ID == "" {
+		payment.ID = s.newID("pay")
+	}
+	if payment.Status == "" {
+		payment.Status = reevit.PaymentStatusPending
+	}
+	s.payments.put(payment.ID, payment)
+	return payment
+}
+
+// AddConnection stores a connection fixture, assigning an ID when it has none.
+func (s *Server) AddConnection(connection reevit.Connection) reevit.Connection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if connection.ID == "" {
+		connection.ID = s.newID("conn")
+	}
+	if connection.Status == "" {
+		connection.Status = "active"
+	}
+	s.connections.put(connection.ID, connection)
+	return connection
+}
+
+// AddSubscription stores a subscription fixture, assigning an ID when it has none.
+func (s *Server) AddSubscription(subscription reevit.Subscription) reevit.Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if subscription.ID == "" {
+		subscription.ID = s.newID("sub")
+	}
+	if subscription.Status == "" {
+		subscription.Status = reevit.SubscriptionStatusActive
+	}
+	s.subscriptions.put(subscription.ID, subscription)
+	return subscription
+}
+
+// Payment returns the current state of a payment.
+func (s *Server) Payment(id string) (reevit.Payment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.payments.get(id)
+}
+
+// Connection returns the current state of a connection.
+func (s *Server) Connection(id string) (reevit.Connection, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections.get(id)
+}
+
+// Subscription returns the current state of a subscription.
+func (s *Server) Subscription(id string) (reevit.Subscription, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subscriptions.get(id)
+}
+
+// InjectFailure registers a failure. Failures are matched in registration order.
+func (s *Server) InjectFailure(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure)
+}
+
+// Requests returns the requests received so far, including failed ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ClearRequests forgets the recorded requests.
+func (s *Server) ClearRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_test_%d", prefix, s.nextID)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if failure := s.matchFailure(r); failure != nil {
+		if failure.RetryAfter != "" {
+			w.Header().Set("Retry-After", failure.RetryAfter)
+		}
+		message := failure.Message
+		if message == "" {
+			message = http.StatusText(failure.Status)
+		}
+		writeError(w, failure.Status, failure.Code, message)
+		return
+	}
+	if r.Header.Get("X-Reevit-Key") == "" && !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "missing API key or bearer token")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "v1" {
+		notFound(w, r)
+		return
+	}
+	switch segments[1] {
+	case "payments":
+		s.servePayments(w, r, segments[2:], body)
+	case "connections":
+		s.serveConnections(w, r, segments[2:], body)
+	case "subscriptions":
+		s.serveSubscriptions(w, r, segments[2:], body)
+	default:
+		notFound(w, r)
+	}
+}
+
+func (s *Server) matchFailure(r *http.Request) *Failure {
+	for i, failure := range s.failures {
+		if failure.Method != "" && !strings.EqualFold(failure.Method, r.Method) {
+			continue
+		}
+		if matched, _ := path.Match(failure.Path, r.URL.Path); !matched {
+			continue
+		}
+		if failure.Times > 0 {
+			if failure.Times--; failure.Times == 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+func (s *Server) servePayments(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	now := time.Now().UTC()
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		var summaries []reevit.PaymentSummary
+		for _, payment := range s.payments.list() {
+			if !matches(query, "status", payment.Status) || !matches(query, "customer_id", payment.CustomerID) ||
+				!matches(query, "reference", payment.Reference) || !matches(query, "provider", payment.Provider) ||
+				!expiresBefore(query, payment) {
+				continue
+			}
+			summaries = append(summaries, summarize(payment))
+		}
+		// A cursor pages from the payment after the one it names.
+		if cursor := query.Get("cursor"); cursor != "" {
+			after := len(summaries)
+			for i, summary := range summaries {
+				if summary.ID == cursor {
+					after = i + 1
+					break
+				}
+			}
+			summaries = summaries[after:]
+		}
+		writeJSON(w, http.StatusOK, paginate(summaries, query))
+
+	case len(segments) == 1 && segments[0] == "intents" && r.Method == http.MethodPost:
+		var req reevit.PaymentIntentRequest
+		if !decode(w, body, &req) {
+			return
+		}
+		if req.Amount <= 0 || len(req.Currency) != 3 {
+			writeValidationError(w, "amount", "amount must be positive and currency a 3-letter code")
+			return
+		}
+		id := s.newID("pay")
+		payment := reevit.Payment{
+			ID:           id,
+			Method:       req.Method,
+			Status:       reevit.PaymentStatusPending,
+			Amount:       req.Amount,
+			Currency:     strings.ToUpper(req.Currency),
+			CustomerID:   req.CustomerID,
+			ClientSecret: id + "_secret",
+			Metadata:     req.Metadata,
+			Reference:    req.Reference,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if req.ScheduleAt != nil {
+			payment.Status = reevit.PaymentStatusScheduled
+			payment.ScheduledAt = req.ScheduleAt
+		}
+		if req.ExpiresAt != nil {
+			payment.ExpiresAt = req.ExpiresAt
+		}
+		if req.AutoCancelAfter > 0 {
+			if expiresAt := now.Add(req.AutoCancelAfter); payment.ExpiresAt == nil || expiresAt.Before(*payment.ExpiresAt) {
+				payment.ExpiresAt = &expiresAt
+			}
+		}
+		s.payments.put(id, payment)
+		writeJSON(w, http.StatusCreated, payment)
+
+	case len(segments) == 2 && segments[0] == "intents" && r.Method == http.MethodPatch:
+		payment, ok := s.payments.get(segments[1])
+		if !ok {
+			notFound(w, r)
+			return
+		}
+		var req reevit.PaymentIntentUpdateRequest
+		if !decode(w, body, &req) {
+			return
+		}
+		if req.Amount != nil {
+			payment.Amount = *req.Amount
+		}
+		if req.Metadata != nil {
+			payment.Metadata = req.Metadata
+		}
+		payment.UpdatedAt = now
+		s.payments.put(payment.ID, payment)
+		writeJSON(w, http.StatusOK, payment)
+
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		payment, ok := s.payments.get(segments[0])
+		if !ok {
+			notFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, payment)
+
+	case len(segments) == 2 && r.Method == http.MethodPost:
+		payment, ok := s.payments.get(segments[0])
+		if !ok {
+			notFound(w, r)
+			return
+		}
+		var next reevit.PaymentStatus
+		switch segments[1] {
+		case "confirm", "confirm-intent":
+			next = reevit.PaymentStatusSucceeded
+		case "cancel":
+			next = reevit.PaymentStatusCanceled
+		default:
+			notFound(w, r)
+			return
+		}
+		if payment.Status != reevit.PaymentStatusPending && !(payment.Status == reevit.PaymentStatusScheduled && next == reevit.PaymentStatusCanceled) {
+			writeError(w, http.StatusConflict, "invalid_state", fmt.Sprintf("payment is %s", payment.Status))
+			return
+		}
+		payment.Status = next
+		payment.UpdatedAt = now
+		s.payments.put(payment.ID, payment)
+		writeJSON(w, http.StatusOK, payment)
+
+	default:
+		notFound(w, r)
+	}
+}
+
+func (s *Server) serveConnections(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	now := time.Now().UTC()
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		var connections []reevit.Connection
+		for _, connection := range s.connections.list() {
+			if matches(query, "provider", connection.Provider) && matches(query, "mode", connection.Mode) &&
+				matches(query, "status", connection.Status) {
+				connections = append(connections, connection)
+			}
+		}
+		writeJSON(w, http.StatusOK, paginate(connections, query))
+
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var req reevit.ConnectionRequest
+		if !decode(w, body, &req) {
+			return
+		}
+		if req.Provider == "" {
+			writeValidationError(w, "provider", "provider is required")
+			return
+		}
+		connection := reevit.Connection{
+			ID:           s.newID("conn"),
+			Provider:     req.Provider,
+			Mode:         req.Mode,
+			Status:       "active",
+			Capabilities: req.Capabilities,
+			RoutingHints: req.RoutingHints,
+			Labels:       req.Labels,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		s.connections.put(connection.ID, connection)
+		writeJSON(w, http.StatusCreated, connection)
+
+	case len(segments) >= 1:
+		connection, ok := s.connections.get(segments[0])
+		if !ok {
+			notFound(w, r)
+			return
+		}
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, connection)
+			return
+		case len(segments) == 1 && r.Method == http.MethodDelete:
+			s.connections.remove(connection.ID)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case len(segments) == 1 && r.Method == http.MethodPatch:
+			var req reevit.ConnectionUpdateRequest
+			if !decode(w, body, &req) {
+				return
+			}
+			if req.Mode != "" {
+				connection.Mode = req.Mode
+			}
+			if req.Capabilities != nil {
+				connection.Capabilities = req.Capabilities.Apply(connection.Capabilities)
+			}
+			if req.RoutingHints != nil {
+				connection.RoutingHints = req.RoutingHints
+			}
+			if req.Labels != nil {
+				connection.Labels = req.Labels
+			}
+		case len(segments) == 2 && segments[1] == "status" && r.Method == http.MethodPatch:
+			var req reevit.ConnectionStatusUpdate
+			if !decode(w, body, &req) {
+				return
+			}
+			connection.Status = req.Status
+		case len(segments) == 2 && segments[1] == "labels" && r.Method == http.MethodPatch:
+			var req reevit.ConnectionLabelsUpdate
+			if !decode(w, body, &req) {
+				return
+			}
+			connection.Labels = req.Labels
+		default:
+			notFound(w, r)
+			return
+		}
+		connection.UpdatedAt = now
+		s.connections.put(connection.ID, connection)
+		writeJSON(w, http.StatusOK, connection)
+
+	default:
+		notFound(w, r)
+	}
+}
+
+func (s *Server) serveSubscriptions(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	now := time.Now().UTC()
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		var subscriptions []reevit.Subscription
+		for _, subscription := range s.subscriptions.list() {
+			if matches(query, "status", subscription.Status) && matches(query, "customer_id", subscription.CustomerID) &&
+				matches(query, "plan_id", subscription.PlanID) {
+				subscriptions = append(subscriptions, subscription)
+			}
+		}
+		writeJSON(w, http.StatusOK, paginate(subscriptions, query))
+
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		var req reevit.SubscriptionRequest
+		if !decode(w, body, &req) {
+			return
+		}
+		if req.CustomerID == "" {
+			writeValidationError(w, "customer_id", "customer_id is required")
+			return
+		}
+		subscription := reevit.Subscription{
+			ID:            s.newID("sub"),
+			OrgID:         requestOrg(r),
+			CustomerID:    req.CustomerID,
+			PlanID:        req.PlanID,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			Method:        req.Method,
+			Interval:      req.Interval,
+			Status:        reevit.SubscriptionStatusActive,
+			NextRenewalAt: nextRenewal(now, req.Interval),
+			Metadata:      req.Metadata,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		s.subscriptions.put(subscription.ID, subscription)
+		writeJSON(w, http.StatusCreated, subscription)
+
+	case len(segments) >= 1:
+		subscription, ok := s.subscriptions.get(segments[0])
+		if !ok {
+			notFound(w, r)
+			return
+		}
+		switch {
+		case len(segments) == 1 && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, subscription)
+			return
+		case len(segments) == 1 && r.Method == http.MethodPatch:
+			var req reevit.SubscriptionUpdateRequest
+			if !decode(w, body, &req) {
+				return
+			}
+			if req.PlanID != "" {
+				subscription.PlanID = req.PlanID
+			}
+			if req.Method != "" {
+				subscription.Method = req.Method
+			}
+			if req.Interval != "" {
+				subscription.Interval = req.Interval
+			}
+			if req.Metadata != nil {
+				subscription.Metadata = req.Metadata
+			}
+		case len(segments) == 2 && segments[1] == "cancel" && r.Method == http.MethodPost:
+			if subscription.Status == reevit.SubscriptionStatusCanceled {
+				writeError(w, http.StatusConflict, "invalid_state", "subscription is already canceled")
+				return
+			}
+			subscription.Status = reevit.SubscriptionStatusCanceled
+		case len(segments) == 2 && segments[1] == "resume" && r.Method == http.MethodPost:
+			if subscription.Status == reevit.SubscriptionStatusCanceled {
+				writeError(w, http.StatusConflict, "invalid_state", "canceled subscriptions cannot be resumed")
+				return
+			}
+			subscription.Status = reevit.SubscriptionStatusActive
+		default:
+			notFound(w, r)
+			return
+		}
+		subscription.UpdatedAt = now
+		s.subscriptions.put(subscription.ID, subscription)
+		writeJSON(w, http.StatusOK, subscription)
+
+	default:
+		notFound(w, r)
+	}
+}
+
+// collection keeps fixtures in insertion order so that list responses are stable.
+type collection[T any] struct {
+	items map[string]T
+	order []string
+}
+
+func newCollection[T any]() collection[T] {
+	return collection[T]{items: make(map[string]T)}
+}
+
+func (c *collection[T]) put(id string, item T) {
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = item
+}
+
+func (c *collection[T]) get(id string) (T, bool) {
+	item, ok := c.items[id]
+	return item, ok
+}
+
+func (c *collection[T]) remove(id string) {
+	delete(c.items, id)
+	for i, existing := range c.order {
+		if existing == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *collection[T]) list() []T {
+	items := make([]T, 0, len(c.order))
+	for _, id := range c.order {
+		items = append(items, c.items[id])
+	}
+	return items
+}
+
+func summarize(payment reevit.Payment) reevit.PaymentSummary {
+	return reevit.PaymentSummary{
+		ID:           payment.ID,
+		ConnectionID: payment.ConnectionID,
+		Provider:     payment.Provider,
+		Method:       payment.Method,
+		Status:       payment.Status,
+		Amount:       payment.Amount,
+		Currency:     payment.Currency,
+		FeeAmount:    payment.FeeAmount,
+		FeeCurrency:  payment.FeeCurrency,
+		NetAmount:    payment.NetAmount,
+		CustomerID:   payment.CustomerID,
+		Metadata:     payment.Metadata,
+		Reference:    payment.Reference,
+		CreatedAt:    payment.CreatedAt,
+		ExpiresAt:    payment.ExpiresAt,
+	}
+}
+
+// expiresBefore applies the expires_before filter, which selects open intents only.
+func expiresBefore(query url.Values, payment reevit.Payment) bool {
+	value := query.Get("expires_before")
+	if value == "" {
+		return true
+	}
+	before, err := time.Parse(time.RFC3339, value)
+	return err == nil && payment.ExpiresAt != nil && payment.ExpiresAt.Before(before) && !payment.Status.IsTerminal()
+}
+
+func nextRenewal(now time.Time, interval reevit.Interval) time.Time {
+	if interval == reevit.IntervalYearly {
+		return now.AddDate(1, 0, 0)
+	}
+	return now.AddDate(0, 1, 0)
+}
+
+func matches[T ~string](query url.Values, key string, value T) bool {
+	filter := query.Get(key)
+	return filter == "" || filter == string(value)
+}
+
+// paginate applies the limit and offset query parameters. It never returns nil so that
+// empty pages encode as [].
+func paginate[T any](items []T, query url.Values) []T {
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return append([]T{}, items...)
+}
+
+func decode(w http.ResponseWriter, body []byte, v interface{}) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// requestOrg returns the organization a request acts for: the connected organization of
+// WithOrgOverride, or the client's own.
+func requestOrg(r *http.Request) string {
+	if org := r.Header.Get("Reevit-Account"); org != "" {
+		return org
+	}
+	return r.Header.Get("X-Org-Id")
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+}
+
+func writeValidationError(w http.ResponseWriter, field, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"code":    reevit.ErrorCodeValidation,
+		"message": message,
+		"errors":  []reevit.FieldError{{Field: field, Code: "invalid", Message: message}},
+	})
+}
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, reevit.ErrorCodeNotFound, fmt.Sprintf("reevittest: no %s %s", r.Method, r.URL.Path))
+}
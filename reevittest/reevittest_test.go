@@ -0,0 +1,156 @@
+package reevittest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	payment, err := client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Amount: 5000, Currency: "ghs", Method: reevit.MethodMobileMoney, Reference: "order_1"})
+	require.NoError(t, err)
+	require.Equal(t, reevit.PaymentStatusPending, payment.Status)
+	require.Equal(t, "GHS", payment.Currency)
+
+	payment, err = client.Payments.Confirm(ctx, payment.ID)
+	require.NoError(t, err)
+	require.Equal(t, reevit.PaymentStatusSucceeded, payment.Status)
+
+	_, err = client.Payments.Cancel(ctx, payment.ID)
+	var conflict *reevit.ConflictError
+	require.ErrorAs(t, err, &conflict)
+
+	payments, err := client.Payments.List(ctx, &reevit.PaymentListOptions{Reference: "order_1"})
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+
+	_, err = client.Payments.Get(ctx, "pay_missing")
+	require.True(t, reevit.IsNotFound(err))
+
+	// Rejected by client-side validation, so it never reaches the server.
+	_, err = client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Currency: "GHS"})
+	var validation *reevit.ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.FieldErrors("amount"), 1)
+
+	requests := server.Requests()
+	require.Len(t, requests, 5)
+	require.Equal(t, "/v1/payments/intents", requests[0].Path)
+	require.JSONEq(t, `{"amount":5000,"currency":"ghs","method":"momo","country":"","reference":"order_1"}`, string(requests[0].Body))
+}
+
+func TestFixturesAndFailures(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client(reevit.WithMaxRetries(1))
+	ctx := context.Background()
+
+	connection := server.AddConnection(reevit.Connection{Provider: reevit.ProviderPaystack, Mode: "test"})
+	subscription := server.AddSubscription(reevit.Subscription{CustomerID: "cus_1", PlanID: "plan_1"})
+
+	server.InjectFailure(Failure{Method: http.MethodGet, Path: "/v1/connections/*", Status: http.StatusTooManyRequests, RetryAfter: "0", Times: 1})
+	fetched, err := client.Connections.Get(ctx, connection.ID)
+	require.NoError(t, err)
+	require.Equal(t, reevit.ProviderPaystack, fetched.Provider)
+
+	server.InjectFailure(Failure{Path: "/v1/subscriptions/*/cancel", Status: http.StatusBadGateway})
+	_, err = client.Subscriptions.Cancel(ctx, subscription.ID)
+	var serverErr *reevit.ServerError
+	require.True(t, errors.As(err, &serverErr))
+
+	stored, ok := server.Subscription(subscription.ID)
+	require.True(t, ok)
+	require.Equal(t, reevit.SubscriptionStatusActive, stored.Status)
+
+	require.NoError(t, client.Connections.Delete(ctx, connection.ID))
+	_, ok = server.Connection(connection.ID)
+	require.False(t, ok)
+}
+
+func TestPaymentsAutoPaging(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	var created []string
+	for i := 0; i < 3; i++ {
+		payment, err := client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Amount: 5000, Currency: "GHS", Method: reevit.MethodCard})
+		require.NoError(t, err)
+		created = append(created, payment.ID)
+	}
+
+	for _, options := range []reevit.PaymentListOptions{{Limit: 2}, {Limit: 2, Cursor: created[0]}} {
+		iter := client.Payments.ListAutoPaging(ctx, options)
+		var ids []string
+		for iter.Next() {
+			ids = append(ids, iter.Current().ID)
+		}
+		require.NoError(t, iter.Err())
+		if options.Cursor == "" {
+			require.ElementsMatch(t, created, ids)
+		} else {
+			require.Len(t, ids, 2)
+		}
+	}
+}
+
+func TestExpiringIntents(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	soon, err := client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Amount: 5000, Currency: "GHS", AutoCancelAfter: 10 * time.Minute})
+	require.NoError(t, err)
+	require.NotNil(t, soon.ExpiresAt)
+	later := time.Now().Add(2 * time.Hour)
+	_, err = client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Amount: 5000, Currency: "GHS", ExpiresAt: &later})
+	require.NoError(t, err)
+	paid, err := client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Amount: 5000, Currency: "GHS", AutoCancelAfter: time.Minute})
+	require.NoError(t, err)
+	_, err = client.Payments.Confirm(ctx, paid.ID)
+	require.NoError(t, err)
+
+	expiring, err := client.Payments.ListExpiring(ctx, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, expiring, 1)
+	require.Equal(t, soon.ID, expiring[0].ID)
+	require.WithinDuration(t, *soon.ExpiresAt, *expiring[0].ExpiresAt, time.Second)
+
+	past := time.Now().Add(-time.Minute)
+	_, err = client.Payments.CreateIntent(ctx, &reevit.PaymentIntentRequest{Amount: 5000, Currency: "GHS", ExpiresAt: &past})
+	var validationErr *reevit.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestBearerAuthAndOrgOverride(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := reevit.NewClient("", "", reevit.WithBaseURL(server.URL),
+		reevit.WithOAuthToken(reevit.TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "at_test", nil
+		})),
+	)
+	ctx := context.Background()
+
+	subscription, err := client.Subscriptions.Create(ctx, &reevit.SubscriptionRequest{CustomerID: "cus_1", PlanID: "plan_1"}, reevit.WithOrgOverride("org_connected"))
+	require.NoError(t, err)
+	require.Equal(t, "org_connected", subscription.OrgID)
+
+	unauthenticated := reevit.NewClient("", "org_test", reevit.WithBaseURL(server.URL))
+	_, err = unauthenticated.Payments.Get(ctx, "pay_1")
+	var apiErr *reevit.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
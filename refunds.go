@@ -0,0 +1,126 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RefundsService handles communication with the refund related methods of the Reevit API.
+type RefundsService service
+
+// Refund reason codes accepted by the API.
+const (
+	RefundReasonDuplicate           = "duplicate"
+	RefundReasonFraudulent          = "fraudulent"
+	RefundReasonRequestedByCustomer = "requested_by_customer"
+	RefundReasonOther               = "other"
+)
+
+// RefundRequest represents a payment refund request.
+// Leave Amount at zero to refund the full remaining amount of the payment.
+type RefundRequest struct {
+	Amount     int64                  `json:"amount,omitempty"`
+	Reason     string                 `json:"reason,omitempty"`
+	ReasonCode string                 `json:"reason_code,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Refund represents a refund record returned by the API.
+type Refund struct {
+	ID         string                 `json:"id"`
+	PaymentID  string                 `json:"payment_id"`
+	Status     string                 `json:"status"`
+	Amount     int64                  `json:"amount"`
+	Currency   string                 `json:"currency"`
+	Reason     string                 `json:"reason"`
+	ReasonCode string                 `json:"reason_code"`
+	Metadata   map[string]interface{} `json:"metadata"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
+}
+
+// RefundSummary represents the refund state embedded in a payment.
+type RefundSummary struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	ReasonCode string    `json:"reason_code"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Create creates a full or partial refund for a payment.
+//
+// API Docs: POST /v1/payments/{id}/refund
+func (s *RefundsService) Create(ctx context.Context, paymentID string, req *RefundRequest, opts ...RequestOption) (*Refund, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/payments/%s/refund", paymentID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var refund Refund
+	if err := s.client.do(httpRequest, &refund); err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// Get retrieves a refund by ID.
+//
+// API Docs: GET /v1/refunds/{id}
+func (s *RefundsService) Get(ctx context.Context, refundID string, opts ...RequestOption) (*Refund, error) {
+	if err := checkID(IDKindRefund, refundID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/refunds/%s", refundID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var refund Refund
+	if err := s.client.do(httpRequest, &refund); err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// List returns the refunds created for a payment.
+//
+// API Docs: GET /v1/payments/{id}/refunds
+func (s *RefundsService) List(ctx context.Context, paymentID string, options *PaginationOptions, opts ...RequestOption) ([]Refund, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/payments/%s/refunds", paymentID), values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[Refund](s.client, raw, "refunds")
+}
@@ -0,0 +1,146 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultReportPollInterval is how often WaitUntilReady re-checks a report when no
+// interval is given.
+const DefaultReportPollInterval = 5 * time.Second
+
+// ErrReportFailed is returned by WaitUntilReady when report generation failed;
+// Report.FailureReason says why.
+var ErrReportFailed = errors.New("reevit: report generation failed")
+
+// ReportsService handles reports generated asynchronously by Reevit, such as payment
+// and settlement exports covering a date range.
+type ReportsService service
+
+// Report statuses.
+const (
+	ReportPending    = "pending"
+	ReportProcessing = "processing"
+	ReportCompleted  = "completed"
+	ReportFailed     = "failed"
+)
+
+// Report represents a report resource.
+type Report struct {
+	ID string `json:"id"`
+	// Type is the data the report covers, e.g. "payments", "settlements" or "transfers".
+	Type   string `json:"type"`
+	Format string `json:"format"`
+	Status string `json:"status"`
+	// RowsProcessed and RowsTotal tell how far generation has come; RowsTotal is zero
+	// until Reevit has counted the rows.
+	RowsProcessed int64 `json:"rows_processed"`
+	RowsTotal     int64 `json:"rows_total"`
+	// FileID identifies the generated file once the report is completed; fetch it with
+	// Files.Download.
+	FileID        string     `json:"file_id"`
+	FailureReason string     `json:"failure_reason"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
+
+// ReportRequest represents a report create payload.
+type ReportRequest struct {
+	Type string `json:"type"`
+	// Format is "csv" (the default) or "xlsx".
+	Format   string     `json:"format,omitempty"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+	Currency string     `json:"currency,omitempty"`
+}
+
+// ReportWaitOptions configures WaitUntilReady.
+type ReportWaitOptions struct {
+	// PollInterval is the delay between re-checks. It defaults to
+	// DefaultReportPollInterval.
+	PollInterval time.Duration
+	// OnProgress, if set, is called after every check of the report. Stage is the report
+	// status, Processed the rows generated so far and Total the rows expected.
+	OnProgress ProgressFunc
+}
+
+// Create starts generating a report. The returned report is pending; wait for it with
+// WaitUntilReady.
+//
+// API Docs: POST /v1/reports
+func (s *ReportsService) Create(ctx context.Context, req *ReportRequest, opts ...RequestOption) (*Report, error) {
+	if req == nil || req.Type == "" {
+		return nil, errors.New("reevit: report type is required")
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/reports", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	if err := s.client.do(httpRequest, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// Get fetches a report by ID.
+//
+// API Docs: GET /v1/reports/{id}
+func (s *ReportsService) Get(ctx context.Context, reportID string, opts ...RequestOption) (*Report, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/reports/%s", reportID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	if err := s.client.do(httpRequest, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// WaitUntilReady polls a report until it is completed and returns it. It returns an error
+// wrapping ErrReportFailed if generation fails, and the last report fetched with the
+// context's error when ctx is done.
+func (s *ReportsService) WaitUntilReady(ctx context.Context, reportID string, options *ReportWaitOptions, opts ...RequestOption) (*Report, error) {
+	interval := DefaultReportPollInterval
+	var onProgress ProgressFunc
+	if options != nil {
+		if options.PollInterval > 0 {
+			interval = options.PollInterval
+		}
+		onProgress = options.OnProgress
+	}
+
+	tracker := NewProgressTracker(0, 0)
+	var report *Report
+	for {
+		latest, err := s.Get(ctx, reportID, opts...)
+		if err != nil {
+			return report, err
+		}
+		report = latest
+		if onProgress != nil {
+			tracker.SetTotal(report.RowsTotal)
+			onProgress(tracker.Update(report.Status, report.RowsProcessed))
+		}
+
+		switch report.Status {
+		case ReportCompleted:
+			return report, nil
+		case ReportFailed:
+			return report, fmt.Errorf("%w: %s: %s", ErrReportFailed, report.ID, report.FailureReason)
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return report, err
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Resources managed declaratively (connections, routing rules, webhook endpoints) follow
+// the same contract so that infrastructure tools can be built on the SDK:
+//
+//   - IDs are assigned by Reevit on Create and never change on Update.
+//   - Create accepts WithIdempotencyKey; replaying a create with the same key returns the
+//     original resource instead of a duplicate.
+//   - Get returns the full server-side state, so comparing it with the desired state
+//     detects drift. A deleted resource yields an error for which IsNotFound is true.
+//   - ImportByID adopts a resource created outside the tool.
+
+// importByID fetches a resource by ID for adoption, rejecting blank IDs up front and
+// naming the resource kind when it does not exist.
+func importByID[T any](ctx context.Context, kind, id string, get func(context.Context, string, ...RequestOption) (*T, error), opts ...RequestOption) (*T, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("reevit: %s ID is required", kind)
+	}
+	resource, err := get(ctx, id, opts...)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, fmt.Errorf("reevit: %s %q does not exist: %w", kind, id, err)
+		}
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,83 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Response holds metadata about the HTTP response to an API call.
+type Response struct {
+	StatusCode int
+	// RequestID is the X-Request-ID assigned by Reevit; quote it when contacting support.
+	RequestID string
+	RateLimit RateLimit
+	Header    http.Header
+}
+
+// RateLimit describes the rate-limit state reported by the API.
+// Fields are zero when the response did not carry the corresponding header.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+type responseCaptureKey struct{}
+
+type responseCapture struct {
+	mu       sync.Mutex
+	response *Response
+}
+
+// WithResponseCapture returns a context that records the metadata of the API responses
+// received while it is in use. Read it back with ResponseFromContext:
+//
+//	ctx = reevit.WithResponseCapture(ctx)
+//	payment, err := client.Payments.Get(ctx, paymentID)
+//	log.Printf("request %s", reevit.ResponseFromContext(ctx).RequestID)
+//
+// Error responses are captured too. When the context is shared by several calls, the
+// most recent response wins.
+func WithResponseCapture(ctx context.Context) context.Context {
+	return context.WithValue(ctx, responseCaptureKey{}, &responseCapture{})
+}
+
+// ResponseFromContext returns the last response captured by a context created with
+// WithResponseCapture, or nil when no response has been received.
+func ResponseFromContext(ctx context.Context) *Response {
+	capture, ok := ctx.Value(responseCaptureKey{}).(*responseCapture)
+	if !ok {
+		return nil
+	}
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	return capture.response
+}
+
+func captureResponse(ctx context.Context, resp *http.Response) {
+	capture, ok := ctx.Value(responseCaptureKey{}).(*responseCapture)
+	if !ok {
+		return
+	}
+	response := newResponse(resp)
+	capture.mu.Lock()
+	capture.response = response
+	capture.mu.Unlock()
+}
+
+func newResponse(resp *http.Response) *Response {
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		Header:     resp.Header.Clone(),
+	}
+	response.RateLimit.Limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	response.RateLimit.Remaining, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+		response.RateLimit.Reset = time.Unix(reset, 0)
+	}
+	return response
+}
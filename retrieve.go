@@ -0,0 +1,57 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Object is a resource returned by Client.Retrieve. Its dynamic type is one of *Payment,
+// *Subscription, *Connection or *Refund; use a type switch to handle it:
+//
+//	switch object := object.(type) {
+//	case *reevit.Payment:
+//		fmt.Println("payment", object.Status)
+//	case *reevit.Refund:
+//		fmt.Println("refund of", object.PaymentID)
+//	}
+type Object interface {
+	reevitObject()
+}
+
+func (*Payment) reevitObject()      {}
+func (*Subscription) reevitObject() {}
+func (*Connection) reevitObject()   {}
+func (*Refund) reevitObject()       {}
+
+// Retrieve fetches the resource identified by id, inferring its type from the prefix:
+// pay_ for payments, sub_ for subscriptions, conn_ for connections and rfnd_ for refunds.
+// It is meant for support and debugging tools where the ID is pasted in by a person;
+// code that knows what it is fetching should call the service directly.
+func (c *Client) Retrieve(ctx context.Context, id string, opts ...RequestOption) (Object, error) {
+	id = strings.TrimSpace(id)
+	kind, _, err := ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case IDKindPayment:
+		return retrieve(c.Payments.Get(ctx, id, opts...))
+	case IDKindSubscription:
+		return retrieve(c.Subscriptions.Get(ctx, id, opts...))
+	case IDKindConnection:
+		return retrieve(c.Connections.Get(ctx, id, opts...))
+	case IDKindRefund:
+		return retrieve(c.Refunds.Get(ctx, id, opts...))
+	}
+	return nil, fmt.Errorf("%w: %s IDs cannot be retrieved", ErrUnknownIDPrefix, kind)
+}
+
+// retrieve converts a typed result to an Object without turning a nil pointer into a
+// non-nil interface.
+func retrieve[T Object](object T, err error) (Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
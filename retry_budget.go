@@ -0,0 +1,136 @@
+package reevit
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// RetryBudget caps how many retries the client may issue, protecting the platform from
+// retry storms during incidents. Counters reset at the start of every Window.
+type RetryBudget struct {
+	// Ratio is the fraction of requests in the window that may be retries, e.g. 0.2.
+	Ratio float64
+	// MinRetries is the number of retries allowed per window regardless of Ratio, so
+	// low-traffic clients can still retry.
+	MinRetries int
+	// PerEndpoint caps retries per endpoint (method and route) per window. Zero disables the cap.
+	PerEndpoint int
+	// Window is the accounting period. Zero means 10 seconds.
+	Window time.Duration
+}
+
+// DefaultRetryBudget allows up to 20% of requests to be retries, with a floor of ten
+// retries every ten seconds.
+var DefaultRetryBudget = RetryBudget{Ratio: 0.2, MinRetries: 10, Window: 10 * time.Second}
+
+// WithRetryBudget bounds the retries enabled by WithMaxRetries. When the budget is spent
+// the failed response is returned wrapped in a *RetryBudgetExhaustedError.
+func WithRetryBudget(budget RetryBudget) Option {
+	return func(c *Client) {
+		if budget.Window <= 0 {
+			budget.Window = 10 * time.Second
+		}
+		c.retryBudget = &retryBudget{config: budget}
+	}
+}
+
+// RetryBudgetExhaustedError is returned when a request could have been retried but the
+// retry budget did not allow it. It wraps the error of the last attempt.
+type RetryBudgetExhaustedError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *RetryBudgetExhaustedError) Error() string {
+	return fmt.Sprintf("reevit: retry budget exhausted for %s: %v", e.Endpoint, e.Err)
+}
+
+func (e *RetryBudgetExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// RetryStats are cumulative retry counters of a client.
+type RetryStats struct {
+	Requests        uint64
+	Retries         uint64
+	BudgetExhausted uint64
+}
+
+// RetryStats returns the client's retry counters, for export to a metrics system.
+func (c *Client) RetryStats() RetryStats {
+	return RetryStats{
+		Requests:        c.retryStats.requests.Load(),
+		Retries:         c.retryStats.retries.Load(),
+		BudgetExhausted: c.retryStats.budgetExhausted.Load(),
+	}
+}
+
+type retryCounters struct {
+	requests        atomic.Uint64
+	retries         atomic.Uint64
+	budgetExhausted atomic.Uint64
+}
+
+type retryBudget struct {
+	config RetryBudget
+
+	mu          sync.Mutex
+	windowStart time.Time
+	requests    int
+	retries     int
+	perEndpoint map[string]int
+}
+
+func (b *retryBudget) rollover(now time.Time) {
+	if now.Sub(b.windowStart) < b.config.Window {
+		return
+	}
+	b.windowStart = now
+	b.requests = 0
+	b.retries = 0
+	b.perEndpoint = make(map[string]int)
+}
+
+func (b *retryBudget) recordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollover(time.Now())
+	b.requests++
+}
+
+// allowRetry reserves a retry for endpoint if the budget permits it.
+func (b *retryBudget) allowRetry(endpoint string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rollover(time.Now())
+	if b.retries >= b.config.MinRetries && float64(b.retries+1) > b.config.Ratio*float64(b.requests) {
+		return false
+	}
+	if b.config.PerEndpoint > 0 && b.perEndpoint[endpoint] >= b.config.PerEndpoint {
+		return false
+	}
+	b.retries++
+	b.perEndpoint[endpoint]++
+	return true
+}
+
+// endpointKey identifies the route of a request, replacing ID-like path segments so
+// that every payment shares the budget of "POST /v1/payments/{id}/confirm".
+func endpointKey(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if strings.IndexFunc(segment, unicode.IsDigit) >= 0 && !isVersionSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+func isVersionSegment(segment string) bool {
+	return len(segment) > 1 && segment[0] == 'v' && strings.TrimLeft(segment[1:], "0123456789") == ""
+}
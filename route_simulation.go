@@ -0,0 +1,56 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+)
+
+// RouteSimulation is the routing decision the router would make for an intent.
+type RouteSimulation struct {
+	// Candidates lists the eligible connections in the order the router would try them.
+	Candidates []RouteCandidate `json:"candidates"`
+	// Excluded lists the connections ruled out, with the reason in Reasons.
+	Excluded []RouteCandidate `json:"excluded"`
+	// MatchedRules lists the IDs of the routing rules that applied, in priority order.
+	MatchedRules []string `json:"matched_rules"`
+}
+
+// RouteCandidate is a connection considered by the router.
+type RouteCandidate struct {
+	ConnectionID string   `json:"connection_id"`
+	Provider     Provider `json:"provider"`
+	Labels       []string `json:"labels"`
+	// Score ranks eligible connections; higher scores are tried first.
+	Score float64 `json:"score"`
+	// Reasons explains the score or the exclusion, e.g. "method_bias:card=paystack" or
+	// "excluded:currency_not_supported".
+	Reasons []string `json:"reasons"`
+}
+
+// SimulateRoute returns the connections the router would try for req, in order, with
+// their scores and the policy reasons behind them, without creating an intent. It is
+// meant for debugging routing hints, labels and rules; the live decision can still differ
+// as connection health changes.
+//
+// API Docs: POST /v1/payments/route-simulations
+func (s *PaymentsService) SimulateRoute(ctx context.Context, req *PaymentIntentRequest, opts ...RequestOption) (*RouteSimulation, error) {
+	req, err := s.client.withIntentDefaults(ctx, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/payments/route-simulations", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var simulation RouteSimulation
+	if err := s.client.do(httpRequest, &simulation); err != nil {
+		return nil, err
+	}
+
+	return &simulation, nil
+}
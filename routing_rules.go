@@ -2,7 +2,6 @@ package reevit
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 )
@@ -44,33 +43,29 @@ type RoutingRuleUpdateRequest struct {
 }
 
 // List returns routing rules for the current org.
-func (s *RoutingRulesService) List(ctx context.Context) ([]RoutingRule, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, "/v1/routing-rules", nil)
+func (s *RoutingRulesService) List(ctx context.Context, opts ...RequestOption) ([]RoutingRule, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/routing-rules", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[RoutingRule](raw, "rules")
+	return decodeArrayResponse[RoutingRule](s.client, raw, "rules")
 }
 
 // Create creates a routing rule.
 func (s *RoutingRulesService) Create(ctx context.Context, req *RoutingRuleCreateRequest, opts ...RequestOption) (*RoutingRule, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/routing-rules", req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/routing-rules", req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var rule RoutingRule
-	if err := s.client.do(ctx, httpRequest, &rule); err != nil {
+	if err := s.client.do(httpRequest, &rule); err != nil {
 		return nil, err
 	}
 
@@ -78,14 +73,14 @@ func (s *RoutingRulesService) Create(ctx context.Context, req *RoutingRuleCreate
 }
 
 // Get fetches a routing rule by ID.
-func (s *RoutingRulesService) Get(ctx context.Context, ruleID string) (*RoutingRule, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/routing-rules/%s", ruleID), nil)
+func (s *RoutingRulesService) Get(ctx context.Context, ruleID string, opts ...RequestOption) (*RoutingRule, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/routing-rules/%s", ruleID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var rule RoutingRule
-	if err := s.client.do(ctx, httpRequest, &rule); err != nil {
+	if err := s.client.do(httpRequest, &rule); err != nil {
 		return nil, err
 	}
 
@@ -94,33 +89,31 @@ func (s *RoutingRulesService) Get(ctx context.Context, ruleID string) (*RoutingR
 
 // Update updates a routing rule.
 func (s *RoutingRulesService) Update(ctx context.Context, ruleID string, req *RoutingRuleUpdateRequest, opts ...RequestOption) (*RoutingRule, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/routing-rules/%s", ruleID), req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/routing-rules/%s", ruleID), req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var rule RoutingRule
-	if err := s.client.do(ctx, httpRequest, &rule); err != nil {
+	if err := s.client.do(httpRequest, &rule); err != nil {
 		return nil, err
 	}
 
 	return &rule, nil
 }
 
+// ImportByID fetches an existing routing rule so it can be adopted by an external state
+// manager such as a Terraform provider.
+func (s *RoutingRulesService) ImportByID(ctx context.Context, ruleID string, opts ...RequestOption) (*RoutingRule, error) {
+	return importByID(ctx, "routing rule", ruleID, s.Get, opts...)
+}
+
 // Delete removes a routing rule.
 func (s *RoutingRulesService) Delete(ctx context.Context, ruleID string, opts ...RequestOption) error {
-	httpRequest, err := s.client.newRequest(http.MethodDelete, fmt.Sprintf("/v1/routing-rules/%s", ruleID), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/routing-rules/%s", ruleID), nil, opts...)
 	if err != nil {
 		return err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
-	return s.client.do(ctx, httpRequest, nil)
+	return s.client.do(httpRequest, nil)
 }
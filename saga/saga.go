@@ -0,0 +1,270 @@
+// Package saga runs payment workflows as a sequence of steps with compensating actions,
+// so that a failure part way through undoes what the earlier steps did: cancelling the
+// intent when the order cannot be saved, refunding the payment when fulfillment fails.
+//
+//	s := &saga.Saga{
+//		ID:  "order:" + orderID,
+//		Log: log, // durable, e.g. a table in the order database
+//		Steps: []saga.Step{{
+//			Name: "intent",
+//			Action: func(ctx context.Context, state *saga.State) error {
+//				payment, err := client.Payments.CreateIntent(ctx, req, reevit.WithIdempotencyKey(state.IdempotencyKey()))
+//				if err != nil {
+//					return err
+//				}
+//				state.Set("payment_id", payment.ID)
+//				return nil
+//			},
+//			Compensate: func(ctx context.Context, state *saga.State) error {
+//				_, err := client.Payments.Cancel(ctx, state.Get("payment_id"))
+//				return err
+//			},
+//		}, {
+//			Name:   "order",
+//			Action: func(ctx context.Context, state *saga.State) error { return orders.Save(ctx, orderID, state.Get("payment_id")) },
+//		}},
+//	}
+//	err := s.Run(ctx)
+//
+// Every completed step, the values it set and every compensation are written to the Log
+// before the saga moves on, so a process that crashes mid-saga can call Run again with the
+// same ID: completed steps are skipped and an interrupted compensation is finished.
+// Actions and compensations may therefore run more than once and must be idempotent;
+// State.IdempotencyKey gives each step a stable key for its API calls.
+package saga
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status is the state of a saga in its Log.
+type Status string
+
+// Saga statuses.
+const (
+	StatusRunning      Status = "running"
+	StatusCompleted    Status = "completed"
+	StatusCompensating Status = "compensating"
+	StatusCompensated  Status = "compensated"
+)
+
+// ErrCompensated is returned by Run for a saga that failed and was compensated on an
+// earlier run.
+var ErrCompensated = errors.New("saga: already compensated")
+
+// Step is one action of a saga and the compensation that undoes it.
+type Step struct {
+	// Name identifies the step in the Log; it must be unique within the saga and stable
+	// across deploys.
+	Name   string
+	Action func(ctx context.Context, state *State) error
+	// Compensate undoes Action after a later step fails. Steps without a compensation,
+	// such as the final one, are skipped.
+	Compensate func(ctx context.Context, state *State) error
+}
+
+// Record is the durable state of a saga.
+type Record struct {
+	Status Status `json:"status"`
+	// Completed lists the steps whose action succeeded, in order.
+	Completed []string `json:"completed"`
+	// Compensated lists the steps whose compensation succeeded.
+	Compensated []string `json:"compensated,omitempty"`
+	// Values are the values set by the steps through State.Set.
+	Values map[string]string `json:"values,omitempty"`
+	// Failure is the error of the step that made the saga compensate.
+	Failure   string    `json:"failure,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Log persists sagas between runs.
+type Log interface {
+	// Load returns the record of saga, or nil when the saga has not run before.
+	Load(ctx context.Context, saga string) (*Record, error)
+	Save(ctx context.Context, saga string, record *Record) error
+}
+
+// State is passed to actions and compensations.
+type State struct {
+	saga   string
+	step   string
+	record *Record
+}
+
+// Set records a value for later steps and compensations, such as the ID of a created
+// payment. It is persisted when the step completes.
+func (s *State) Set(key, value string) {
+	if s.record.Values == nil {
+		s.record.Values = make(map[string]string)
+	}
+	s.record.Values[key] = value
+}
+
+// Get returns a value set by this or an earlier step.
+func (s *State) Get(key string) string {
+	return s.record.Values[key]
+}
+
+// IdempotencyKey returns a key derived from the saga ID and the current step, identical on
+// every run, for use with reevit.WithIdempotencyKey.
+func (s *State) IdempotencyKey() string {
+	return fmt.Sprintf("saga_%x", sha256.Sum256([]byte(s.saga+"\x00"+s.step)))
+}
+
+// Saga describes a workflow.
+type Saga struct {
+	// ID identifies the saga in the Log; reuse it to resume.
+	ID    string
+	Steps []Step
+	// Log persists progress. When nil, progress is kept in memory only and a crash
+	// loses it.
+	Log Log
+}
+
+// Run runs the steps in order, resuming from the saved record. If a step fails, the
+// completed steps are compensated in reverse order, even when ctx has been cancelled, and
+// the step's error is returned. If
+// a compensation fails too, Run stops and returns both errors; calling Run again retries
+// the remaining compensations.
+func (s *Saga) Run(ctx context.Context) error {
+	log := s.Log
+	if log == nil {
+		log = NewMemoryLog()
+	}
+	record, err := log.Load(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = &Record{Status: StatusRunning}
+	}
+	save := func(ctx context.Context) error {
+		record.UpdatedAt = time.Now().UTC()
+		return log.Save(ctx, s.ID, record)
+	}
+
+	switch record.Status {
+	case StatusCompleted:
+		return nil
+	case StatusCompensated:
+		return fmt.Errorf("%w: %s", ErrCompensated, record.Failure)
+	case StatusCompensating:
+		return s.compensate(ctx, record, save, errors.New(record.Failure))
+	}
+
+	for i, step := range s.Steps {
+		if i < len(record.Completed) {
+			if record.Completed[i] != step.Name {
+				return fmt.Errorf("saga: step %d of %q is %q, but the log recorded %q", i, s.ID, step.Name, record.Completed[i])
+			}
+			continue
+		}
+		if err := step.Action(ctx, &State{saga: s.ID, step: step.Name, record: record}); err != nil {
+			err = fmt.Errorf("saga: step %q: %w", step.Name, err)
+			record.Status = StatusCompensating
+			record.Failure = err.Error()
+			// The step may have failed because ctx was cancelled; record the failure anyway.
+			if saveErr := save(context.WithoutCancel(ctx)); saveErr != nil {
+				return errors.Join(err, saveErr)
+			}
+			return s.compensate(ctx, record, save, err)
+		}
+		record.Completed = append(record.Completed, step.Name)
+		if err := save(ctx); err != nil {
+			return err
+		}
+	}
+	record.Status = StatusCompleted
+	return save(ctx)
+}
+
+// compensate undoes the completed steps in reverse order and returns cause. Compensations
+// run with a context that keeps ctx's values but not its cancellation, so that a saga
+// aborted by a cancelled context is still rolled back.
+func (s *Saga) compensate(ctx context.Context, record *Record, save func(context.Context) error, cause error) error {
+	ctx = context.WithoutCancel(ctx)
+	compensated := make(map[string]bool, len(record.Compensated))
+	for _, name := range record.Compensated {
+		compensated[name] = true
+	}
+	for i := len(record.Completed) - 1; i >= 0; i-- {
+		name := record.Completed[i]
+		step, ok := s.step(name)
+		if !ok {
+			return errors.Join(cause, fmt.Errorf("saga: step %q of %q is no longer defined", name, s.ID))
+		}
+		if compensated[name] || step.Compensate == nil {
+			continue
+		}
+		if err := step.Compensate(ctx, &State{saga: s.ID, step: name, record: record}); err != nil {
+			return errors.Join(cause, fmt.Errorf("saga: compensating step %q: %w", name, err))
+		}
+		record.Compensated = append(record.Compensated, name)
+		if err := save(ctx); err != nil {
+			return errors.Join(cause, err)
+		}
+	}
+	record.Status = StatusCompensated
+	if err := save(ctx); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (s *Saga) step(name string) (Step, bool) {
+	for _, step := range s.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// MemoryLog keeps sagas in memory, for tests and sagas that need not survive a restart.
+type MemoryLog struct {
+	mu    sync.Mutex
+	sagas map[string]Record
+}
+
+// NewMemoryLog returns an empty in-memory log.
+func NewMemoryLog() *MemoryLog {
+	return &MemoryLog{sagas: make(map[string]Record)}
+}
+
+// Load implements Log.
+func (l *MemoryLog) Load(ctx context.Context, saga string) (*Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record, ok := l.sagas[saga]
+	if !ok {
+		return nil, nil
+	}
+	record.Completed = append([]string(nil), record.Completed...)
+	record.Compensated = append([]string(nil), record.Compensated...)
+	values := make(map[string]string, len(record.Values))
+	for key, value := range record.Values {
+		values[key] = value
+	}
+	record.Values = values
+	return &record, nil
+}
+
+// Save implements Log.
+func (l *MemoryLog) Save(ctx context.Context, saga string, record *Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	saved := *record
+	saved.Completed = append([]string(nil), record.Completed...)
+	saved.Compensated = append([]string(nil), record.Compensated...)
+	saved.Values = make(map[string]string, len(record.Values))
+	for key, value := range record.Values {
+		saved.Values[key] = value
+	}
+	l.sagas[saga] = saved
+	return nil
+}
@@ -0,0 +1,124 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSagaCompensatesInReverse(t *testing.T) {
+	log := NewMemoryLog()
+	var calls []string
+	fulfillment := errors.New("out of stock")
+	refundErr := errors.New("provider unavailable")
+
+	s := &Saga{ID: "order:1", Log: log, Steps: []Step{{
+		Name: "intent",
+		Action: func(ctx context.Context, state *State) error {
+			calls = append(calls, "intent")
+			state.Set("payment_id", "pay_1")
+			return nil
+		},
+		Compensate: func(ctx context.Context, state *State) error {
+			calls = append(calls, "refund "+state.Get("payment_id"))
+			return refundErr
+		},
+	}, {
+		Name: "order",
+		Action: func(ctx context.Context, state *State) error {
+			calls = append(calls, "order")
+			return nil
+		},
+	}, {
+		Name: "fulfill",
+		Action: func(ctx context.Context, state *State) error {
+			calls = append(calls, "fulfill")
+			return fulfillment
+		},
+	}}}
+
+	err := s.Run(context.Background())
+	require.ErrorIs(t, err, fulfillment)
+	require.ErrorIs(t, err, refundErr)
+	require.Equal(t, []string{"intent", "order", "fulfill", "refund pay_1"}, calls)
+
+	record, err := log.Load(context.Background(), "order:1")
+	require.NoError(t, err)
+	require.Equal(t, StatusCompensating, record.Status)
+	require.Equal(t, []string{"intent", "order"}, record.Completed)
+	require.Equal(t, "pay_1", record.Values["payment_id"])
+
+	// A later run, e.g. after a restart, only retries the compensation.
+	refundErr = nil
+	s.Steps[0].Compensate = func(ctx context.Context, state *State) error {
+		calls = append(calls, "refund "+state.Get("payment_id"))
+		return nil
+	}
+	err = s.Run(context.Background())
+	require.EqualError(t, err, `saga: step "fulfill": out of stock`)
+	require.Equal(t, []string{"intent", "order", "fulfill", "refund pay_1", "refund pay_1"}, calls)
+
+	require.ErrorIs(t, s.Run(context.Background()), ErrCompensated)
+	require.Len(t, calls, 5)
+}
+
+func TestSagaResumesCompletedSteps(t *testing.T) {
+	log := NewMemoryLog()
+	require.NoError(t, log.Save(context.Background(), "order:2", &Record{
+		Status:    StatusRunning,
+		Completed: []string{"intent"},
+		Values:    map[string]string{"payment_id": "pay_2"},
+	}))
+
+	var keys []string
+	var paymentID string
+	s := &Saga{ID: "order:2", Log: log, Steps: []Step{{
+		Name: "intent",
+		Action: func(ctx context.Context, state *State) error {
+			t.Fatal("completed step ran again")
+			return nil
+		},
+	}, {
+		Name: "order",
+		Action: func(ctx context.Context, state *State) error {
+			keys = append(keys, state.IdempotencyKey())
+			paymentID = state.Get("payment_id")
+			return nil
+		},
+	}}}
+	require.NoError(t, s.Run(context.Background()))
+	require.Equal(t, "pay_2", paymentID)
+	require.Equal(t, (&State{saga: "order:2", step: "order"}).IdempotencyKey(), keys[0])
+	require.NotEqual(t, (&State{saga: "order:2", step: "intent"}).IdempotencyKey(), keys[0])
+
+	record, err := log.Load(context.Background(), "order:2")
+	require.NoError(t, err)
+	require.Equal(t, StatusCompleted, record.Status)
+	require.NoError(t, s.Run(context.Background()))
+}
+
+func TestSagaCompensatesAfterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var compensateErr error
+	s := &Saga{ID: "order:2", Log: NewMemoryLog(), Steps: []Step{{
+		Name:   "intent",
+		Action: func(ctx context.Context, state *State) error { return nil },
+		Compensate: func(ctx context.Context, state *State) error {
+			compensateErr = ctx.Err()
+			return nil
+		},
+	}, {
+		Name: "order",
+		Action: func(ctx context.Context, state *State) error {
+			cancel()
+			return ctx.Err()
+		},
+	}}}
+
+	err := s.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, compensateErr)
+	require.ErrorIs(t, s.Run(context.Background()), ErrCompensated)
+}
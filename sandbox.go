@@ -0,0 +1,166 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrLiveModeKey is returned by SandboxService when the client uses a live API key.
+var ErrLiveModeKey = errors.New("reevit: sandbox simulations require a test API key")
+
+// SandboxService drives the simulation endpoints of the sandbox, which force the outcome
+// of test-mode payments, inject connection outages and move subscriptions through time. The sandbox subpackage
+// wraps it in helpers for integration tests.
+type SandboxService service
+
+// Simulated payment outcomes.
+const (
+	SimulateSuccess = "success"
+	SimulateFailure = "failure"
+)
+
+// PaymentSimulation describes the outcome to force on a test-mode payment.
+type PaymentSimulation struct {
+	// Outcome is SimulateSuccess or SimulateFailure.
+	Outcome string `json:"outcome"`
+	// FailureCode is the decline code reported for SimulateFailure, e.g.
+	// "insufficient_funds". The sandbox picks a generic decline when it is empty.
+	FailureCode string `json:"failure_code,omitempty"`
+}
+
+// SimulatePayment settles a pending test-mode payment with the given outcome, as if the
+// PSP had reported it, and returns the updated payment. The usual webhooks are sent.
+//
+// API Docs: POST /v1/sandbox/payments/{id}/simulate
+func (s *SandboxService) SimulatePayment(ctx context.Context, paymentID string, simulation *PaymentSimulation, opts ...RequestOption) (*Payment, error) {
+	if err := s.requireTestKey(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/sandbox/payments/%s/simulate", paymentID), simulation, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var payment Payment
+	if err := s.client.do(httpRequest, &payment); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// AdvanceSubscriptionClock moves a test-mode subscription's clock forward to to,
+// running every renewal, retry and status change that falls due on the way.
+//
+// API Docs: POST /v1/sandbox/subscriptions/{id}/advance-clock
+func (s *SandboxService) AdvanceSubscriptionClock(ctx context.Context, subscriptionID string, to time.Time, opts ...RequestOption) (*Subscription, error) {
+	if err := s.requireTestKey(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkID(IDKindSubscription, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{"to": to.UTC().Format(time.RFC3339)}
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/sandbox/subscriptions/%s/advance-clock", subscriptionID), body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription Subscription
+	if err := s.client.do(httpRequest, &subscription); err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// Ways a test-mode connection can be made to fail with FailConnection.
+const (
+	// OutageError makes the PSP reject every charge with a server error.
+	OutageError = "error"
+	// OutageTimeout makes the PSP stop answering, so charges time out.
+	OutageTimeout = "timeout"
+)
+
+// ConnectionOutage is a failure injected into a test-mode connection.
+type ConnectionOutage struct {
+	ConnectionID string    `json:"connection_id"`
+	Mode         string    `json:"mode"`
+	EndsAt       time.Time `json:"ends_at"`
+}
+
+// FailConnection makes a test-mode connection fail in the given mode, OutageError when
+// empty, for duration, rounded up to whole minutes. Payments routed while the outage
+// lasts must fall back to another connection, which is what failover drills assert.
+//
+// API Docs: POST /v1/sandbox/connections/{id}/outage
+func (s *SandboxService) FailConnection(ctx context.Context, connectionID string, duration time.Duration, mode string, opts ...RequestOption) (*ConnectionOutage, error) {
+	if err := s.requireTestKey(ctx); err != nil {
+		return nil, err
+	}
+	if err := checkID(IDKindConnection, connectionID); err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, errors.New("reevit: outage duration must be positive")
+	}
+	if mode == "" {
+		mode = OutageError
+	}
+
+	minutes := int((duration + time.Minute - 1) / time.Minute)
+	body := map[string]interface{}{"minutes": minutes, "mode": mode}
+	opts = append([]RequestOption{withFreshIdempotencyKey()}, opts...)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/sandbox/connections/%s/outage", connectionID), body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var outage ConnectionOutage
+	if err := s.client.do(httpRequest, &outage); err != nil {
+		return nil, err
+	}
+
+	return &outage, nil
+}
+
+// RestoreConnection ends an outage injected with FailConnection before it expires.
+//
+// API Docs: DELETE /v1/sandbox/connections/{id}/outage
+func (s *SandboxService) RestoreConnection(ctx context.Context, connectionID string, opts ...RequestOption) error {
+	if err := s.requireTestKey(ctx); err != nil {
+		return err
+	}
+	if err := checkID(IDKindConnection, connectionID); err != nil {
+		return err
+	}
+
+	opts = append([]RequestOption{withFreshIdempotencyKey()}, opts...)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/sandbox/connections/%s/outage", connectionID), nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(httpRequest, nil)
+}
+
+// requireTestKey refuses to run simulations with a live key, which the API would
+// reject anyway, so that a misconfigured test suite fails before touching production.
+func (s *SandboxService) requireTestKey(ctx context.Context) error {
+	key, err := s.client.currentAPIKey(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(key, "_live_") {
+		return ErrLiveModeKey
+	}
+	return nil
+}
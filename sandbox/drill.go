@@ -0,0 +1,182 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+)
+
+// DefaultOutageDuration is how long the connections of a Scenario fail when its Duration
+// is zero. Outages are lifted at the end of each scenario regardless.
+const DefaultOutageDuration = 5 * time.Minute
+
+// Drill is a scripted failover drill: each scenario takes connections down, creates a
+// payment and checks that routing fell back to a healthy connection.
+//
+//	report, err := sandbox.RunDrill(ctx, client, sandbox.Drill{
+//		Name: "Q3 resilience audit",
+//		Scenarios: []sandbox.Scenario{{
+//			Name:              "Paystack down",
+//			Fail:              []string{"conn_paystack"},
+//			Payment:           &reevit.PaymentIntentRequest{Amount: 1000, Currency: "GHS", Method: reevit.MethodCard, Country: "GH"},
+//			ExpectConnections: []string{"conn_flutterwave"},
+//		}},
+//	})
+//	fmt.Print(report)
+type Drill struct {
+	Name      string
+	Scenarios []Scenario
+}
+
+// Scenario is one step of a Drill.
+type Scenario struct {
+	Name string
+	// Fail lists the connections to take down for the scenario.
+	Fail []string
+	// Mode is reevit.OutageError or reevit.OutageTimeout; empty means OutageError.
+	Mode string
+	// Duration is how long the outage lasts if the scenario is interrupted before it can
+	// lift it; zero means DefaultOutageDuration.
+	Duration time.Duration
+	// Payment is the intent created while the connections are down.
+	Payment *reevit.PaymentIntentRequest
+	// ExpectConnections lists the connections the payment may fall back to. When empty,
+	// any connection not in Fail passes.
+	ExpectConnections []string
+}
+
+// Report is the outcome of a Drill, kept as evidence for resilience audits.
+type Report struct {
+	Drill      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Results    []ScenarioResult
+}
+
+// ScenarioResult is the outcome of one Scenario.
+type ScenarioResult struct {
+	Scenario string
+	Passed   bool
+	// Failure explains why the scenario did not pass.
+	Failure      string
+	PaymentID    string
+	ConnectionID string
+	Provider     reevit.Provider
+	// Route lists the connections routing tried, in order.
+	Route    []reevit.PaymentRouteAttempt
+	Duration time.Duration
+}
+
+// Passed reports whether every scenario passed.
+func (r *Report) Passed() bool {
+	for _, result := range r.Results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report as plain text, one line per scenario.
+func (r *Report) String() string {
+	var b strings.Builder
+	status := "PASSED"
+	if !r.Passed() {
+		status = "FAILED"
+	}
+	fmt.Fprintf(&b, "Failover drill %q %s (%s, %s)\n", r.Drill, status, r.StartedAt.UTC().Format(time.RFC3339), r.FinishedAt.Sub(r.StartedAt).Round(time.Millisecond))
+	for _, result := range r.Results {
+		if result.Passed {
+			fmt.Fprintf(&b, "  PASS %s: %s routed to %s (%s)", result.Scenario, result.PaymentID, result.ConnectionID, result.Provider)
+		} else {
+			fmt.Fprintf(&b, "  FAIL %s: %s", result.Scenario, result.Failure)
+		}
+		if len(result.Route) > 0 {
+			attempts := make([]string, len(result.Route))
+			for i, attempt := range result.Route {
+				attempts[i] = attempt.ConnectionID + "=" + attempt.Status
+			}
+			fmt.Fprintf(&b, " [route: %s]", strings.Join(attempts, " -> "))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// RunDrill runs the scenarios of drill in order and reports how routing behaved. Routing
+// failures are recorded in the report; the returned error is for drills that could not
+// run, such as a live API key or an outage the sandbox refused to inject. Outages are
+// lifted after each scenario, even when ctx is canceled.
+func RunDrill(ctx context.Context, client *reevit.Client, drill Drill) (*Report, error) {
+	report := &Report{Drill: drill.Name, StartedAt: time.Now()}
+	for _, scenario := range drill.Scenarios {
+		result, err := runScenario(ctx, client, scenario)
+		if err != nil {
+			report.FinishedAt = time.Now()
+			return report, fmt.Errorf("sandbox: scenario %q: %w", scenario.Name, err)
+		}
+		report.Results = append(report.Results, result)
+	}
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func runScenario(ctx context.Context, client *reevit.Client, scenario Scenario) (result ScenarioResult, err error) {
+	if scenario.Payment == nil {
+		return result, errors.New("no payment to route")
+	}
+	duration := scenario.Duration
+	if duration <= 0 {
+		duration = DefaultOutageDuration
+	}
+
+	result.Scenario = scenario.Name
+	started := time.Now()
+	defer func() {
+		restoreCtx := context.WithoutCancel(ctx)
+		for _, connectionID := range scenario.Fail {
+			if restoreErr := client.Sandbox.RestoreConnection(restoreCtx, connectionID); restoreErr != nil && err == nil {
+				err = restoreErr
+			}
+		}
+	}()
+	for _, connectionID := range scenario.Fail {
+		if _, err := client.Sandbox.FailConnection(ctx, connectionID, duration, scenario.Mode); err != nil {
+			return result, err
+		}
+	}
+
+	payment, createErr := client.Payments.CreateIntent(ctx, scenario.Payment)
+	result.Duration = time.Since(started)
+	if createErr != nil {
+		result.Failure = "payment not routed: " + createErr.Error()
+		return result, nil
+	}
+	result.PaymentID = payment.ID
+	result.ConnectionID = payment.ConnectionID
+	result.Provider = payment.Provider
+	result.Route = payment.Route
+
+	switch {
+	case contains(scenario.Fail, payment.ConnectionID):
+		result.Failure = fmt.Sprintf("%s routed to failed connection %s", payment.ID, payment.ConnectionID)
+	case len(scenario.ExpectConnections) > 0 && !contains(scenario.ExpectConnections, payment.ConnectionID):
+		result.Failure = fmt.Sprintf("%s routed to %s, want one of %s", payment.ID, payment.ConnectionID, strings.Join(scenario.ExpectConnections, ", "))
+	default:
+		result.Passed = true
+	}
+	return result, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package sandbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDrill(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	down := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/outage"):
+			connectionID := strings.Split(r.URL.Path, "/")[4]
+			down[connectionID] = r.Method == http.MethodPost
+			_, _ = w.Write([]byte(`{"connection_id":"` + connectionID + `","mode":"error","ends_at":"2026-03-01T00:05:00Z"}`))
+		case r.URL.Path == "/v1/payments/intents":
+			if down["conn_paystack"] && down["conn_flutterwave"] {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"code":"no_route","message":"no healthy connection"}`))
+				return
+			}
+			connectionID := "conn_paystack"
+			route := `[{"connection_id":"conn_paystack","status":"pending"}]`
+			if down["conn_paystack"] {
+				connectionID = "conn_flutterwave"
+				route = `[{"connection_id":"conn_paystack","status":"failed"},{"connection_id":"conn_flutterwave","status":"pending"}]`
+			}
+			_, _ = w.Write([]byte(`{"id":"pay_1","connection_id":"` + connectionID + `","provider":"paystack","route":` + route + `}`))
+		}
+	}))
+	defer server.Close()
+
+	client := reevit.NewClient("pfk_test_1", "org_1", reevit.WithBaseURL(server.URL), reevit.WithMaxRetries(0))
+	payment := &reevit.PaymentIntentRequest{Amount: 1000, Currency: "GHS", Method: reevit.MethodCard, Country: "GH"}
+	report, err := RunDrill(context.Background(), client, Drill{
+		Name: "Q3 audit",
+		Scenarios: []Scenario{
+			{Name: "paystack down", Fail: []string{"conn_paystack"}, Payment: payment, ExpectConnections: []string{"conn_flutterwave"}},
+			{Name: "all down", Fail: []string{"conn_paystack", "conn_flutterwave"}, Payment: payment},
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	require.Len(t, report.Results, 2)
+	require.True(t, report.Results[0].Passed)
+	require.Equal(t, "conn_flutterwave", report.Results[0].ConnectionID)
+	require.False(t, report.Results[1].Passed)
+	require.Contains(t, report.Results[1].Failure, "payment not routed")
+	require.Contains(t, report.String(), "PASS paystack down: pay_1 routed to conn_flutterwave (paystack) [route: conn_paystack=failed -> conn_flutterwave=pending]")
+	require.Contains(t, report.String(), "FAIL all down")
+	require.Empty(t, down["conn_paystack"])
+	require.Empty(t, down["conn_flutterwave"])
+	require.Equal(t, "POST /v1/sandbox/connections/conn_paystack/outage", requests[0])
+	require.Equal(t, "DELETE /v1/sandbox/connections/conn_paystack/outage", requests[2])
+
+	live := reevit.NewClient("pfk_live_1", "org_1", reevit.WithBaseURL(server.URL))
+	_, err = RunDrill(context.Background(), live, Drill{Scenarios: []Scenario{{Name: "live", Fail: []string{"conn_paystack"}, Payment: payment}}})
+	require.ErrorIs(t, err, reevit.ErrLiveModeKey)
+}
@@ -0,0 +1,45 @@
+// Package sandbox drives payment and subscription outcomes in the Reevit sandbox, so
+// integration tests can exercise success, decline and renewal paths deterministically
+// instead of waiting on a PSP test environment.
+//
+//	payment, err := client.Payments.CreateIntent(ctx, req)
+//	...
+//	payment, err = sandbox.TriggerFailure(ctx, client, payment.ID, sandbox.FailureInsufficientFunds)
+//
+// The helpers only work with test API keys; with a live key they return
+// reevit.ErrLiveModeKey without sending a request.
+package sandbox
+
+import (
+	"context"
+	"time"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+)
+
+// Failure codes understood by the sandbox. Other decline codes reported by the API are
+// accepted as well.
+const (
+	FailureDeclined          = "card_declined"
+	FailureInsufficientFunds = "insufficient_funds"
+	FailureExpiredCard       = "expired_card"
+	FailureTimeout           = "timeout"
+	FailureWrongPIN          = "wrong_pin"
+)
+
+// TriggerPaymentSuccess makes a pending test-mode payment succeed.
+func TriggerPaymentSuccess(ctx context.Context, client *reevit.Client, paymentID string) (*reevit.Payment, error) {
+	return client.Sandbox.SimulatePayment(ctx, paymentID, &reevit.PaymentSimulation{Outcome: reevit.SimulateSuccess})
+}
+
+// TriggerFailure makes a pending test-mode payment fail with the decline code, e.g.
+// FailureInsufficientFunds.
+func TriggerFailure(ctx context.Context, client *reevit.Client, paymentID, code string) (*reevit.Payment, error) {
+	return client.Sandbox.SimulatePayment(ctx, paymentID, &reevit.PaymentSimulation{Outcome: reevit.SimulateFailure, FailureCode: code})
+}
+
+// AdvanceSubscriptionClock moves a test-mode subscription forward to t, running the
+// renewals and dunning steps that fall due on the way.
+func AdvanceSubscriptionClock(ctx context.Context, client *reevit.Client, subscriptionID string, t time.Time) (*reevit.Subscription, error) {
+	return client.Sandbox.AdvanceSubscriptionClock(ctx, subscriptionID, t)
+}
@@ -0,0 +1,51 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	reevit "github.com/Reevit-Platform/go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxHelpers(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		encoded, _ := json.Marshal(body)
+		requests = append(requests, r.URL.Path+" "+string(encoded))
+		if r.URL.Path == "/v1/sandbox/subscriptions/sub_1/advance-clock" {
+			_, _ = w.Write([]byte(`{"id":"sub_1","status":"past_due"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"pay_1","status":"failed"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := reevit.NewClient("pfk_test_1", "org_1", reevit.WithBaseURL(server.URL))
+	payment, err := TriggerFailure(ctx, client, "pay_1", FailureInsufficientFunds)
+	require.NoError(t, err)
+	require.Equal(t, reevit.PaymentStatusFailed, payment.Status)
+	_, err = TriggerPaymentSuccess(ctx, client, "pay_1")
+	require.NoError(t, err)
+	subscription, err := AdvanceSubscriptionClock(ctx, client, "sub_1", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, reevit.SubscriptionStatusPastDue, subscription.Status)
+
+	require.Equal(t, []string{
+		`/v1/sandbox/payments/pay_1/simulate {"failure_code":"insufficient_funds","outcome":"failure"}`,
+		`/v1/sandbox/payments/pay_1/simulate {"outcome":"success"}`,
+		`/v1/sandbox/subscriptions/sub_1/advance-clock {"to":"2026-03-01T00:00:00Z"}`,
+	}, requests)
+
+	live := reevit.NewClient("pfk_live_1", "org_1", reevit.WithBaseURL(server.URL))
+	_, err = TriggerPaymentSuccess(ctx, live, "pay_1")
+	require.ErrorIs(t, err, reevit.ErrLiveModeKey)
+	require.Len(t, requests, 3)
+}
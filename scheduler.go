@@ -0,0 +1,111 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultScheduleShare is the fraction of the organization's rate limit a Scheduler uses
+// when ScheduleOptions.Share is zero, leaving the rest to live traffic.
+const DefaultScheduleShare = 0.5
+
+// ErrQuotaExhausted is returned by Scheduler.Run when the daily quota left cannot cover
+// the estimated requests of the jobs.
+var ErrQuotaExhausted = errors.New("reevit: daily request quota cannot cover the scheduled jobs")
+
+// ScheduleOptions configures a Scheduler.
+type ScheduleOptions struct {
+	// Window is how long the jobs may take. Requests are paced to finish within it rather
+	// than as fast as the rate limit allows; zero paces them at the allowed share only.
+	Window time.Duration
+	// Share is the fraction of the organization's rate limit the jobs may use, between 0
+	// and 1. It defaults to DefaultScheduleShare.
+	Share float64
+}
+
+// ScheduledJob is a read-heavy workload run by a Scheduler, such as an export or a sync.
+type ScheduledJob struct {
+	Name string
+	// Requests estimates the API requests the job makes. The estimates of all jobs spread
+	// the requests over the window and are checked against the daily quota; zero means
+	// unknown.
+	Requests int64
+	// Run does the work. Every request made with ctx through the client that created the
+	// scheduler is paced.
+	Run func(ctx context.Context) error
+}
+
+// Scheduler runs nightly jobs at a pace derived from the organization's rate-limit tier,
+// fetched with Usage.GetLimits, instead of at full speed, so that exports and syncs
+// started at midnight do not starve live traffic of its rate limit or exhaust the daily
+// quota.
+type Scheduler struct {
+	client  *Client
+	options ScheduleOptions
+}
+
+// NewScheduler returns a Scheduler for jobs using c.
+func (c *Client) NewScheduler(options ScheduleOptions) *Scheduler {
+	if options.Share <= 0 || options.Share > 1 {
+		options.Share = DefaultScheduleShare
+	}
+	return &Scheduler{client: c, options: options}
+}
+
+// Run runs jobs one after the other and returns the errors of those that failed, joined.
+// A failing job does not stop the next ones. Requests are paced at the lower of the
+// allowed share of the rate limit and the rate that spreads the estimated requests over
+// the window; the client's own WithRateLimit still applies on top.
+func (s *Scheduler) Run(ctx context.Context, jobs ...ScheduledJob) error {
+	limits, err := s.client.Usage.GetLimits(ctx)
+	if err != nil {
+		return fmt.Errorf("reevit: fetching rate limits: %w", err)
+	}
+	var total int64
+	for _, job := range jobs {
+		total += job.Requests
+	}
+	if remaining := limits.DailyRemaining(); remaining >= 0 && total > remaining {
+		return fmt.Errorf("%w: %d requests estimated, %d left until %s", ErrQuotaExhausted, total, remaining, limits.ResetsAt.UTC().Format(time.RFC3339))
+	}
+
+	rps, burst := s.pace(limits, total)
+	ctx = context.WithValue(ctx, pacingKey{}, newRateLimiter(rps, burst))
+	var errs []error
+	for _, job := range jobs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := job.Run(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("reevit: scheduled job %q: %w", job.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pace returns the request rate and burst for total estimated requests.
+func (s *Scheduler) pace(limits *APILimits, total int64) (float64, int) {
+	rps := limits.RequestsPerSecond * s.options.Share
+	burst := int(float64(limits.Burst) * s.options.Share)
+	if s.options.Window > 0 && total > 0 {
+		if spread := float64(total) / s.options.Window.Seconds(); spread < rps || rps <= 0 {
+			rps, burst = spread, 1
+		}
+	}
+	if rps <= 0 {
+		// The tier did not say; fall back to one request per second rather than no pacing.
+		rps = 1
+	}
+	return rps, burst
+}
+
+type pacingKey struct{}
+
+// pacing returns the rate limiter a Scheduler attached to ctx, if any.
+func pacing(ctx context.Context) *rateLimiter {
+	limiter, _ := ctx.Value(pacingKey{}).(*rateLimiter)
+	return limiter
+}
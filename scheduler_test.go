@@ -0,0 +1,63 @@
+package reevit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerPace(t *testing.T) {
+	limits := &APILimits{RequestsPerSecond: 100, Burst: 20}
+	client := NewClient("pfk_test", "org_1")
+
+	rps, burst := client.NewScheduler(ScheduleOptions{}).pace(limits, 0)
+	require.Equal(t, 50.0, rps)
+	require.Equal(t, 10, burst)
+
+	// 36000 requests over ten hours only need one request per second.
+	rps, burst = client.NewScheduler(ScheduleOptions{Window: 10 * time.Hour, Share: 0.25}).pace(limits, 36000)
+	require.Equal(t, 1.0, rps)
+	require.Equal(t, 1, burst)
+
+	// A window too short for the estimate is capped at the allowed share.
+	rps, _ = client.NewScheduler(ScheduleOptions{Window: time.Minute, Share: 0.25}).pace(limits, 36000)
+	require.Equal(t, 25.0, rps)
+}
+
+func TestSchedulerRun(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/v1/usage/limits":
+			_, _ = w.Write([]byte(`{"tier":"standard","requests_per_second":1000,"burst":100,"daily_limit":1000,"daily_used":990,"resets_at":"2026-03-02T00:00:00Z"}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	scheduler := client.NewScheduler(ScheduleOptions{Window: time.Hour})
+	sync := ScheduledJob{Name: "sync", Requests: 2, Run: func(ctx context.Context) error {
+		require.NotNil(t, pacing(ctx))
+		_, err := client.Payments.List(ctx, nil)
+		return err
+	}}
+	broken := ScheduledJob{Name: "export", Requests: 1, Run: func(ctx context.Context) error {
+		return errors.New("disk full")
+	}}
+
+	err := scheduler.Run(context.Background(), broken, sync)
+	require.EqualError(t, err, `reevit: scheduled job "export": disk full`)
+	require.Equal(t, []string{"/v1/usage/limits", "/v1/payments"}, requests)
+
+	err = scheduler.Run(context.Background(), ScheduledJob{Name: "full export", Requests: 50, Run: sync.Run})
+	require.ErrorIs(t, err, ErrQuotaExhausted)
+	require.EqualError(t, err, "reevit: daily request quota cannot cover the scheduled jobs: 50 requests estimated, 10 left until 2026-03-02T00:00:00Z")
+}
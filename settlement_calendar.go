@@ -0,0 +1,120 @@
+package reevit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SettlementCalendarService handles communication with the settlement calendar endpoint of the Reevit API.
+type SettlementCalendarService service
+
+// SettlementCalendar describes when settlements are paid out in a country.
+type SettlementCalendar struct {
+	Country string `json:"country"`
+	// Timezone is the IANA timezone in which cut-off times and holidays are expressed.
+	Timezone string `json:"timezone"`
+	// CutoffTime is the local time of day (HH:MM) after which payments roll over to the next business day.
+	CutoffTime string `json:"cutoff_time"`
+	// SettlementDelayDays is the number of business days between capture and payout (T+N).
+	SettlementDelayDays int       `json:"settlement_delay_days"`
+	Holidays            []Holiday `json:"holidays"`
+}
+
+// Holiday represents a bank holiday on which no settlements are paid.
+type Holiday struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Name string `json:"name"`
+}
+
+// Get fetches the settlement calendar for a country.
+//
+// API Docs: GET /v1/settlements/calendar
+func (s *SettlementCalendarService) Get(ctx context.Context, country string, opts ...RequestOption) (*SettlementCalendar, error) {
+	values := url.Values{}
+	setString(values, "country", strings.ToUpper(country))
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/settlements/calendar", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var calendar SettlementCalendar
+	if err := s.client.do(httpRequest, &calendar); err != nil {
+		return nil, err
+	}
+
+	return &calendar, nil
+}
+
+// NextSettlementDate predicts the payout date for a payment captured at t in the given country.
+func (s *SettlementCalendarService) NextSettlementDate(ctx context.Context, country string, t time.Time, opts ...RequestOption) (time.Time, error) {
+	calendar, err := s.Get(ctx, country, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calendar.NextSettlementDate(t)
+}
+
+// IsBusinessDay reports whether t falls on a weekday that is not a holiday in the calendar's timezone.
+func (c *SettlementCalendar) IsBusinessDay(t time.Time) bool {
+	location, err := c.location()
+	if err != nil {
+		location = time.UTC
+	}
+	local := t.In(location)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	date := local.Format("2006-01-02")
+	for _, holiday := range c.Holidays {
+		if holiday.Date == date {
+			return false
+		}
+	}
+	return true
+}
+
+// NextSettlementDate returns the local date (at midnight) on which a payment captured at t is paid out.
+func (c *SettlementCalendar) NextSettlementDate(t time.Time) (time.Time, error) {
+	location, err := c.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := t.In(location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+
+	if c.CutoffTime != "" {
+		cutoff, err := time.ParseInLocation("15:04", c.CutoffTime, location)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("reevit: invalid settlement cut-off time %q: %w", c.CutoffTime, err)
+		}
+		cutoffToday := day.Add(time.Duration(cutoff.Hour())*time.Hour + time.Duration(cutoff.Minute())*time.Minute)
+		if !local.Before(cutoffToday) {
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+
+	for !c.IsBusinessDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	for remaining := c.SettlementDelayDays; remaining > 0; {
+		day = day.AddDate(0, 0, 1)
+		if c.IsBusinessDay(day) {
+			remaining--
+		}
+	}
+
+	return day, nil
+}
+
+func (c *SettlementCalendar) location() (*time.Location, error) {
+	if strings.TrimSpace(c.Timezone) == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
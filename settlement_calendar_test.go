@@ -0,0 +1,66 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettlementCalendarNextSettlementDate(t *testing.T) {
+	ghana := &SettlementCalendar{
+		Country:             "GH",
+		Timezone:            "Africa/Accra",
+		CutoffTime:          "15:00",
+		SettlementDelayDays: 1,
+		Holidays:            []Holiday{{Date: "2026-03-06", Name: "Independence Day"}},
+	}
+	nigeria := &SettlementCalendar{Country: "NG", Timezone: "Africa/Lagos", CutoffTime: "15:00", SettlementDelayDays: 1}
+
+	tests := []struct {
+		name     string
+		calendar *SettlementCalendar
+		captured time.Time
+		want     string
+	}{
+		{"before cut-off", ghana, time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), "2026-03-03"},
+		{"at cut-off", ghana, time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC), "2026-03-04"},
+		{"skips holiday and weekend", ghana, time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), "2026-03-09"},
+		{"captured on a weekend", ghana, time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC), "2026-03-10"},
+		{"cut-off in local time", nigeria, time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC), "2026-03-04"},
+		{"no cut-off or delay", &SettlementCalendar{}, time.Date(2026, 3, 7, 23, 0, 0, 0, time.UTC), "2026-03-09"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.calendar.NextSettlementDate(tt.captured)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Format("2006-01-02"))
+			require.Zero(t, got.Hour())
+		})
+	}
+
+	_, err := (&SettlementCalendar{CutoffTime: "3pm"}).NextSettlementDate(time.Now())
+	require.ErrorContains(t, err, `invalid settlement cut-off time "3pm"`)
+	_, err = (&SettlementCalendar{Timezone: "Mars/Olympus"}).NextSettlementDate(time.Now())
+	require.Error(t, err)
+
+	require.False(t, ghana.IsBusinessDay(time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC)))
+	require.True(t, ghana.IsBusinessDay(time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestSettlementCalendarService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/settlements/calendar", r.URL.Path)
+		require.Equal(t, "GH", r.URL.Query().Get("country"))
+		_, _ = w.Write([]byte(`{"country":"GH","timezone":"Africa/Accra","cutoff_time":"15:00","settlement_delay_days":2,"holidays":[{"date":"2026-03-06","name":"Independence Day"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	date, err := client.SettlementCalendar.NextSettlementDate(context.Background(), "gh", time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "2026-03-09", date.Format("2006-01-02"))
+}
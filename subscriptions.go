@@ -2,7 +2,7 @@ package reevit
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"time"
@@ -12,21 +12,23 @@ import (
 type SubscriptionsService service
 
 // SubscriptionRequest represents a request to create a subscription.
+// When PlanID refers to a managed plan, Amount, Currency and Interval may be left
+// empty and are taken from the plan.
 type SubscriptionRequest struct {
 	CustomerID string                 `json:"customer_id"`
 	PlanID     string                 `json:"plan_id"`
-	Amount     int64                  `json:"amount"`
-	Currency   string                 `json:"currency"`
-	Method     string                 `json:"method"`
-	Interval   string                 `json:"interval"`
+	Amount     int64                  `json:"amount,omitempty"`
+	Currency   string                 `json:"currency,omitempty"`
+	Method     PaymentMethod          `json:"method"`
+	Interval   Interval               `json:"interval,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // SubscriptionUpdateRequest represents a partial update to a subscription.
 type SubscriptionUpdateRequest struct {
 	PlanID   string                 `json:"plan_id,omitempty"`
-	Method   string                 `json:"method,omitempty"`
-	Interval string                 `json:"interval,omitempty"`
+	Method   PaymentMethod          `json:"method,omitempty"`
+	Interval Interval               `json:"interval,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
@@ -34,7 +36,7 @@ type SubscriptionUpdateRequest struct {
 type SubscriptionListOptions struct {
 	Limit      int
 	Offset     int
-	Status     string
+	Status     SubscriptionStatus
 	CustomerID string
 	PlanID     string
 }
@@ -47,30 +49,34 @@ type Subscription struct {
 	PlanID        string                 `json:"plan_id"`
 	Amount        int64                  `json:"amount"`
 	Currency      string                 `json:"currency"`
-	Method        string                 `json:"method"`
-	Interval      string                 `json:"interval"`
-	Status        string                 `json:"status"`
+	Method        PaymentMethod          `json:"method"`
+	Interval      Interval               `json:"interval"`
+	Status        SubscriptionStatus     `json:"status"`
 	NextRenewalAt time.Time              `json:"next_renewal_at"`
 	Metadata      map[string]interface{} `json:"metadata"`
 	CreatedAt     time.Time              `json:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
 }
 
 // Create creates a new subscription.
 //
 // API Docs: POST /v1/subscriptions
 func (s *SubscriptionsService) Create(ctx context.Context, req *SubscriptionRequest, opts ...RequestOption) (*Subscription, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/subscriptions", req)
-	if err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/subscriptions", req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var subscription Subscription
-	if err := s.client.do(ctx, httpRequest, &subscription); err != nil {
+	if err := s.client.do(httpRequest, &subscription); err != nil {
 		return nil, err
 	}
 
@@ -80,40 +86,55 @@ func (s *SubscriptionsService) Create(ctx context.Context, req *SubscriptionRequ
 // List returns a list of subscriptions.
 //
 // API Docs: GET /v1/subscriptions
-func (s *SubscriptionsService) List(ctx context.Context, options ...SubscriptionListOptions) ([]Subscription, error) {
+func (s *SubscriptionsService) List(ctx context.Context, options *SubscriptionListOptions, opts ...RequestOption) ([]Subscription, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
-		setString(values, "status", options[0].Status)
-		setString(values, "customer_id", options[0].CustomerID)
-		setString(values, "plan_id", options[0].PlanID)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", string(options.Status))
+		setString(values, "customer_id", options.CustomerID)
+		setString(values, "plan_id", options.PlanID)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/subscriptions", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/subscriptions", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var subscriptions []Subscription
-	if err := s.client.do(ctx, httpRequest, &subscriptions); err != nil {
+	if err := s.client.do(httpRequest, &subscriptions); err != nil {
 		return nil, err
 	}
 
 	return subscriptions, nil
 }
 
+// ListAutoPaging returns an iterator over all subscriptions matching the filters,
+// fetching options.Limit subscriptions per page.
+func (s *SubscriptionsService) ListAutoPaging(ctx context.Context, options SubscriptionListOptions, opts ...RequestOption) *Iter[Subscription] {
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]Subscription, error) {
+		page := options
+		page.Limit = limit
+		page.Offset = offset
+		return s.List(ctx, &page, opts...)
+	})
+}
+
 // Get retrieves a subscription by ID.
 //
 // API Docs: GET /v1/subscriptions/{id}
-func (s *SubscriptionsService) Get(ctx context.Context, subscriptionID string) (*Subscription, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/subscriptions/%s", subscriptionID), nil)
+func (s *SubscriptionsService) Get(ctx context.Context, subscriptionID string, opts ...RequestOption) (*Subscription, error) {
+	if err := checkID(IDKindSubscription, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/subscriptions/%s", subscriptionID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var subscription Subscription
-	if err := s.client.do(ctx, httpRequest, &subscription); err != nil {
+	if err := s.client.do(httpRequest, &subscription); err != nil {
 		return nil, err
 	}
 
@@ -124,17 +145,17 @@ func (s *SubscriptionsService) Get(ctx context.Context, subscriptionID string) (
 //
 // API Docs: PATCH /v1/subscriptions/{id}
 func (s *SubscriptionsService) Update(ctx context.Context, subscriptionID string, req *SubscriptionUpdateRequest, opts ...RequestOption) (*Subscription, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPatch, fmt.Sprintf("/v1/subscriptions/%s", subscriptionID), req)
-	if err != nil {
+	if err := checkID(IDKindSubscription, subscriptionID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/subscriptions/%s", subscriptionID), req, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var subscription Subscription
-	if err := s.client.do(ctx, httpRequest, &subscription); err != nil {
+	if err := s.client.do(httpRequest, &subscription); err != nil {
 		return nil, err
 	}
 
@@ -145,17 +166,17 @@ func (s *SubscriptionsService) Update(ctx context.Context, subscriptionID string
 //
 // API Docs: POST /v1/subscriptions/{id}/cancel
 func (s *SubscriptionsService) Cancel(ctx context.Context, subscriptionID string, opts ...RequestOption) (*Subscription, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/subscriptions/%s/cancel", subscriptionID), map[string]interface{}{})
-	if err != nil {
+	if err := checkID(IDKindSubscription, subscriptionID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/subscriptions/%s/cancel", subscriptionID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var subscription Subscription
-	if err := s.client.do(ctx, httpRequest, &subscription); err != nil {
+	if err := s.client.do(httpRequest, &subscription); err != nil {
 		return nil, err
 	}
 
@@ -166,17 +187,17 @@ func (s *SubscriptionsService) Cancel(ctx context.Context, subscriptionID string
 //
 // API Docs: POST /v1/subscriptions/{id}/resume
 func (s *SubscriptionsService) Resume(ctx context.Context, subscriptionID string, opts ...RequestOption) (*Subscription, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/subscriptions/%s/resume", subscriptionID), map[string]interface{}{})
-	if err != nil {
+	if err := checkID(IDKindSubscription, subscriptionID); err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/subscriptions/%s/resume", subscriptionID), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	var subscription Subscription
-	if err := s.client.do(ctx, httpRequest, &subscription); err != nil {
+	if err := s.client.do(httpRequest, &subscription); err != nil {
 		return nil, err
 	}
 
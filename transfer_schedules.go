@@ -0,0 +1,220 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransferSchedulesService handles recurring transfers such as weekly supplier payouts.
+type TransferSchedulesService service
+
+// TransferSchedule is a standing order that executes a transfer on a recurring basis.
+type TransferSchedule struct {
+	ID            string                 `json:"id"`
+	Transfer      TransferRequest        `json:"transfer"`
+	Interval      Interval               `json:"interval"`
+	IntervalCount int                    `json:"interval_count"`
+	Status        string                 `json:"status"`
+	StartAt       time.Time              `json:"start_at"`
+	EndAt         *time.Time             `json:"end_at"`
+	NextRunAt     *time.Time             `json:"next_run_at"`
+	LastRunAt     *time.Time             `json:"last_run_at"`
+	Executions    int                    `json:"executions"`
+	MaxExecutions int                    `json:"max_executions"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// TransferScheduleRequest represents a request to create a transfer schedule.
+type TransferScheduleRequest struct {
+	// Transfer is the transfer executed on every run.
+	Transfer TransferRequest `json:"transfer"`
+	// Interval is "daily", "weekly" or "monthly".
+	Interval Interval `json:"interval"`
+	// IntervalCount runs the schedule every IntervalCount intervals, e.g. 2 with "weekly"
+	// for fortnightly payouts.
+	IntervalCount int `json:"interval_count,omitempty"`
+	// StartAt is the first run; it defaults to now.
+	StartAt *time.Time `json:"start_at,omitempty"`
+	// EndAt and MaxExecutions optionally bound the schedule.
+	EndAt         *time.Time             `json:"end_at,omitempty"`
+	MaxExecutions int                    `json:"max_executions,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TransferScheduleUpdateRequest represents a partial update to a transfer schedule. Changes
+// apply from the next run.
+type TransferScheduleUpdateRequest struct {
+	Transfer      *TransferRequest       `json:"transfer,omitempty"`
+	Interval      Interval               `json:"interval,omitempty"`
+	IntervalCount int                    `json:"interval_count,omitempty"`
+	EndAt         *time.Time             `json:"end_at,omitempty"`
+	MaxExecutions int                    `json:"max_executions,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TransferScheduleListOptions contains list filters for transfer schedules.
+type TransferScheduleListOptions struct {
+	Limit         int
+	Offset        int
+	Status        string
+	DestinationID string
+}
+
+// TransferScheduleExecutionListOptions contains list filters for schedule executions.
+type TransferScheduleExecutionListOptions struct {
+	Limit  int
+	Offset int
+	Status string
+}
+
+// TransferScheduleExecution records one run of a transfer schedule.
+type TransferScheduleExecution struct {
+	ID             string     `json:"id"`
+	ScheduleID     string     `json:"schedule_id"`
+	TransferID     string     `json:"transfer_id"`
+	Status         string     `json:"status"`
+	FailureCode    string     `json:"failure_code"`
+	FailureMessage string     `json:"failure_message"`
+	ScheduledFor   time.Time  `json:"scheduled_for"`
+	ExecutedAt     *time.Time `json:"executed_at"`
+}
+
+// Create creates a transfer schedule.
+//
+// API Docs: POST /v1/transfer-schedules
+func (s *TransferSchedulesService) Create(ctx context.Context, req *TransferScheduleRequest, opts ...RequestOption) (*TransferSchedule, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/transfer-schedules", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule TransferSchedule
+	if err := s.client.do(httpRequest, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// List returns a list of transfer schedules.
+//
+// API Docs: GET /v1/transfer-schedules
+func (s *TransferSchedulesService) List(ctx context.Context, options *TransferScheduleListOptions, opts ...RequestOption) ([]TransferSchedule, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+		setString(values, "destination_id", options.DestinationID)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/transfer-schedules", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[TransferSchedule](s.client, raw, "transfer_schedules")
+}
+
+// Get retrieves a transfer schedule by ID.
+//
+// API Docs: GET /v1/transfer-schedules/{id}
+func (s *TransferSchedulesService) Get(ctx context.Context, scheduleID string, opts ...RequestOption) (*TransferSchedule, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/transfer-schedules/%s", scheduleID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule TransferSchedule
+	if err := s.client.do(httpRequest, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// Update updates a transfer schedule.
+//
+// API Docs: PATCH /v1/transfer-schedules/{id}
+func (s *TransferSchedulesService) Update(ctx context.Context, scheduleID string, req *TransferScheduleUpdateRequest, opts ...RequestOption) (*TransferSchedule, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/transfer-schedules/%s", scheduleID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule TransferSchedule
+	if err := s.client.do(httpRequest, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// Pause stops a schedule from executing until it is resumed. Runs that fall due while
+// paused are skipped, not caught up.
+//
+// API Docs: POST /v1/transfer-schedules/{id}/pause
+func (s *TransferSchedulesService) Pause(ctx context.Context, scheduleID string, opts ...RequestOption) (*TransferSchedule, error) {
+	return s.transition(ctx, scheduleID, "pause", opts...)
+}
+
+// Resume resumes a paused schedule from its next due run.
+//
+// API Docs: POST /v1/transfer-schedules/{id}/resume
+func (s *TransferSchedulesService) Resume(ctx context.Context, scheduleID string, opts ...RequestOption) (*TransferSchedule, error) {
+	return s.transition(ctx, scheduleID, "resume", opts...)
+}
+
+// Cancel permanently stops a schedule.
+//
+// API Docs: POST /v1/transfer-schedules/{id}/cancel
+func (s *TransferSchedulesService) Cancel(ctx context.Context, scheduleID string, opts ...RequestOption) (*TransferSchedule, error) {
+	return s.transition(ctx, scheduleID, "cancel", opts...)
+}
+
+// ListExecutions returns the runs of a schedule, most recent first.
+//
+// API Docs: GET /v1/transfer-schedules/{id}/executions
+func (s *TransferSchedulesService) ListExecutions(ctx context.Context, scheduleID string, options *TransferScheduleExecutionListOptions, opts ...RequestOption) ([]TransferScheduleExecution, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/transfer-schedules/%s/executions", scheduleID), values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[TransferScheduleExecution](s.client, raw, "executions")
+}
+
+func (s *TransferSchedulesService) transition(ctx context.Context, scheduleID, action string, opts ...RequestOption) (*TransferSchedule, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/transfer-schedules/%s/%s", scheduleID, action), map[string]interface{}{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule TransferSchedule
+	if err := s.client.do(httpRequest, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
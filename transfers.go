@@ -0,0 +1,316 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Reevit-Platform/go-sdk/msisdn"
+)
+
+// TransfersService handles transfers of funds to sub-merchants and suppliers, including
+// the transfers created by split payments.
+type TransfersService service
+
+// TransferRequest moves funds from the organization balance to a sub-merchant or
+// supplier account.
+type TransferRequest struct {
+	// DestinationID is the recipient account, e.g. a sub-merchant ID.
+	DestinationID string                 `json:"destination_id,omitempty"`
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Description   string                 `json:"description,omitempty"`
+	Reference     string                 `json:"reference,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	// PaymentID ties the transfer to the payment that funded it. The transfer is then
+	// listed with the payment and cannot exceed its net amount.
+	PaymentID string `json:"payment_id,omitempty"`
+	// Recipient pays out to a mobile money wallet instead of DestinationID.
+	Recipient *MobileMoneyRecipient `json:"recipient,omitempty"`
+}
+
+// MobileMoneyRecipient is a mobile money wallet receiving a transfer.
+type MobileMoneyRecipient struct {
+	// Phone is the wallet number in local or international format for Country.
+	// Transfers.Create sends it in E.164 format.
+	Phone   string `json:"phone"`
+	Country string `json:"country"`
+	// Network is the mobile network of the wallet, e.g. msisdn.NetworkMTN. When empty,
+	// Transfers.Create derives it from the number.
+	Network string `json:"network,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// Transfer represents a transfer of funds to a destination account.
+type Transfer struct {
+	ID            string                 `json:"id"`
+	DestinationID string                 `json:"destination_id"`
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Status        string                 `json:"status"`
+	Description   string                 `json:"description"`
+	Reference     string                 `json:"reference"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// ScheduleID is set on transfers executed by a TransferSchedule.
+	ScheduleID string `json:"schedule_id,omitempty"`
+
+	// PaymentID is set on transfers funded by a payment, including split payments.
+	PaymentID string `json:"payment_id,omitempty"`
+	// AmountReversed is the part of Amount returned to the organization by reversals.
+	AmountReversed int64      `json:"amount_reversed"`
+	ReversedAt     *time.Time `json:"reversed_at,omitempty"`
+
+	// ExtraFields holds the fields returned by the API that this version of the SDK does
+	// not know yet, so that they are not lost. WithStrictDecoding rejects them instead.
+	ExtraFields map[string]json.RawMessage `json:"-"`
+}
+
+// TransferReversalRequest represents a request to reverse a transfer.
+type TransferReversalRequest struct {
+	// Amount is the amount to reverse. Zero reverses the remaining amount.
+	Amount   int64                  `json:"amount,omitempty"`
+	Reason   string                 `json:"reason,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TransferListOptions contains list filters for transfers.
+type TransferListOptions struct {
+	Limit         int
+	Offset        int
+	Status        string
+	DestinationID string
+	PaymentID     string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// SplitConfig splits a payment between sub-merchants. When the payment succeeds a
+// transfer is created for every split, tied to the payment through Transfer.PaymentID.
+type SplitConfig struct {
+	Splits []Split `json:"splits"`
+	// FeeBearer is who pays the processing fee: "platform" (the default) or "splits",
+	// in which case it is deducted from the splits pro rata.
+	FeeBearer string `json:"fee_bearer,omitempty"`
+}
+
+// Split is one sub-merchant's share of a payment. Exactly one of Amount and BasisPoints
+// must be set.
+type Split struct {
+	DestinationID string `json:"destination_id"`
+	// Amount is a fixed share in minor units of the payment currency.
+	Amount int64 `json:"amount,omitempty"`
+	// BasisPoints is a proportional share in hundredths of a percent, e.g. 250 for 2.5%.
+	BasisPoints int    `json:"basis_points,omitempty"`
+	Reference   string `json:"reference,omitempty"`
+}
+
+// Create creates a transfer.
+//
+// API Docs: POST /v1/transfers
+func (s *TransfersService) Create(ctx context.Context, req *TransferRequest, opts ...RequestOption) (*Transfer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Recipient != nil {
+		recipient, err := req.Recipient.normalized()
+		if err != nil {
+			return nil, err
+		}
+		normalized := *req
+		normalized.Recipient = recipient
+		req = &normalized
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/transfers", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer Transfer
+	if err := s.client.do(httpRequest, &transfer); err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// BulkTransferOptions configures Transfers.CreateBulk.
+type BulkTransferOptions struct {
+	// StopOnError stops at the first failed transfer instead of attempting the rest.
+	StopOnError bool
+	// OnProgress, if set, is called after every transfer. Stage is "transfers",
+	// Processed the number of transfers attempted and Total the number requested.
+	OnProgress ProgressFunc
+}
+
+// BulkTransferResult is the outcome of one transfer of CreateBulk: the created transfer,
+// or the error it failed with.
+type BulkTransferResult struct {
+	Transfer *Transfer
+	Err      error
+}
+
+// CreateBulk creates transfers one after the other, e.g. a weekly payout run to many
+// vendors, and returns the outcome of each attempted transfer in the order of reqs. The
+// opts apply to every transfer. It returns an error wrapping the first failure when any
+// transfer failed, and the context's error when ctx is done before every transfer was
+// attempted. Re-running a batch creates the successful transfers again, so retry only the
+// requests whose result has an Err.
+func (s *TransfersService) CreateBulk(ctx context.Context, reqs []*TransferRequest, options *BulkTransferOptions, opts ...RequestOption) ([]BulkTransferResult, error) {
+	if options == nil {
+		options = &BulkTransferOptions{}
+	}
+	tracker := NewProgressTracker(int64(len(reqs)), 0)
+	results := make([]BulkTransferResult, 0, len(reqs))
+	var failed int
+	var firstErr error
+	for _, req := range reqs {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		transfer, err := s.Create(ctx, req, opts...)
+		results = append(results, BulkTransferResult{Transfer: transfer, Err: err})
+		if options.OnProgress != nil {
+			options.OnProgress(tracker.Update("transfers", int64(len(results))))
+		}
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			if options.StopOnError {
+				break
+			}
+		}
+	}
+	if firstErr != nil {
+		return results, fmt.Errorf("reevit: %d of %d transfers failed: %w", failed, len(results), firstErr)
+	}
+	return results, nil
+}
+
+// Get retrieves a transfer by ID.
+//
+// API Docs: GET /v1/transfers/{id}
+func (s *TransfersService) Get(ctx context.Context, transferID string, opts ...RequestOption) (*Transfer, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/transfers/%s", transferID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer Transfer
+	if err := s.client.do(httpRequest, &transfer); err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// List returns a list of transfers.
+//
+// API Docs: GET /v1/transfers
+func (s *TransfersService) List(ctx context.Context, options *TransferListOptions, opts ...RequestOption) ([]Transfer, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+		setString(values, "destination_id", options.DestinationID)
+		setString(values, "payment_id", options.PaymentID)
+		setTime(values, "created_after", options.CreatedAfter)
+		setTime(values, "created_before", options.CreatedBefore)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/transfers", values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[Transfer](s.client, raw, "transfers")
+}
+
+// ListAutoPaging returns an iterator over all transfers matching the filters, fetching
+// options.Limit transfers per page.
+func (s *TransfersService) ListAutoPaging(ctx context.Context, options TransferListOptions, opts ...RequestOption) *Iter[Transfer] {
+	return newIter(ctx, options.Limit, options.Offset, func(ctx context.Context, limit, offset int) ([]Transfer, error) {
+		page := options
+		page.Limit = limit
+		page.Offset = offset
+		return s.List(ctx, &page, opts...)
+	})
+}
+
+// ListForPayment returns the transfers funded by a payment, such as those created by
+// its SplitConfig.
+//
+// API Docs: GET /v1/payments/{id}/transfers
+func (s *TransfersService) ListForPayment(ctx context.Context, paymentID string, opts ...RequestOption) ([]Transfer, error) {
+	if err := checkID(IDKindPayment, paymentID); err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/payments/%s/transfers", paymentID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[Transfer](s.client, raw, "transfers")
+}
+
+// Reverse returns all or part of a transfer to the organization balance, e.g. when the
+// payment that funded it is refunded.
+//
+// API Docs: POST /v1/transfers/{id}/reverse
+func (s *TransfersService) Reverse(ctx context.Context, transferID string, req *TransferReversalRequest, opts ...RequestOption) (*Transfer, error) {
+	var body interface{} = map[string]interface{}{}
+	if req != nil {
+		body = req
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/transfers/%s/reverse", transferID), body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer Transfer
+	if err := s.client.do(httpRequest, &transfer); err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// normalized returns a copy of r with the phone number in E.164 format and the network
+// filled in.
+func (r *MobileMoneyRecipient) normalized() (*MobileMoneyRecipient, error) {
+	phone, err := msisdn.Normalize(r.Phone, r.Country)
+	if err != nil {
+		return nil, fmt.Errorf("reevit: recipient phone: %w", err)
+	}
+	normalized := *r
+	normalized.Phone = phone
+	normalized.Country = strings.ToUpper(strings.TrimSpace(r.Country))
+	if normalized.Network == "" {
+		if normalized.Network, err = msisdn.Network(phone, r.Country); err != nil {
+			return nil, fmt.Errorf("reevit: recipient phone: %w", err)
+		}
+	}
+	return &normalized, nil
+}
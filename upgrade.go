@@ -0,0 +1,104 @@
+package reevit
+
+import (
+	"net/http"
+	"time"
+)
+
+// ErrorCodeUpgradeRequired is the error code of responses rejecting an SDK version the
+// API no longer supports.
+const ErrorCodeUpgradeRequired = "sdk_upgrade_required"
+
+// UpgradeRequiredError is returned when the API refuses requests from this SDK version,
+// with status 426, or 410 and the sdk_upgrade_required code. Requests will keep failing
+// until the SDK is upgraded to at least MinimumVersion.
+type UpgradeRequiredError struct {
+	*APIError
+	// MinimumVersion is the oldest SDK version the API accepts.
+	MinimumVersion string
+	// ChangelogURL points at the release notes covering the required upgrade.
+	ChangelogURL string
+}
+
+func (e *UpgradeRequiredError) Unwrap() error { return e.APIError }
+
+// SDKDeprecation reports that the API has deprecated or stopped accepting this SDK
+// version.
+type SDKDeprecation struct {
+	// CurrentVersion is Version.
+	CurrentVersion string
+	MinimumVersion string
+	ChangelogURL   string
+	// Sunset is when the API stops accepting this version, if announced.
+	Sunset time.Time
+	// Required is set when requests are already being rejected with an
+	// UpgradeRequiredError.
+	Required bool
+}
+
+// Headers the API sets on responses to deprecated SDK versions.
+const (
+	headerSDKDeprecated   = "X-Reevit-SDK-Deprecated"
+	headerSDKMinVersion   = "X-Reevit-SDK-Min-Version"
+	headerSDKChangelogURL = "X-Reevit-SDK-Changelog-URL"
+	headerSDKSunset       = "Sunset"
+)
+
+// WithDeprecationHook calls hook when a response says this SDK version is deprecated or
+// no longer accepted, so platforms can alert before, or as soon as, requests start
+// failing. The hook runs on the request path for every such response and should be
+// cheap, e.g. incrementing a metric or logging once.
+func WithDeprecationHook(hook func(SDKDeprecation)) Option {
+	return func(c *Client) {
+		c.deprecationHook = hook
+	}
+}
+
+func isUpgradeRequired(apiErr *APIError) bool {
+	return apiErr.StatusCode == http.StatusUpgradeRequired ||
+		(apiErr.StatusCode == http.StatusGone && apiErr.Code == ErrorCodeUpgradeRequired)
+}
+
+func newUpgradeRequiredError(resp *http.Response, apiErr *APIError) *UpgradeRequiredError {
+	err := &UpgradeRequiredError{
+		APIError:       apiErr,
+		MinimumVersion: detailString(apiErr.Details, "min_version"),
+		ChangelogURL:   detailString(apiErr.Details, "changelog_url"),
+	}
+	if err.MinimumVersion == "" {
+		err.MinimumVersion = resp.Header.Get(headerSDKMinVersion)
+	}
+	if err.ChangelogURL == "" {
+		err.ChangelogURL = resp.Header.Get(headerSDKChangelogURL)
+	}
+	return err
+}
+
+// checkDeprecation calls the deprecation hook for responses flagging this SDK version.
+func (c *Client) checkDeprecation(resp *http.Response, body []byte) {
+	if c.deprecationHook == nil {
+		return
+	}
+	deprecation := SDKDeprecation{
+		CurrentVersion: Version,
+		MinimumVersion: resp.Header.Get(headerSDKMinVersion),
+		ChangelogURL:   resp.Header.Get(headerSDKChangelogURL),
+	}
+	if sunset := resp.Header.Get(headerSDKSunset); sunset != "" {
+		deprecation.Sunset, _ = http.ParseTime(sunset)
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := newAPIError(resp, body)
+		if !isUpgradeRequired(apiErr) {
+			return
+		}
+		upgrade := newUpgradeRequiredError(resp, apiErr)
+		deprecation.Required = true
+		deprecation.MinimumVersion = upgrade.MinimumVersion
+		deprecation.ChangelogURL = upgrade.ChangelogURL
+	} else if resp.Header.Get(headerSDKDeprecated) == "" {
+		return
+	}
+	c.deprecationHook(deprecation)
+}
@@ -0,0 +1,44 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportUsage(t *testing.T) {
+	var keys []string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id":"usage_1","subscription_id":"sub_1","quantity":42}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	record := UsageRecord{Metric: "api_calls", Quantity: 42, Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	created, err := client.Subscriptions.ReportUsage(context.Background(), "sub_1", record)
+	require.NoError(t, err)
+	require.Equal(t, "usage_1", created.ID)
+	_, err = client.Subscriptions.ReportUsage(context.Background(), "sub_1", record)
+	require.NoError(t, err)
+	_, err = client.Subscriptions.ReportUsage(context.Background(), "sub_1", record, WithIdempotencyKey("custom"))
+	require.NoError(t, err)
+
+	require.Equal(t, keys[0], keys[1])
+	require.NotEmpty(t, keys[0])
+	require.Equal(t, "custom", keys[2])
+	require.Equal(t, map[string]interface{}{
+		"metric":    "api_calls",
+		"quantity":  float64(42),
+		"timestamp": "2025-01-01T12:00:00Z",
+		"action":    "increment",
+	}, body)
+}
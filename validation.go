@@ -0,0 +1,228 @@
+package reevit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Reevit-Platform/go-sdk/msisdn"
+)
+
+// The Validate methods below check a request for problems the API would reject, so
+// that Create calls can fail without a round trip. They report every problem at once as
+// a *ValidationError whose Fields name the offending JSON fields, the same shape as a
+// validation error returned by the API. Validate only catches mistakes that are certain;
+// the API remains the authority and may still reject a request that passes. A nil
+// request fails validation rather than panicking.
+
+// Validate checks the request for missing or malformed fields. Payments.CreateIntent and
+// CheckoutSessions.Create call it before sending the request.
+func (r *PaymentIntentRequest) Validate() error {
+	var v validator
+	if r == nil {
+		v.add("body", "required", "request body is required")
+		return v.err("payment intent")
+	}
+	v.positive("amount", r.Amount)
+	v.currency("currency", r.Currency, true)
+	if r.Country != "" && !isAlpha(strings.ToUpper(r.Country), 2) {
+		v.add("country", "invalid", "country must be a 2-letter ISO 3166-1 code")
+	}
+	if r.Policy != nil && r.Policy.MaxAmount > 0 && r.Amount > r.Policy.MaxAmount {
+		v.add("amount", "invalid", "amount exceeds the policy max_amount")
+	}
+	if r.ScheduleAt != nil && !r.ScheduleAt.After(time.Now()) {
+		v.add("schedule_at", "invalid", "schedule_at must be in the future")
+	}
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		v.add("expires_at", "invalid", "expires_at must be in the future")
+	}
+	if r.AutoCancelAfter < 0 || (r.AutoCancelAfter > 0 && r.AutoCancelAfter < time.Second) {
+		v.add("auto_cancel_after_seconds", "invalid", "auto_cancel_after must be at least one second")
+	}
+	if r.Split != nil {
+		v.split(r.Split, r.Amount)
+	}
+	if r.Method == MethodMobileMoney && r.Phone != "" {
+		v.phone("phone", r.Phone, r.Country)
+	}
+	return v.err("payment intent")
+}
+
+// withNormalizedPhone returns r with a mobile money phone number in E.164 format, copying
+// r if it has to change. Validate must have accepted r.
+func (r *PaymentIntentRequest) withNormalizedPhone() *PaymentIntentRequest {
+	if r.Method != MethodMobileMoney || r.Phone == "" {
+		return r
+	}
+	phone, err := msisdn.Normalize(r.Phone, r.Country)
+	if err != nil || phone == r.Phone {
+		return r
+	}
+	normalized := *r
+	normalized.Phone = phone
+	return &normalized
+}
+
+// Validate checks the request for missing or malformed fields. Transfers.Create calls it
+// before sending the request.
+func (r *TransferRequest) Validate() error {
+	var v validator
+	if r == nil {
+		v.add("body", "required", "request body is required")
+		return v.err("transfer")
+	}
+	switch {
+	case strings.TrimSpace(r.DestinationID) == "" && r.Recipient == nil:
+		v.add("destination_id", "required", "destination_id or recipient is required")
+	case r.DestinationID != "" && r.Recipient != nil:
+		v.add("recipient", "invalid", "destination_id and recipient are mutually exclusive")
+	}
+	v.positive("amount", r.Amount)
+	v.currency("currency", r.Currency, true)
+	if r.Recipient != nil {
+		if r.Recipient.Phone == "" {
+			v.add("recipient.phone", "required", "recipient.phone is required")
+		} else {
+			v.phone("recipient.phone", r.Recipient.Phone, r.Recipient.Country)
+		}
+	}
+	return v.err("transfer")
+}
+
+// Validate checks the request for missing or malformed fields. Connections.Create calls
+// it before sending the request.
+func (r *ConnectionRequest) Validate() error {
+	var v validator
+	if r == nil {
+		v.add("body", "required", "request body is required")
+		return v.err("connection")
+	}
+	if strings.TrimSpace(string(r.Provider)) == "" {
+		v.add("provider", "required", "provider is required")
+	}
+	if r.Mode != "" && r.Mode != "test" && r.Mode != "live" {
+		v.add("mode", "invalid", `mode must be "test" or "live"`)
+	}
+	if len(r.Credentials) == 0 {
+		v.add("credentials", "required", "credentials are required")
+	}
+	return v.err("connection")
+}
+
+// Validate checks the request for missing or malformed fields. Subscriptions.Create calls
+// it before sending the request.
+func (r *SubscriptionRequest) Validate() error {
+	var v validator
+	if r == nil {
+		v.add("body", "required", "request body is required")
+		return v.err("subscription")
+	}
+	if strings.TrimSpace(r.CustomerID) == "" {
+		v.add("customer_id", "required", "customer_id is required")
+	}
+	if r.PlanID == "" {
+		// Without a plan the subscription is priced inline.
+		v.positive("amount", r.Amount)
+		v.currency("currency", r.Currency, true)
+		if r.Interval == "" {
+			v.add("interval", "required", "interval is required without plan_id")
+		}
+	} else {
+		if r.Amount < 0 {
+			v.add("amount", "invalid", "amount must not be negative")
+		}
+		v.currency("currency", r.Currency, false)
+	}
+	if r.Interval != "" && !r.Interval.valid() {
+		v.add("interval", "invalid", "interval must be daily, weekly, monthly, quarterly or yearly")
+	}
+	return v.err("subscription")
+}
+
+func (i Interval) valid() bool {
+	switch i {
+	case IntervalDaily, IntervalWeekly, IntervalMonthly, IntervalQuarterly, IntervalYearly:
+		return true
+	}
+	return false
+}
+
+// validator collects field errors for a single request.
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field, code, message string) {
+	v.fields = append(v.fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+func (v *validator) positive(field string, amount int64) {
+	if amount <= 0 {
+		v.add(field, "invalid", field+" must be greater than zero")
+	}
+}
+
+func (v *validator) currency(field, currency string, required bool) {
+	switch {
+	case currency == "":
+		if required {
+			v.add(field, "required", field+" is required")
+		}
+	case !isAlpha(strings.ToUpper(currency), 3):
+		v.add(field, "invalid", field+" must be a 3-letter ISO 4217 code")
+	}
+}
+
+// phone checks a mobile money number against the numbering plan of country.
+func (v *validator) phone(field, phone, country string) {
+	if country == "" {
+		v.add(field, "invalid", "country is required to validate "+field)
+		return
+	}
+	if err := msisdn.Validate(phone, country); err != nil {
+		v.add(field, "invalid", field+" is not a valid mobile money number: "+err.Error())
+	}
+}
+
+func (v *validator) split(config *SplitConfig, amount int64) {
+	var fixed int64
+	var basisPoints int
+	for i, split := range config.Splits {
+		field := fmt.Sprintf("split.splits.%d", i)
+		if strings.TrimSpace(split.DestinationID) == "" {
+			v.add(field+".destination_id", "required", "destination_id is required")
+		}
+		if (split.Amount > 0) == (split.BasisPoints > 0) || split.Amount < 0 || split.BasisPoints < 0 {
+			v.add(field, "invalid", "exactly one of amount and basis_points must be positive")
+		}
+		fixed += split.Amount
+		basisPoints += split.BasisPoints
+	}
+	if len(config.Splits) == 0 {
+		v.add("split.splits", "required", "split needs at least one split")
+	}
+	if basisPoints > 10000 {
+		v.add("split.splits", "invalid", "split basis_points must not exceed 10000 in total")
+	}
+	if amount > 0 && fixed+amount*int64(basisPoints)/10000 > amount {
+		v.add("split.splits", "invalid", "splits must not exceed the payment amount")
+	}
+}
+
+func (v *validator) err(kind string) error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	messages := make([]string, len(v.fields))
+	for i, field := range v.fields {
+		messages[i] = field.Message
+	}
+	return &ValidationError{
+		APIError: &APIError{
+			Code:    ErrorCodeValidation,
+			Message: "invalid " + kind + ": " + strings.Join(messages, "; "),
+		},
+		Fields: v.fields,
+	}
+}
@@ -0,0 +1,120 @@
+package reevit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequests(t *testing.T) {
+	err := (&PaymentIntentRequest{Amount: 0, Currency: "cedi", Country: "GHA"}).Validate()
+	var validation *ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Len(t, validation.FieldErrors("amount"), 1)
+	require.Len(t, validation.FieldErrors("currency"), 1)
+	require.Len(t, validation.FieldErrors("country"), 1)
+	require.True(t, validation.IsValidation())
+	require.Contains(t, err.Error(), "reevit: invalid payment intent: amount must be greater than zero")
+
+	require.NoError(t, (&PaymentIntentRequest{Amount: 100, Currency: "ghs"}).Validate())
+
+	err = (&ConnectionRequest{Mode: "prod"}).Validate()
+	require.ErrorAs(t, err, &validation)
+	require.Equal(t, []string{"provider", "mode", "credentials"}, fieldNames(validation.Fields))
+
+	err = (&SubscriptionRequest{CustomerID: "cus_1", Amount: 1000, Currency: "GHS", Interval: "fortnightly"}).Validate()
+	require.ErrorAs(t, err, &validation)
+	require.Equal(t, []string{"interval"}, fieldNames(validation.Fields))
+	require.NoError(t, (&SubscriptionRequest{CustomerID: "cus_1", PlanID: "plan_1"}).Validate())
+	err = (&SubscriptionRequest{CustomerID: "cus_1", PlanID: "plan_1", Amount: -1}).Validate()
+	require.ErrorAs(t, err, &validation)
+	require.Equal(t, []string{"amount"}, fieldNames(validation.Fields))
+	require.Contains(t, err.Error(), "amount must not be negative")
+}
+
+func TestCreateIntentValidatesBeforeSending(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	_, err := client.Payments.CreateIntent(context.Background(), &PaymentIntentRequest{Amount: -5, Currency: "GHS"})
+	var validation *ValidationError
+	require.ErrorAs(t, err, &validation)
+	require.Zero(t, calls)
+}
+
+func TestCreateRejectsNilRequests(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL), WithOrgDefaults())
+	ctx := context.Background()
+	var validation *ValidationError
+
+	_, err := client.Payments.CreateIntent(ctx, nil)
+	require.ErrorAs(t, err, &validation)
+	require.EqualError(t, err, "reevit: invalid payment intent: request body is required")
+	_, err = client.CheckoutSessions.Create(ctx, nil)
+	require.ErrorAs(t, err, &validation)
+	_, err = client.Connections.Create(ctx, nil)
+	require.ErrorAs(t, err, &validation)
+	_, err = client.Subscriptions.Create(ctx, nil)
+	require.ErrorAs(t, err, &validation)
+	_, err = client.Transfers.Create(ctx, nil)
+	require.ErrorAs(t, err, &validation)
+	require.Zero(t, calls)
+}
+
+func fieldNames(fields []FieldError) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Field
+	}
+	return names
+}
+
+func TestMobileMoneyPhones(t *testing.T) {
+	var validation *ValidationError
+	err := (&PaymentIntentRequest{Amount: 100, Currency: "GHS", Country: "GH", Method: MethodMobileMoney, Phone: "0991234567"}).Validate()
+	require.ErrorAs(t, err, &validation)
+	require.Equal(t, []string{"phone"}, fieldNames(validation.Fields))
+	err = (&PaymentIntentRequest{Amount: 100, Currency: "GHS", Method: MethodMobileMoney, Phone: "0241234567"}).Validate()
+	require.ErrorAs(t, err, &validation)
+	require.Contains(t, err.Error(), "country is required to validate phone")
+
+	err = (&TransferRequest{Amount: 100, Currency: "GHS", DestinationID: "acct_1", Recipient: &MobileMoneyRecipient{Phone: "0241234567", Country: "GH"}}).Validate()
+	require.ErrorAs(t, err, &validation)
+	require.Equal(t, []string{"recipient"}, fieldNames(validation.Fields))
+
+	var intent, transfer map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/transfers" {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&transfer))
+		} else {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&intent))
+		}
+		_, _ = w.Write([]byte(`{"id":"x"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("pfk_test", "org_1", WithBaseURL(server.URL))
+	req := &PaymentIntentRequest{Amount: 100, Currency: "GHS", Country: "GH", Method: MethodMobileMoney, Phone: "024 123 4567"}
+	_, err = client.Payments.CreateIntent(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "+233241234567", intent["phone"])
+	require.Equal(t, "024 123 4567", req.Phone)
+
+	_, err = client.Transfers.Create(context.Background(), &TransferRequest{Amount: 5000, Currency: "GHS", Recipient: &MobileMoneyRecipient{Phone: "0241234567", Country: "gh", Name: "Kofi"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"phone": "+233241234567", "country": "GH", "network": "mtn", "name": "Kofi"}, transfer["recipient"])
+}
@@ -0,0 +1,238 @@
+package reevit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WebhookEndpointsService handles webhook endpoint related methods of the Reevit API.
+//
+// Unlike the single org-level config of WebhooksService, an organization can have several
+// endpoints, each subscribed to its own event types and signing with its own secret.
+type WebhookEndpointsService service
+
+// WebhookEndpoint represents a webhook endpoint resource.
+type WebhookEndpoint struct {
+	ID          string   `json:"id"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Events      []string `json:"events"`
+	// Secret is the signing secret (whsec_...). It is only returned by Create and
+	// RotateSecret; store it then, as Get and List leave it empty.
+	Secret    string                 `json:"secret,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// WebhookEndpointRequest represents a webhook endpoint create payload.
+type WebhookEndpointRequest struct {
+	URL         string                 `json:"url"`
+	Description string                 `json:"description,omitempty"`
+	Events      []string               `json:"events,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WebhookEndpointUpdateRequest represents a webhook endpoint update payload. Status is
+// "enabled" or "disabled".
+type WebhookEndpointUpdateRequest struct {
+	URL         string                 `json:"url,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Events      []string               `json:"events,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WebhookSecretRotation is the result of rotating a webhook endpoint's signing secret.
+type WebhookSecretRotation struct {
+	EndpointID string `json:"endpoint_id"`
+	Secret     string `json:"secret"`
+	// PreviousSecretExpiresAt is when deliveries stop being signed with the old secret as
+	// well, leaving time to deploy the new one.
+	PreviousSecretExpiresAt time.Time `json:"previous_secret_expires_at"`
+}
+
+// WebhookDelivery is one attempt series at delivering an event to a webhook endpoint.
+type WebhookDelivery struct {
+	ID         string `json:"id"`
+	EndpointID string `json:"endpoint_id"`
+	EventID    string `json:"event_id"`
+	EventType  string `json:"event_type"`
+	// Status is "pending", "succeeded" or "failed"; failed deliveries are retried with
+	// backoff until NextRetryAt is nil.
+	Status       string `json:"status"`
+	AttemptCount int    `json:"attempt_count"`
+	// ResponseCode and ResponseBody are those of the last attempt. The body is truncated
+	// by Reevit.
+	ResponseCode int        `json:"response_code"`
+	ResponseBody string     `json:"response_body"`
+	Error        string     `json:"error"`
+	DurationMS   int64      `json:"duration_ms"`
+	NextRetryAt  *time.Time `json:"next_retry_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// WebhookDeliveryListOptions contains filters for webhook delivery listing.
+type WebhookDeliveryListOptions struct {
+	Limit         int
+	Offset        int
+	Status        string
+	EventType     string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// List returns webhook endpoints for the current org.
+//
+// API Docs: GET /v1/webhook-endpoints
+func (s *WebhookEndpointsService) List(ctx context.Context, opts ...RequestOption) ([]WebhookEndpoint, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/webhook-endpoints", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[WebhookEndpoint](s.client, raw, "endpoints")
+}
+
+// Create creates a webhook endpoint. The returned endpoint carries its signing secret.
+//
+// API Docs: POST /v1/webhook-endpoints
+func (s *WebhookEndpointsService) Create(ctx context.Context, req *WebhookEndpointRequest, opts ...RequestOption) (*WebhookEndpoint, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/webhook-endpoints", req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint WebhookEndpoint
+	if err := s.client.do(httpRequest, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+// Get fetches a webhook endpoint by ID.
+//
+// API Docs: GET /v1/webhook-endpoints/{id}
+func (s *WebhookEndpointsService) Get(ctx context.Context, endpointID string, opts ...RequestOption) (*WebhookEndpoint, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/webhook-endpoints/%s", endpointID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint WebhookEndpoint
+	if err := s.client.do(httpRequest, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+// Update updates a webhook endpoint.
+//
+// API Docs: PATCH /v1/webhook-endpoints/{id}
+func (s *WebhookEndpointsService) Update(ctx context.Context, endpointID string, req *WebhookEndpointUpdateRequest, opts ...RequestOption) (*WebhookEndpoint, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPatch, pathf("/v1/webhook-endpoints/%s", endpointID), req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint WebhookEndpoint
+	if err := s.client.do(httpRequest, &endpoint); err != nil {
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+// ImportByID fetches an existing webhook endpoint so it can be adopted by an external state
+// manager such as a Terraform provider.
+func (s *WebhookEndpointsService) ImportByID(ctx context.Context, endpointID string, opts ...RequestOption) (*WebhookEndpoint, error) {
+	return importByID(ctx, "webhook endpoint", endpointID, s.Get, opts...)
+}
+
+// Delete removes a webhook endpoint.
+//
+// API Docs: DELETE /v1/webhook-endpoints/{id}
+func (s *WebhookEndpointsService) Delete(ctx context.Context, endpointID string, opts ...RequestOption) error {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, pathf("/v1/webhook-endpoints/%s", endpointID), nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(httpRequest, nil)
+}
+
+// RotateSecret issues a new signing secret for a webhook endpoint. Deliveries are signed
+// with both secrets until PreviousSecretExpiresAt, so handlers can be updated without
+// rejecting webhooks.
+//
+// API Docs: POST /v1/webhook-endpoints/{id}/rotate-secret
+func (s *WebhookEndpointsService) RotateSecret(ctx context.Context, endpointID string, opts ...RequestOption) (*WebhookSecretRotation, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/webhook-endpoints/%s/rotate-secret", endpointID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rotation WebhookSecretRotation
+	if err := s.client.do(httpRequest, &rotation); err != nil {
+		return nil, err
+	}
+
+	return &rotation, nil
+}
+
+// ListDeliveries returns the deliveries made to a webhook endpoint, most recent first.
+// Filter on Status "failed" to find the deliveries to Redeliver.
+//
+// API Docs: GET /v1/webhook-endpoints/{id}/deliveries
+func (s *WebhookEndpointsService) ListDeliveries(ctx context.Context, endpointID string, options *WebhookDeliveryListOptions, opts ...RequestOption) ([]WebhookDelivery, error) {
+	values := url.Values{}
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "status", options.Status)
+		setString(values, "event_type", options.EventType)
+		setTime(values, "created_after", options.CreatedAfter)
+		setTime(values, "created_before", options.CreatedBefore)
+	}
+
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath(pathf("/v1/webhook-endpoints/%s/deliveries", endpointID), values), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.client.doRaw(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArrayResponse[WebhookDelivery](s.client, raw, "deliveries")
+}
+
+// Redeliver sends the event of a delivery to its endpoint again and returns the new
+// delivery. The original delivery is left as is.
+//
+// API Docs: POST /v1/webhook-deliveries/{id}/redeliver
+func (s *WebhookEndpointsService) Redeliver(ctx context.Context, deliveryID string, opts ...RequestOption) (*WebhookDelivery, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/webhook-deliveries/%s/redeliver", deliveryID), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery WebhookDelivery
+	if err := s.client.do(httpRequest, &delivery); err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
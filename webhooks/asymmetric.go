@@ -0,0 +1,197 @@
+package webhooks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidSignature is returned when an asymmetric signature does not verify.
+var ErrInvalidSignature = errors.New("webhooks: invalid signature")
+
+// KeyResolver resolves the public key identified by a JWS "kid" header.
+type KeyResolver interface {
+	ResolveKey(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// StaticKey is a KeyResolver that always returns the same key, for partners that
+// publish a single certificate instead of a JWKS.
+type StaticKey struct {
+	Key crypto.PublicKey
+}
+
+// ResolveKey returns the static key regardless of kid.
+func (s StaticKey) ResolveKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if s.Key == nil {
+		return nil, errors.New("webhooks: no key configured")
+	}
+	return s.Key, nil
+}
+
+// VerifyRSASHA256 verifies a base64 RSASSA-PKCS1-v1_5 SHA-256 signature over body.
+func VerifyRSASHA256(body []byte, signature string, key *rsa.PublicKey) error {
+	sig, err := decodeBase64(signature)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(body)
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyECDSASHA256 verifies a base64 ECDSA SHA-256 signature over body.
+// Both ASN.1 DER and fixed-size r||s encodings are accepted.
+func VerifyECDSASHA256(body []byte, signature string, key *ecdsa.PublicKey) error {
+	sig, err := decodeBase64(signature)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(body)
+	if !verifyECDSA(key, digest[:], sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+type jwsHeader struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid"`
+	B64  *bool    `json:"b64"`
+	Crit []string `json:"crit"`
+	Iat  int64    `json:"iat"`
+}
+
+// VerifyDetachedJWS verifies a JWS with a detached payload ("<header>..<signature>", RFC 7515
+// Appendix F) over body. The unencoded payload option (RFC 7797, "b64": false) is supported
+// when "b64" is listed in the "crit" header, as RFC 7797 requires. Supported algorithms are
+// RS256, PS256, ES256 (P-256 keys only) and ES384 (P-384 keys only).
+func VerifyDetachedJWS(ctx context.Context, body []byte, jws string, keys KeyResolver) error {
+	_, err := verifyDetachedJWS(ctx, body, jws, keys)
+	return err
+}
+
+func verifyDetachedJWS(ctx context.Context, body []byte, jws string, keys KeyResolver) (*jwsHeader, error) {
+	parts := strings.Split(strings.TrimSpace(jws), ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return nil, errors.New("webhooks: malformed detached JWS")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: malformed JWS header: %w", err)
+	}
+	var header jwsHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("webhooks: malformed JWS header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: malformed JWS signature: %w", err)
+	}
+
+	unencoded := header.B64 != nil && !*header.B64
+	critB64 := false
+	for _, name := range header.Crit {
+		if name != "b64" {
+			return nil, fmt.Errorf("webhooks: unsupported critical JWS header %q", name)
+		}
+		critB64 = true
+	}
+	if unencoded && !critB64 {
+		return nil, errors.New(`webhooks: JWS with "b64": false must list "b64" as critical`)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(body)
+	if unencoded {
+		payload = string(body)
+	}
+	signingInput := []byte(parts[0] + "." + payload)
+
+	key, err := keys.ResolveKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch header.Alg {
+	case "RS256", "PS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("webhooks: key %q is not an RSA key", header.Kid)
+		}
+		digest := sha256.Sum256(signingInput)
+		if header.Alg == "RS256" {
+			err = rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature)
+		} else {
+			err = rsa.VerifyPSS(rsaKey, crypto.SHA256, digest[:], signature, nil)
+		}
+		if err != nil {
+			return nil, ErrInvalidSignature
+		}
+	case "ES256", "ES384":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("webhooks: key %q is not an EC key", header.Kid)
+		}
+		// Each ES algorithm is defined for a single curve; accepting others would let an
+		// attacker pick a weaker combination than the partner signs with.
+		curve := "P-256"
+		if header.Alg == "ES384" {
+			curve = "P-384"
+		}
+		if ecKey.Curve == nil || ecKey.Curve.Params().Name != curve {
+			return nil, fmt.Errorf("webhooks: key %q cannot be used with %s", header.Kid, header.Alg)
+		}
+		var digest []byte
+		if header.Alg == "ES256" {
+			sum := sha256.Sum256(signingInput)
+			digest = sum[:]
+		} else {
+			sum := sha512.Sum384(signingInput)
+			digest = sum[:]
+		}
+		if !verifyECDSA(ecKey, digest, signature) {
+			return nil, ErrInvalidSignature
+		}
+	default:
+		return nil, fmt.Errorf("webhooks: unsupported JWS algorithm %q", header.Alg)
+	}
+
+	return &header, nil
+}
+
+func verifyECDSA(key *ecdsa.PublicKey, digest, signature []byte) bool {
+	size := (key.Curve.Params().BitSize + 7) / 8
+	if len(signature) == 2*size {
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	var parsed struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(signature, &parsed); err != nil {
+		return false
+	}
+	return ecdsa.Verify(key, digest, parsed.R, parsed.S)
+}
+
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := encoding.DecodeString(value); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, errors.New("webhooks: signature is not valid base64")
+}
@@ -0,0 +1,206 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyRSAAndECDSA(t *testing.T) {
+	body := []byte(`{"reference":"txn_1"}`)
+	digest := sha256.Sum256(body)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	require.NoError(t, VerifyRSASHA256(body, base64.StdEncoding.EncodeToString(rsaSig), &rsaKey.PublicKey))
+	require.ErrorIs(t, VerifyRSASHA256([]byte("tampered"), base64.StdEncoding.EncodeToString(rsaSig), &rsaKey.PublicKey), ErrInvalidSignature)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+	require.NoError(t, VerifyECDSASHA256(body, base64.StdEncoding.EncodeToString(ecSig), &ecKey.PublicKey))
+}
+
+func TestVerifyDetachedJWSWithJWKS(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwksBody, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "key_1",
+			"kty": "EC",
+			"crv": "P-256",
+			"use": "sig",
+			"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+		}},
+	})
+	require.NoError(t, err)
+
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_, _ = w.Write(jwksBody)
+	}))
+	defer server.Close()
+
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded"}`)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","kid":"key_1"}`))
+	digest := sha256.Sum256([]byte(header + "." + base64.RawURLEncoding.EncodeToString(body)))
+	r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+	require.NoError(t, err)
+	signature := append(fixed(r), fixed(s)...)
+	jws := header + ".." + base64.RawURLEncoding.EncodeToString(signature)
+
+	keys := NewJWKS(server.URL)
+	require.NoError(t, VerifyDetachedJWS(context.Background(), body, jws, keys))
+	require.NoError(t, VerifyDetachedJWS(context.Background(), body, jws, keys))
+	require.Equal(t, 1, fetches)
+
+	require.ErrorIs(t, VerifyDetachedJWS(context.Background(), []byte(`{}`), jws, keys), ErrInvalidSignature)
+	require.Error(t, VerifyDetachedJWS(context.Background(), body, "not-a-jws", keys))
+}
+
+func fixed(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, 32))
+}
+
+func signJWS(t *testing.T, key *ecdsa.PrivateKey, header string, body []byte) string {
+	t.Helper()
+	encodedHeader := base64.RawURLEncoding.EncodeToString([]byte(header))
+	digest := sha256.Sum256([]byte(encodedHeader + "." + base64.RawURLEncoding.EncodeToString(body)))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	return encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(append(fixed(r), fixed(s)...))
+}
+
+func TestJWSHandler(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keys := StaticKey{Key: &ecKey.PublicKey}
+
+	var received []string
+	handler := NewJWSHandler(keys, HandlerFuncs{Default: func(ctx context.Context, event *Event) error {
+		received = append(received, event.ID)
+		return nil
+	}})
+
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","data":{}}`)
+	deliver := func(jws string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		req.Header.Set(JWSHeader, jws)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	now := time.Now().Unix()
+	require.Equal(t, http.StatusOK, deliver(signJWS(t, ecKey, fmt.Sprintf(`{"alg":"ES256","kid":"k1","iat":%d}`, now), body)))
+	require.Equal(t, []string{"evt_1"}, received)
+
+	stale := signJWS(t, ecKey, fmt.Sprintf(`{"alg":"ES256","kid":"k1","iat":%d}`, now-3600), body)
+	require.Equal(t, http.StatusUnauthorized, deliver(stale))
+	require.ErrorIs(t, VerifyJWS(context.Background(), body, stale, keys), ErrStaleJWS)
+
+	undated := signJWS(t, ecKey, `{"alg":"ES256","kid":"k1"}`, body)
+	require.ErrorIs(t, VerifyJWS(context.Background(), body, undated, keys), ErrStaleJWS)
+
+	tampered := signJWS(t, ecKey, fmt.Sprintf(`{"alg":"ES256","kid":"k1","iat":%d}`, now), []byte(`{}`))
+	require.Equal(t, http.StatusUnauthorized, deliver(tampered))
+	require.Len(t, received, 1)
+}
+
+func TestVerifyDetachedJWSHeaders(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keys := StaticKey{Key: &p256.PublicKey}
+	body := []byte(`{"id":"evt_1"}`)
+
+	unencoded := func(header string) string {
+		encodedHeader := base64.RawURLEncoding.EncodeToString([]byte(header))
+		digest := sha256.Sum256([]byte(encodedHeader + "." + string(body)))
+		r, s, err := ecdsa.Sign(rand.Reader, p256, digest[:])
+		require.NoError(t, err)
+		return encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(append(fixed(r), fixed(s)...))
+	}
+	require.NoError(t, VerifyDetachedJWS(context.Background(), body, unencoded(`{"alg":"ES256","b64":false,"crit":["b64"]}`), keys))
+	require.ErrorContains(t, VerifyDetachedJWS(context.Background(), body, unencoded(`{"alg":"ES256","b64":false}`), keys), "critical")
+	require.ErrorContains(t, VerifyDetachedJWS(context.Background(), body, signJWS(t, p256, `{"alg":"ES256","crit":["exp"]}`, body), keys), `"exp"`)
+
+	// An ES384 header must not be verified with a P-256 key.
+	require.ErrorContains(t, VerifyDetachedJWS(context.Background(), body, signJWS(t, p256, `{"alg":"ES384"}`, body), keys), "cannot be used with ES384")
+}
+
+func TestJWKSFetchDoesNotBlockCachedKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwksBody, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": "key_1",
+			"kty": "EC",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(fixed(ecKey.X)),
+			"y":   base64.RawURLEncoding.EncodeToString(fixed(ecKey.Y)),
+		}},
+	})
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if fetches > 1 {
+			<-release
+		}
+		_, _ = w.Write(jwksBody)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	keys := NewJWKS(server.URL)
+	_, err = keys.ResolveKey(context.Background(), "key_1")
+	require.NoError(t, err)
+
+	// An unknown key ID past the refresh interval triggers a fetch, which the server holds.
+	keys.mu.Lock()
+	keys.fetchedAt = time.Now().Add(-2 * minJWKSRefresh)
+	keys.mu.Unlock()
+	go func() { _, _ = keys.ResolveKey(context.Background(), "key_2") }()
+	require.Eventually(t, func() bool {
+		keys.mu.Lock()
+		defer keys.mu.Unlock()
+		return keys.inflight != nil
+	}, time.Second, time.Millisecond)
+
+	resolved := make(chan error, 1)
+	go func() {
+		_, err := keys.ResolveKey(context.Background(), "key_1")
+		resolved <- err
+	}()
+	select {
+	case err := <-resolved:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("cached key lookup blocked on the JWKS fetch")
+	}
+}
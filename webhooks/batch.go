@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EventFailure records why a single event of a batch could not be processed.
+type EventFailure struct {
+	Index   int
+	EventID string
+	Err     error
+}
+
+// BatchError aggregates the failures of a batched delivery.
+type BatchError struct {
+	Failures []EventFailure
+}
+
+func (e *BatchError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		if failure.EventID != "" {
+			messages = append(messages, fmt.Sprintf("event %s: %v", failure.EventID, failure.Err))
+		} else {
+			messages = append(messages, fmt.Sprintf("event #%d: %v", failure.Index, failure.Err))
+		}
+	}
+	return fmt.Sprintf("webhooks: %d event(s) failed: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the individual event errors.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// IsBatch reports whether a body is a batched delivery (a JSON array of events).
+func IsBatch(body []byte) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// ParseEvents decodes a batched delivery. Events that fail to parse do not abort the batch:
+// they are returned as nil entries and reported in the returned *BatchError.
+// A single (non-array) event body is accepted and returned as a batch of one.
+func ParseEvents(body []byte) ([]*Event, error) {
+	if !IsBatch(body) {
+		event, err := ParseEvent(body)
+		if err != nil {
+			return nil, err
+		}
+		return []*Event{event}, nil
+	}
+
+	if err := checkDepth(body); err != nil {
+		return nil, err
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		return nil, newParseError(body, err, 0, "")
+	}
+
+	events := make([]*Event, len(raws))
+	var batchErr BatchError
+	for i, raw := range raws {
+		event, err := ParseEvent(raw)
+		if err != nil {
+			batchErr.Failures = append(batchErr.Failures, EventFailure{Index: i, EventID: peekEventID(raw), Err: err})
+			continue
+		}
+		events[i] = event
+	}
+	if len(batchErr.Failures) > 0 {
+		return events, &batchErr
+	}
+	return events, nil
+}
+
+// DispatchAll dispatches each event individually and aggregates failures into a *BatchError.
+// Nil events (entries that failed to parse) are skipped.
+func DispatchAll(ctx context.Context, dispatcher Dispatcher, events []*Event) error {
+	var batchErr BatchError
+	for i, event := range events {
+		if event == nil {
+			continue
+		}
+		if err := dispatcher.Dispatch(ctx, event); err != nil {
+			batchErr.Failures = append(batchErr.Failures, EventFailure{Index: i, EventID: event.ID, Err: err})
+		}
+	}
+	if len(batchErr.Failures) > 0 {
+		return &batchErr
+	}
+	return nil
+}
+
+func peekEventID(raw json.RawMessage) string {
+	var envelope struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(raw, &envelope)
+	return envelope.ID
+}
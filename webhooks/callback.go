@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+// maxMultipartMemory bounds the memory used for multipart parts when parsing callbacks.
+const maxMultipartMemory = 10 << 20
+
+// Callback is a provider callback decoded from JSON, form-encoded or multipart bodies.
+// Raw holds the exact bytes received, which is what provider signatures are computed over.
+type Callback struct {
+	ContentType string
+	Raw         []byte
+	// JSON is set for application/json bodies.
+	JSON json.RawMessage
+	// Fields holds form fields for form-encoded and multipart bodies.
+	Fields url.Values
+	// Files holds file parts of multipart bodies, keyed by form field name.
+	Files map[string][]CallbackFile
+}
+
+// CallbackFile is a file part of a multipart callback.
+type CallbackFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// ParseCallback decodes a raw callback body according to its Content-Type.
+// Signatures should be verified against Callback.Raw, never against re-encoded fields.
+func ParseCallback(contentType string, body []byte) (*Callback, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		if strings.TrimSpace(contentType) != "" {
+			return nil, fmt.Errorf("webhooks: invalid content type %q: %w", contentType, err)
+		}
+		mediaType = "application/json"
+	}
+
+	callback := &Callback{ContentType: mediaType, Raw: body}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if !json.Valid(body) {
+			return nil, errors.New("webhooks: invalid JSON callback body")
+		}
+		callback.JSON = json.RawMessage(body)
+	case mediaType == "application/x-www-form-urlencoded":
+		fields, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("webhooks: invalid form callback body: %w", err)
+		}
+		callback.Fields = fields
+	case mediaType == "multipart/form-data":
+		boundary := params["boundary"]
+		if boundary == "" {
+			return nil, errors.New("webhooks: multipart callback is missing a boundary")
+		}
+		form, err := multipart.NewReader(bytes.NewReader(body), boundary).ReadForm(maxMultipartMemory)
+		if err != nil {
+			return nil, fmt.Errorf("webhooks: invalid multipart callback body: %w", err)
+		}
+		defer form.RemoveAll()
+
+		callback.Fields = url.Values(form.Value)
+		callback.Files = map[string][]CallbackFile{}
+		for field, headers := range form.File {
+			for _, header := range headers {
+				file, err := header.Open()
+				if err != nil {
+					return nil, err
+				}
+				data, err := io.ReadAll(file)
+				file.Close()
+				if err != nil {
+					return nil, err
+				}
+				callback.Files[field] = append(callback.Files[field], CallbackFile{
+					Filename:    header.Filename,
+					ContentType: header.Header.Get("Content-Type"),
+					Data:        data,
+				})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("webhooks: unsupported callback content type %q", mediaType)
+	}
+
+	return callback, nil
+}
@@ -0,0 +1,44 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrBodyTooLarge is returned by ReadRawBody and ReadCallback for bodies larger than the
+// 1 MiB that Handler accepts.
+var ErrBodyTooLarge = fmt.Errorf("webhooks: body exceeds %d bytes", maxBodyBytes)
+
+// ReadRawBody reads the request body exactly as received and restores r.Body so that
+// r.ParseForm or r.ParseMultipartForm can still be used afterwards. Bodies larger than
+// 1 MiB are rejected with ErrBodyTooLarge; respond with 413 Request Entity Too Large.
+func ReadRawBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, ErrBodyTooLarge
+		}
+		return nil, err
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// ReadCallback captures the raw body of r and decodes it according to its Content-Type.
+func ReadCallback(r *http.Request) (*Callback, error) {
+	body, err := ReadRawBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCallback(r.Header.Get("Content-Type"), body)
+}
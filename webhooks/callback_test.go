@@ -0,0 +1,59 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCallbackForm(t *testing.T) {
+	body := "ResponseCode=0000&ClientReference=order_1&Amount=10.00"
+	req := httptest.NewRequest(http.MethodPost, "/callbacks/hubtel", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	callback, err := ReadCallback(req)
+	require.NoError(t, err)
+	require.Equal(t, body, string(callback.Raw))
+	require.Equal(t, "order_1", callback.Fields.Get("ClientReference"))
+	require.Equal(t, SignHubtel([]byte(body), "secret"), SignHubtel(callback.Raw, "secret"))
+
+	// The body is restored for standard library parsing.
+	require.NoError(t, req.ParseForm())
+	require.Equal(t, "0000", req.PostForm.Get("ResponseCode"))
+}
+
+func TestParseCallbackMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	require.NoError(t, writer.WriteField("reference", "txn_1"))
+	part, err := writer.CreateFormFile("receipt", "receipt.txt")
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("paid"))
+	require.NoError(t, writer.Close())
+
+	callback, err := ParseCallback(writer.FormDataContentType(), buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "txn_1", callback.Fields.Get("reference"))
+	require.Equal(t, "paid", string(callback.Files["receipt"][0].Data))
+
+	_, err = ParseCallback("text/plain", []byte("hello"))
+	require.Error(t, err)
+}
+
+func TestReadRawBodyTooLarge(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/callbacks/hubtel", strings.NewReader(strings.Repeat("a", maxBodyBytes+1)))
+	_, err := ReadRawBody(req)
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+
+	req = httptest.NewRequest(http.MethodPost, "/callbacks/hubtel", strings.NewReader(strings.Repeat("a", maxBodyBytes)))
+	body, err := ReadRawBody(req)
+	require.NoError(t, err)
+	require.Len(t, body, maxBodyBytes)
+}
@@ -0,0 +1,14 @@
+// Package webhooks verifies and decodes the webhooks Reevit sends, and the provider
+// callbacks relayed through it.
+//
+// The package depends on the standard library only and never on the API client in the
+// parent package, so services that only receive webhooks can import it without linking
+// the client, its services or any third-party module. Keep it that way: code shared with
+// the client belongs here, not the other way round.
+//
+// Signature verification, signing and event decoding also build for WebAssembly
+// (GOOS=js and GOOS=wasip1) and with TinyGo, for edge functions. Under TinyGo the parts
+// that need an HTTP stack (NewHandler, NewJWSHandler, ReadCallback, SourceFilter and the
+// remote JWKS resolver) are left out; those live in the *_http.go files behind the
+// !tinygo build tag.
+package webhooks
@@ -0,0 +1,115 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// maxNestingDepth bounds how deeply nested a webhook body may be before parsing is refused.
+const maxNestingDepth = 128
+
+// snippetRadius is the number of bytes shown on either side of a parse error offset.
+const snippetRadius = 20
+
+// ParseError describes why a webhook body could not be parsed.
+type ParseError struct {
+	// Offset is the byte offset in the body where the problem was detected.
+	Offset int64
+	// Field is the JSON path of the offending field, when known.
+	Field string
+	// Expected is the Go type that was expected at Field, when known.
+	Expected string
+	// Snippet is the part of the body surrounding Offset.
+	Snippet string
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Field != "" && e.Expected != "":
+		return fmt.Sprintf("webhooks: invalid event at offset %d: field %q should be %s near %q", e.Offset, e.Field, e.Expected, e.Snippet)
+	case e.Field != "":
+		return fmt.Sprintf("webhooks: invalid event: %s (field %q)", e.Err, e.Field)
+	default:
+		return fmt.Sprintf("webhooks: invalid event at offset %d: %s near %q", e.Offset, e.Err, e.Snippet)
+	}
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError converts a JSON decoding error into a ParseError. base is added to
+// offsets reported for a sub-document such as the event data.
+func newParseError(body []byte, err error, base int64, fieldPrefix string) *ParseError {
+	parseErr := &ParseError{Err: err}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		parseErr.Offset = base + syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		parseErr.Offset = base + typeErr.Offset
+		parseErr.Expected = typeErr.Type.String()
+		parseErr.Field = typeErr.Field
+		if fieldPrefix != "" {
+			parseErr.Field = fieldPrefix + "." + typeErr.Field
+		}
+	}
+	parseErr.Snippet = snippet(body, parseErr.Offset)
+
+	return parseErr
+}
+
+func snippet(body []byte, offset int64) string {
+	if len(body) == 0 {
+		return ""
+	}
+	start := offset - snippetRadius
+	if start < 0 {
+		start = 0
+	}
+	end := offset + snippetRadius
+	if end > int64(len(body)) {
+		end = int64(len(body))
+	}
+	if start > end {
+		start = end
+	}
+	return string(body[start:end])
+}
+
+// checkDepth rejects bodies nested deeper than maxNestingDepth without decoding them.
+func checkDepth(body []byte) *ParseError {
+	depth := 0
+	inString := false
+	escaped := false
+	for i, b := range body {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+		switch b {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > maxNestingDepth {
+				err := fmt.Errorf("nesting exceeds %d levels", maxNestingDepth)
+				return &ParseError{Offset: int64(i), Snippet: snippet(body, int64(i)), Err: err}
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return nil
+}
@@ -0,0 +1,411 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Event types delivered by Reevit outbound webhooks.
+const (
+	EventWebhookTest                     = "reevit.webhook.test"
+	EventPaymentSucceeded                = "payment.succeeded"
+	EventPaymentFailed                   = "payment.failed"
+	EventPaymentRefunded                 = "payment.refunded"
+	EventPaymentPending                  = "payment.pending"
+	EventPaymentScheduled                = "payment.scheduled"
+	EventPaymentExecuted                 = "payment.executed"
+	EventRefundCompleted                 = "refund.completed"
+	EventSubscriptionCreated             = "subscription.created"
+	EventSubscriptionUpdated             = "subscription.updated"
+	EventSubscriptionRenewed             = "subscription.renewed"
+	EventSubscriptionCanceled            = "subscription.canceled"
+	EventSubscriptionPaused              = "subscription.paused"
+	EventSubscriptionRenewalSucceeded    = "subscription.renewal_succeeded"
+	EventSubscriptionRenewalFailed       = "subscription.renewal_failed"
+	EventConnectionStatusChanged         = "connection.status_changed"
+	EventConnectionCredentialsExpiring   = "connection.credentials_expiring"
+	EventFraudBlocked                    = "fraud.blocked"
+	EventFraudReviewRequired             = "fraud.review_required"
+	EventPolicyUpdated                   = "policy.updated"
+	EventCustomerRedacted                = "customer.redacted"
+	EventCheckoutSessionViewed           = "checkout.session.viewed"
+	EventCheckoutSessionMethodSelected   = "checkout.session.method_selected"
+	EventCheckoutSessionAbandoned        = "checkout.session.abandoned"
+	EventTransferScheduleExecuted        = "transfer_schedule.executed"
+	EventTransferScheduleExecutionFailed = "transfer_schedule.execution_failed"
+)
+
+// Event is a decoded Reevit webhook. Type is the discriminator for Data, which holds a
+// pointer to the typed payload (for example *PaymentSucceededEvent for payment.succeeded).
+// Data is nil for event types this package does not know yet; RawData is always populated.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	OrgID     string    `json:"org_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Message   string    `json:"message,omitempty"`
+	// APIVersion is the payload version the event was sent with. Data is always
+	// up-converted to the structs of LatestAPIVersion.
+	APIVersion string          `json:"api_version,omitempty"`
+	RawData    json.RawMessage `json:"data,omitempty"`
+	Data       interface{}     `json:"-"`
+}
+
+// Payment is the payment snapshot included in payment events.
+type Payment struct {
+	ID           string                 `json:"id"`
+	Status       string                 `json:"status"`
+	Amount       int64                  `json:"amount"`
+	Currency     string                 `json:"currency"`
+	Method       string                 `json:"method,omitempty"`
+	Provider     string                 `json:"provider"`
+	ConnectionID string                 `json:"connection_id,omitempty"`
+	CustomerID   string                 `json:"customer_id,omitempty"`
+	Reference    string                 `json:"reference,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Subscription is the full subscription included in subscription events.
+type Subscription struct {
+	ID            string                 `json:"id"`
+	OrgID         string                 `json:"org_id,omitempty"`
+	CustomerID    string                 `json:"customer_id"`
+	PlanID        string                 `json:"plan_id"`
+	Status        string                 `json:"status"`
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Method        string                 `json:"method,omitempty"`
+	Interval      string                 `json:"interval"`
+	NextRenewalAt string                 `json:"next_renewal_at,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt     string                 `json:"created_at,omitempty"`
+	UpdatedAt     string                 `json:"updated_at,omitempty"`
+}
+
+// Refund is the refund snapshot included in refund events.
+type Refund struct {
+	ID         string                 `json:"id"`
+	PaymentID  string                 `json:"payment_id"`
+	Status     string                 `json:"status"`
+	Amount     int64                  `json:"amount"`
+	Currency   string                 `json:"currency"`
+	Reason     string                 `json:"reason,omitempty"`
+	ReasonCode string                 `json:"reason_code,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PaymentSucceededEvent is the payload of a payment.succeeded event.
+type PaymentSucceededEvent struct {
+	Payment
+}
+
+// PaymentFailedEvent is the payload of a payment.failed event.
+type PaymentFailedEvent struct {
+	Payment
+	FailureCode    string `json:"failure_code,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+}
+
+// PaymentRefundedEvent is the payload of a payment.refunded event.
+type PaymentRefundedEvent struct {
+	Payment
+}
+
+// PaymentPendingEvent is the payload of a payment.pending event.
+type PaymentPendingEvent struct {
+	Payment
+}
+
+// PaymentScheduledEvent is the payload of a payment.scheduled event, sent when a payment
+// intent with schedule_at is accepted.
+type PaymentScheduledEvent struct {
+	Payment
+	ScheduledAt string `json:"scheduled_at"`
+}
+
+// PaymentExecutedEvent is the payload of a payment.executed event, sent when a scheduled
+// payment is charged. Status reports the outcome of the charge.
+type PaymentExecutedEvent struct {
+	Payment
+	ScheduledAt    string `json:"scheduled_at"`
+	ExecutedAt     string `json:"executed_at"`
+	FailureCode    string `json:"failure_code,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+}
+
+// RefundCompletedEvent is the payload of a refund.completed event.
+type RefundCompletedEvent struct {
+	Refund
+}
+
+// SubscriptionCreatedEvent is the payload of a subscription.created event.
+type SubscriptionCreatedEvent struct {
+	Subscription
+}
+
+// SubscriptionUpdatedEvent is the payload of a subscription.updated event.
+type SubscriptionUpdatedEvent struct {
+	Subscription
+	// PreviousAttributes holds the previous values of the fields that changed.
+	PreviousAttributes map[string]interface{} `json:"previous_attributes,omitempty"`
+}
+
+// SubscriptionRenewedEvent is the payload of a subscription.renewed event.
+type SubscriptionRenewedEvent struct {
+	Subscription
+}
+
+// SubscriptionCanceledEvent is the payload of a subscription.canceled event.
+type SubscriptionCanceledEvent struct {
+	Subscription
+	CancellationReason string `json:"cancellation_reason,omitempty"`
+}
+
+// SubscriptionPausedEvent is the payload of a subscription.paused event.
+type SubscriptionPausedEvent struct {
+	Subscription
+	ResumesAt string `json:"resumes_at,omitempty"`
+}
+
+// SubscriptionRenewalSucceededEvent is the payload of a subscription.renewal_succeeded event.
+type SubscriptionRenewalSucceededEvent struct {
+	Subscription
+	PaymentID string `json:"payment_id"`
+	InvoiceID string `json:"invoice_id,omitempty"`
+}
+
+// SubscriptionRenewalFailedEvent is the payload of a subscription.renewal_failed event.
+type SubscriptionRenewalFailedEvent struct {
+	Subscription
+	PaymentID      string `json:"payment_id,omitempty"`
+	InvoiceID      string `json:"invoice_id,omitempty"`
+	FailureCode    string `json:"failure_code,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+	NextRetryAt    string `json:"next_retry_at,omitempty"`
+}
+
+// Connection is the PSP connection snapshot included in connection events.
+type Connection struct {
+	ID       string   `json:"id"`
+	Provider string   `json:"provider"`
+	Mode     string   `json:"mode"`
+	Status   string   `json:"status"`
+	Labels   []string `json:"labels,omitempty"`
+}
+
+// ConnectionStatusChangedEvent is the payload of a connection.status_changed event,
+// sent when a connection moves between states such as active, degraded and disabled.
+type ConnectionStatusChangedEvent struct {
+	Connection
+	PreviousStatus string `json:"previous_status"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// ConnectionCredentialsExpiringEvent is the payload of a connection.credentials_expiring
+// event, sent ahead of the expiry of a connection's provider credentials.
+type ConnectionCredentialsExpiringEvent struct {
+	Connection
+	CredentialType string `json:"credential_type,omitempty"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+// FraudRule identifies the fraud rule that triggered a fraud event.
+type FraudRule struct {
+	Name      string `json:"name"`
+	Type      string `json:"type,omitempty"`
+	Threshold int64  `json:"threshold,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+// FraudBlockedEvent is the payload of a fraud.blocked event, sent when a payment is
+// rejected by the organization's fraud policy.
+type FraudBlockedEvent struct {
+	Rule    FraudRule `json:"rule"`
+	Payment Payment   `json:"payment"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// FraudReviewRequiredEvent is the payload of a fraud.review_required event, sent when a
+// payment is held for manual review instead of being blocked outright.
+type FraudReviewRequiredEvent struct {
+	Rule      FraudRule `json:"rule"`
+	Payment   Payment   `json:"payment"`
+	Reason    string    `json:"reason,omitempty"`
+	RiskScore float64   `json:"risk_score,omitempty"`
+}
+
+// FraudPolicy is the fraud policy snapshot included in policy events.
+type FraudPolicy struct {
+	Prefer               []string `json:"prefer,omitempty"`
+	MaxAmount            int64    `json:"max_amount,omitempty"`
+	BlockedBins          []string `json:"blocked_bins,omitempty"`
+	AllowedBins          []string `json:"allowed_bins,omitempty"`
+	VelocityMaxPerMinute int      `json:"velocity_max_per_minute,omitempty"`
+}
+
+// PolicyUpdatedEvent is the payload of a policy.updated event.
+type PolicyUpdatedEvent struct {
+	Policy         FraudPolicy `json:"policy"`
+	PreviousPolicy FraudPolicy `json:"previous_policy"`
+	UpdatedBy      string      `json:"updated_by,omitempty"`
+}
+
+// CustomerRedactedEvent is the payload of a customer.redacted event, sent once a
+// deletion request has been processed and the customer's personal data erased.
+type CustomerRedactedEvent struct {
+	CustomerID        string `json:"customer_id"`
+	ExternalID        string `json:"external_id,omitempty"`
+	DeletionRequestID string `json:"deletion_request_id"`
+	RedactedAt        string `json:"redacted_at"`
+}
+
+// CheckoutSession is the checkout session snapshot included in checkout analytics events.
+// These events are only sent to organizations that enabled checkout analytics.
+type CheckoutSession struct {
+	ID            string                 `json:"id"`
+	PaymentLinkID string                 `json:"payment_link_id,omitempty"`
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Country       string                 `json:"country,omitempty"`
+	CustomerID    string                 `json:"customer_id,omitempty"`
+	Reference     string                 `json:"reference,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt     string                 `json:"created_at,omitempty"`
+}
+
+// CheckoutSessionViewedEvent is the payload of a checkout.session.viewed event, sent when
+// the customer first opens the checkout page.
+type CheckoutSessionViewedEvent struct {
+	CheckoutSession
+	ViewedAt  string `json:"viewed_at"`
+	Device    string `json:"device,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	Referrer  string `json:"referrer,omitempty"`
+}
+
+// CheckoutSessionMethodSelectedEvent is the payload of a checkout.session.method_selected
+// event, sent each time the customer picks a payment method.
+type CheckoutSessionMethodSelectedEvent struct {
+	CheckoutSession
+	Method         string `json:"method"`
+	PreviousMethod string `json:"previous_method,omitempty"`
+	SelectedAt     string `json:"selected_at"`
+}
+
+// CheckoutSessionAbandonedEvent is the payload of a checkout.session.abandoned event,
+// sent when a session expires or is closed without a successful payment.
+type CheckoutSessionAbandonedEvent struct {
+	CheckoutSession
+	// LastStep is the furthest funnel step reached, e.g. "viewed" or "method_selected".
+	LastStep    string `json:"last_step"`
+	LastMethod  string `json:"last_method,omitempty"`
+	PaymentID   string `json:"payment_id,omitempty"`
+	AbandonedAt string `json:"abandoned_at"`
+}
+
+// Transfer is the transfer snapshot included in transfer events.
+type Transfer struct {
+	ID            string                 `json:"id"`
+	DestinationID string                 `json:"destination_id"`
+	Amount        int64                  `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Status        string                 `json:"status"`
+	Reference     string                 `json:"reference,omitempty"`
+	ScheduleID    string                 `json:"schedule_id,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TransferScheduleExecutedEvent is the payload of a transfer_schedule.executed event, sent
+// for every successful run of a recurring transfer schedule.
+type TransferScheduleExecutedEvent struct {
+	ScheduleID  string   `json:"schedule_id"`
+	ExecutionID string   `json:"execution_id"`
+	Transfer    Transfer `json:"transfer"`
+	Executions  int      `json:"executions"`
+	NextRunAt   string   `json:"next_run_at,omitempty"`
+}
+
+// TransferScheduleExecutionFailedEvent is the payload of a
+// transfer_schedule.execution_failed event, sent when a run could not be executed, for
+// example because the balance was insufficient.
+type TransferScheduleExecutionFailedEvent struct {
+	ScheduleID     string `json:"schedule_id"`
+	ExecutionID    string `json:"execution_id"`
+	ScheduledFor   string `json:"scheduled_for"`
+	FailureCode    string `json:"failure_code"`
+	FailureMessage string `json:"failure_message,omitempty"`
+	NextRunAt      string `json:"next_run_at,omitempty"`
+}
+
+// eventPayloads maps each known event type to a constructor for its typed payload.
+var eventPayloads = map[string]func() interface{}{
+	EventPaymentSucceeded:                func() interface{} { return &PaymentSucceededEvent{} },
+	EventPaymentFailed:                   func() interface{} { return &PaymentFailedEvent{} },
+	EventPaymentRefunded:                 func() interface{} { return &PaymentRefundedEvent{} },
+	EventPaymentPending:                  func() interface{} { return &PaymentPendingEvent{} },
+	EventPaymentScheduled:                func() interface{} { return &PaymentScheduledEvent{} },
+	EventPaymentExecuted:                 func() interface{} { return &PaymentExecutedEvent{} },
+	EventRefundCompleted:                 func() interface{} { return &RefundCompletedEvent{} },
+	EventSubscriptionCreated:             func() interface{} { return &SubscriptionCreatedEvent{} },
+	EventSubscriptionUpdated:             func() interface{} { return &SubscriptionUpdatedEvent{} },
+	EventSubscriptionRenewed:             func() interface{} { return &SubscriptionRenewedEvent{} },
+	EventSubscriptionCanceled:            func() interface{} { return &SubscriptionCanceledEvent{} },
+	EventSubscriptionPaused:              func() interface{} { return &SubscriptionPausedEvent{} },
+	EventSubscriptionRenewalSucceeded:    func() interface{} { return &SubscriptionRenewalSucceededEvent{} },
+	EventSubscriptionRenewalFailed:       func() interface{} { return &SubscriptionRenewalFailedEvent{} },
+	EventConnectionStatusChanged:         func() interface{} { return &ConnectionStatusChangedEvent{} },
+	EventConnectionCredentialsExpiring:   func() interface{} { return &ConnectionCredentialsExpiringEvent{} },
+	EventFraudBlocked:                    func() interface{} { return &FraudBlockedEvent{} },
+	EventFraudReviewRequired:             func() interface{} { return &FraudReviewRequiredEvent{} },
+	EventPolicyUpdated:                   func() interface{} { return &PolicyUpdatedEvent{} },
+	EventCustomerRedacted:                func() interface{} { return &CustomerRedactedEvent{} },
+	EventCheckoutSessionViewed:           func() interface{} { return &CheckoutSessionViewedEvent{} },
+	EventCheckoutSessionMethodSelected:   func() interface{} { return &CheckoutSessionMethodSelectedEvent{} },
+	EventCheckoutSessionAbandoned:        func() interface{} { return &CheckoutSessionAbandonedEvent{} },
+	EventTransferScheduleExecuted:        func() interface{} { return &TransferScheduleExecutedEvent{} },
+	EventTransferScheduleExecutionFailed: func() interface{} { return &TransferScheduleExecutionFailedEvent{} },
+}
+
+// ParseEvent decodes a raw webhook body into an Event with a typed payload.
+// The signature should be verified before parsing. Malformed bodies are reported
+// as a *ParseError describing where decoding failed.
+func ParseEvent(body []byte) (*Event, error) {
+	if err := checkDepth(body); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, newParseError(body, err, 0, "")
+	}
+	if event.Type == "" {
+		return nil, &ParseError{Field: "type", Expected: "string", Snippet: snippet(body, 0), Err: errors.New("event type is missing")}
+	}
+
+	if len(event.RawData) == 0 || string(event.RawData) == "null" {
+		return &event, nil
+	}
+
+	var payload interface{}
+	var err error
+	if decode, ok := lookupPayloadDecoder(event.APIVersion, event.Type); ok {
+		payload, err = decode(event.RawData)
+	} else if newPayload, ok := eventPayloads[event.Type]; ok {
+		payload = newPayload()
+		err = json.Unmarshal(event.RawData, payload)
+	} else {
+		return &event, nil
+	}
+	if err != nil {
+		base := int64(bytes.Index(body, event.RawData))
+		if base < 0 {
+			base = 0
+		}
+		return nil, newParseError(body, err, base, "data")
+	}
+	event.Data = payload
+
+	return &event, nil
+}
@@ -0,0 +1,217 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"payment.failed","org_id":"org_1","created_at":"2024-05-01T10:00:00Z","data":{"id":"pay_1","status":"failed","amount":5000,"currency":"GHS","provider":"paystack","failure_code":"insufficient_funds"}}`)
+
+	event, err := ParseEvent(body)
+	require.NoError(t, err)
+	require.Equal(t, EventPaymentFailed, event.Type)
+	require.Equal(t, "org_1", event.OrgID)
+
+	data, ok := event.Data.(*PaymentFailedEvent)
+	require.True(t, ok)
+	require.Equal(t, "pay_1", data.ID)
+	require.Equal(t, int64(5000), data.Amount)
+	require.Equal(t, "insufficient_funds", data.FailureCode)
+}
+
+func TestParseEventUnknownType(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_2","type":"payment.teleported","data":{"id":"pay_1"}}`))
+	require.NoError(t, err)
+	require.Nil(t, event.Data)
+	require.JSONEq(t, `{"id":"pay_1"}`, string(event.RawData))
+
+	_, err = ParseEvent([]byte(`{"id":"evt_3"}`))
+	require.Error(t, err)
+}
+
+func TestParseEventErrors(t *testing.T) {
+	var parseErr *ParseError
+
+	_, err := ParseEvent([]byte(`{"id":"evt_1","type":"payment.succeeded","data":{"amount":"5000"}}`))
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, "data.amount", parseErr.Field)
+	require.Equal(t, "int64", parseErr.Expected)
+	require.Contains(t, parseErr.Snippet, `"5000"`)
+
+	_, err = ParseEvent([]byte(`{"id":"evt_1",,}`))
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, int64(15), parseErr.Offset)
+
+	_, err = ParseEvent([]byte(strings.Repeat("[", 10000)))
+	require.ErrorAs(t, err, &parseErr)
+	require.Contains(t, parseErr.Error(), "nesting")
+}
+
+func FuzzParseEvent(f *testing.F) {
+	f.Add([]byte(`{"id":"evt_1","type":"payment.succeeded","data":{"id":"pay_1","amount":100}}`))
+	f.Add([]byte(`{"id":"evt_2","type":"customer.redacted","data":null}`))
+	f.Add([]byte(`{"type":"payment.failed","data":{"metadata":{"a":[[[{"b":"\"}"}]]]}}}`))
+	f.Add([]byte(`[`))
+	f.Add([]byte(``))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		event, err := ParseEvent(body)
+		if err != nil {
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			require.LessOrEqual(t, parseErr.Offset, int64(len(body)))
+			return
+		}
+		require.NotEmpty(t, event.Type)
+	})
+}
+
+func TestParseEventLegacyVersion(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"payment.failed","api_version":"2024-06-01","data":{"payment_id":"pay_1","psp":"hubtel","amount":100,"error_code":"timeout"}}`)
+
+	event, err := ParseEvent(body)
+	require.NoError(t, err)
+	data, ok := event.Data.(*PaymentFailedEvent)
+	require.True(t, ok)
+	require.Equal(t, "pay_1", data.ID)
+	require.Equal(t, "hubtel", data.Provider)
+	require.Equal(t, "timeout", data.FailureCode)
+
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"subscription.renewed","api_version":"2024-06-01","data":{"subscription_id":"sub_1","next_billing_at":"2024-07-01"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "sub_1", event.Data.(*SubscriptionRenewedEvent).ID)
+	require.Equal(t, "2024-07-01", event.Data.(*SubscriptionRenewedEvent).NextRenewalAt)
+}
+
+func TestParseSubscriptionEvents(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"subscription.renewal_failed","data":{"id":"sub_1","org_id":"org_1","status":"past_due","amount":2500,"failure_code":"insufficient_funds","next_retry_at":"2025-02-01T00:00:00Z"}}`))
+	require.NoError(t, err)
+	failed, ok := event.Data.(*SubscriptionRenewalFailedEvent)
+	require.True(t, ok)
+	require.Equal(t, "sub_1", failed.ID)
+	require.Equal(t, "org_1", failed.OrgID)
+	require.Equal(t, "insufficient_funds", failed.FailureCode)
+
+	var paused *SubscriptionPausedEvent
+	dispatcher := HandlerFuncs{
+		SubscriptionPaused: func(ctx context.Context, event *Event, data *SubscriptionPausedEvent) error {
+			paused = data
+			return nil
+		},
+	}
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"subscription.paused","data":{"id":"sub_1","resumes_at":"2025-03-01"}}`))
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	require.Equal(t, "2025-03-01", paused.ResumesAt)
+}
+
+func TestParseConnectionEvents(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"connection.status_changed","data":{"id":"conn_1","provider":"paystack","status":"degraded","previous_status":"active","reason":"elevated_error_rate"}}`))
+	require.NoError(t, err)
+	changed, ok := event.Data.(*ConnectionStatusChangedEvent)
+	require.True(t, ok)
+	require.Equal(t, "conn_1", changed.ID)
+	require.Equal(t, "degraded", changed.Status)
+	require.Equal(t, "active", changed.PreviousStatus)
+
+	var expiring *ConnectionCredentialsExpiringEvent
+	dispatcher := HandlerFuncs{
+		ConnectionCredentialsExpiring: func(ctx context.Context, event *Event, data *ConnectionCredentialsExpiringEvent) error {
+			expiring = data
+			return nil
+		},
+	}
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"connection.credentials_expiring","data":{"id":"conn_1","provider":"mpesa","expires_at":"2025-03-01T00:00:00Z"}}`))
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	require.Equal(t, "2025-03-01T00:00:00Z", expiring.ExpiresAt)
+}
+
+func TestParseFraudEvents(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"fraud.blocked","data":{"rule":{"name":"max_amount","threshold":500000},"payment":{"id":"pay_1","amount":750000,"currency":"GHS","provider":"paystack"}}}`))
+	require.NoError(t, err)
+	blocked, ok := event.Data.(*FraudBlockedEvent)
+	require.True(t, ok)
+	require.Equal(t, "max_amount", blocked.Rule.Name)
+	require.Equal(t, int64(500000), blocked.Rule.Threshold)
+	require.Equal(t, "pay_1", blocked.Payment.ID)
+
+	var updated *PolicyUpdatedEvent
+	dispatcher := HandlerFuncs{
+		PolicyUpdated: func(ctx context.Context, event *Event, data *PolicyUpdatedEvent) error {
+			updated = data
+			return nil
+		},
+	}
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"policy.updated","data":{"policy":{"max_amount":1000},"previous_policy":{"max_amount":500}}}`))
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	require.Equal(t, int64(1000), updated.Policy.MaxAmount)
+	require.Equal(t, int64(500), updated.PreviousPolicy.MaxAmount)
+}
+
+func TestParseCheckoutAnalyticsEvents(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"checkout.session.method_selected","data":{"id":"cs_1","amount":5000,"currency":"GHS","method":"momo","previous_method":"card","selected_at":"2025-03-01T10:00:00Z"}}`))
+	require.NoError(t, err)
+	selected, ok := event.Data.(*CheckoutSessionMethodSelectedEvent)
+	require.True(t, ok)
+	require.Equal(t, "cs_1", selected.ID)
+	require.Equal(t, "momo", selected.Method)
+	require.Equal(t, "card", selected.PreviousMethod)
+
+	var abandoned *CheckoutSessionAbandonedEvent
+	dispatcher := HandlerFuncs{
+		CheckoutSessionAbandoned: func(ctx context.Context, event *Event, data *CheckoutSessionAbandonedEvent) error {
+			abandoned = data
+			return nil
+		},
+	}
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"checkout.session.abandoned","data":{"id":"cs_1","amount":5000,"currency":"GHS","last_step":"method_selected","last_method":"momo","abandoned_at":"2025-03-01T10:30:00Z"}}`))
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	require.Equal(t, "method_selected", abandoned.LastStep)
+	require.Equal(t, "momo", abandoned.LastMethod)
+}
+
+func TestParseScheduledPaymentEvents(t *testing.T) {
+	var executed *PaymentExecutedEvent
+	dispatcher := HandlerFuncs{
+		PaymentExecuted: func(ctx context.Context, event *Event, data *PaymentExecutedEvent) error {
+			executed = data
+			return nil
+		},
+	}
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"payment.executed","data":{"id":"pay_1","status":"succeeded","amount":5000,"currency":"GHS","provider":"paystack","scheduled_at":"2025-03-01T09:00:00Z","executed_at":"2025-03-01T09:00:02Z"}}`))
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	require.Equal(t, "pay_1", executed.ID)
+	require.Equal(t, "2025-03-01T09:00:02Z", executed.ExecutedAt)
+
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"payment.scheduled","data":{"id":"pay_1","status":"scheduled","scheduled_at":"2025-03-01T09:00:00Z"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "2025-03-01T09:00:00Z", event.Data.(*PaymentScheduledEvent).ScheduledAt)
+}
+
+func TestParseTransferScheduleEvents(t *testing.T) {
+	var failed *TransferScheduleExecutionFailedEvent
+	dispatcher := HandlerFuncs{
+		TransferScheduleExecutionFailed: func(ctx context.Context, event *Event, data *TransferScheduleExecutionFailedEvent) error {
+			failed = data
+			return nil
+		},
+	}
+	event, err := ParseEvent([]byte(`{"id":"evt_1","type":"transfer_schedule.execution_failed","data":{"schedule_id":"tsch_1","execution_id":"tsex_3","scheduled_for":"2025-03-07T08:00:00Z","failure_code":"insufficient_balance"}}`))
+	require.NoError(t, err)
+	require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	require.Equal(t, "insufficient_balance", failed.FailureCode)
+
+	event, err = ParseEvent([]byte(`{"id":"evt_2","type":"transfer_schedule.executed","data":{"schedule_id":"tsch_1","execution_id":"tsex_4","executions":4,"transfer":{"id":"tr_1","destination_id":"acct_9","amount":250000,"currency":"GHS","status":"pending"}}}`))
+	require.NoError(t, err)
+	executed := event.Data.(*TransferScheduleExecutedEvent)
+	require.Equal(t, "acct_9", executed.Transfer.DestinationID)
+	require.Equal(t, 4, executed.Executions)
+}
@@ -0,0 +1,37 @@
+// Command wasm verifies a Reevit webhook read from standard input, the way an edge
+// function compiled to WebAssembly would. CI builds it for js/wasm, wasip1 and TinyGo to
+// keep the webhooks package portable:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -o verify.wasm ./webhooks/examples/wasm
+//	tinygo build -target=wasi -o verify.wasm ./webhooks/examples/wasm
+//
+// The signature and secret are read from REEVIT_SIGNATURE and REEVIT_WEBHOOK_SECRET.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Reevit-Platform/go-sdk/webhooks"
+)
+
+func main() {
+	body, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fail(err)
+	}
+	if !webhooks.VerifySignature(body, os.Getenv("REEVIT_SIGNATURE"), os.Getenv("REEVIT_WEBHOOK_SECRET")) {
+		fail(fmt.Errorf("invalid signature"))
+	}
+	event, err := webhooks.ParseEvent(body)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Println(event.Type, event.ID)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
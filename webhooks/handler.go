@@ -0,0 +1,158 @@
+package webhooks
+
+import "context"
+
+// maxBodyBytes caps the size of webhook bodies read by Handler and ReadRawBody.
+const maxBodyBytes = 1 << 20
+
+// Dispatcher routes a parsed event to application code.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event *Event) error
+}
+
+// HandlerFuncs is a Dispatcher with one optional callback per event type.
+// Events without a matching callback go to Default, or are acknowledged and ignored when Default is nil.
+type HandlerFuncs struct {
+	WebhookTest                     func(ctx context.Context, event *Event) error
+	PaymentSucceeded                func(ctx context.Context, event *Event, data *PaymentSucceededEvent) error
+	PaymentFailed                   func(ctx context.Context, event *Event, data *PaymentFailedEvent) error
+	PaymentRefunded                 func(ctx context.Context, event *Event, data *PaymentRefundedEvent) error
+	PaymentPending                  func(ctx context.Context, event *Event, data *PaymentPendingEvent) error
+	PaymentScheduled                func(ctx context.Context, event *Event, data *PaymentScheduledEvent) error
+	PaymentExecuted                 func(ctx context.Context, event *Event, data *PaymentExecutedEvent) error
+	RefundCompleted                 func(ctx context.Context, event *Event, data *RefundCompletedEvent) error
+	SubscriptionCreated             func(ctx context.Context, event *Event, data *SubscriptionCreatedEvent) error
+	SubscriptionUpdated             func(ctx context.Context, event *Event, data *SubscriptionUpdatedEvent) error
+	SubscriptionRenewed             func(ctx context.Context, event *Event, data *SubscriptionRenewedEvent) error
+	SubscriptionCanceled            func(ctx context.Context, event *Event, data *SubscriptionCanceledEvent) error
+	SubscriptionPaused              func(ctx context.Context, event *Event, data *SubscriptionPausedEvent) error
+	SubscriptionRenewalSucceeded    func(ctx context.Context, event *Event, data *SubscriptionRenewalSucceededEvent) error
+	SubscriptionRenewalFailed       func(ctx context.Context, event *Event, data *SubscriptionRenewalFailedEvent) error
+	ConnectionStatusChanged         func(ctx context.Context, event *Event, data *ConnectionStatusChangedEvent) error
+	ConnectionCredentialsExpiring   func(ctx context.Context, event *Event, data *ConnectionCredentialsExpiringEvent) error
+	FraudBlocked                    func(ctx context.Context, event *Event, data *FraudBlockedEvent) error
+	FraudReviewRequired             func(ctx context.Context, event *Event, data *FraudReviewRequiredEvent) error
+	PolicyUpdated                   func(ctx context.Context, event *Event, data *PolicyUpdatedEvent) error
+	CustomerRedacted                func(ctx context.Context, event *Event, data *CustomerRedactedEvent) error
+	CheckoutSessionViewed           func(ctx context.Context, event *Event, data *CheckoutSessionViewedEvent) error
+	CheckoutSessionMethodSelected   func(ctx context.Context, event *Event, data *CheckoutSessionMethodSelectedEvent) error
+	CheckoutSessionAbandoned        func(ctx context.Context, event *Event, data *CheckoutSessionAbandonedEvent) error
+	TransferScheduleExecuted        func(ctx context.Context, event *Event, data *TransferScheduleExecutedEvent) error
+	TransferScheduleExecutionFailed func(ctx context.Context, event *Event, data *TransferScheduleExecutionFailedEvent) error
+	Default                         func(ctx context.Context, event *Event) error
+}
+
+// Dispatch calls the callback registered for the event type.
+func (h HandlerFuncs) Dispatch(ctx context.Context, event *Event) error {
+	switch data := event.Data.(type) {
+	case *PaymentSucceededEvent:
+		if h.PaymentSucceeded != nil {
+			return h.PaymentSucceeded(ctx, event, data)
+		}
+	case *PaymentFailedEvent:
+		if h.PaymentFailed != nil {
+			return h.PaymentFailed(ctx, event, data)
+		}
+	case *PaymentRefundedEvent:
+		if h.PaymentRefunded != nil {
+			return h.PaymentRefunded(ctx, event, data)
+		}
+	case *PaymentPendingEvent:
+		if h.PaymentPending != nil {
+			return h.PaymentPending(ctx, event, data)
+		}
+	case *PaymentScheduledEvent:
+		if h.PaymentScheduled != nil {
+			return h.PaymentScheduled(ctx, event, data)
+		}
+	case *PaymentExecutedEvent:
+		if h.PaymentExecuted != nil {
+			return h.PaymentExecuted(ctx, event, data)
+		}
+	case *RefundCompletedEvent:
+		if h.RefundCompleted != nil {
+			return h.RefundCompleted(ctx, event, data)
+		}
+	case *SubscriptionCreatedEvent:
+		if h.SubscriptionCreated != nil {
+			return h.SubscriptionCreated(ctx, event, data)
+		}
+	case *SubscriptionUpdatedEvent:
+		if h.SubscriptionUpdated != nil {
+			return h.SubscriptionUpdated(ctx, event, data)
+		}
+	case *SubscriptionRenewedEvent:
+		if h.SubscriptionRenewed != nil {
+			return h.SubscriptionRenewed(ctx, event, data)
+		}
+	case *SubscriptionCanceledEvent:
+		if h.SubscriptionCanceled != nil {
+			return h.SubscriptionCanceled(ctx, event, data)
+		}
+	case *SubscriptionPausedEvent:
+		if h.SubscriptionPaused != nil {
+			return h.SubscriptionPaused(ctx, event, data)
+		}
+	case *SubscriptionRenewalSucceededEvent:
+		if h.SubscriptionRenewalSucceeded != nil {
+			return h.SubscriptionRenewalSucceeded(ctx, event, data)
+		}
+	case *SubscriptionRenewalFailedEvent:
+		if h.SubscriptionRenewalFailed != nil {
+			return h.SubscriptionRenewalFailed(ctx, event, data)
+		}
+	case *ConnectionStatusChangedEvent:
+		if h.ConnectionStatusChanged != nil {
+			return h.ConnectionStatusChanged(ctx, event, data)
+		}
+	case *ConnectionCredentialsExpiringEvent:
+		if h.ConnectionCredentialsExpiring != nil {
+			return h.ConnectionCredentialsExpiring(ctx, event, data)
+		}
+	case *FraudBlockedEvent:
+		if h.FraudBlocked != nil {
+			return h.FraudBlocked(ctx, event, data)
+		}
+	case *FraudReviewRequiredEvent:
+		if h.FraudReviewRequired != nil {
+			return h.FraudReviewRequired(ctx, event, data)
+		}
+	case *PolicyUpdatedEvent:
+		if h.PolicyUpdated != nil {
+			return h.PolicyUpdated(ctx, event, data)
+		}
+	case *CustomerRedactedEvent:
+		if h.CustomerRedacted != nil {
+			return h.CustomerRedacted(ctx, event, data)
+		}
+	case *CheckoutSessionViewedEvent:
+		if h.CheckoutSessionViewed != nil {
+			return h.CheckoutSessionViewed(ctx, event, data)
+		}
+	case *CheckoutSessionMethodSelectedEvent:
+		if h.CheckoutSessionMethodSelected != nil {
+			return h.CheckoutSessionMethodSelected(ctx, event, data)
+		}
+	case *CheckoutSessionAbandonedEvent:
+		if h.CheckoutSessionAbandoned != nil {
+			return h.CheckoutSessionAbandoned(ctx, event, data)
+		}
+	case *TransferScheduleExecutedEvent:
+		if h.TransferScheduleExecuted != nil {
+			return h.TransferScheduleExecuted(ctx, event, data)
+		}
+	case *TransferScheduleExecutionFailedEvent:
+		if h.TransferScheduleExecutionFailed != nil {
+			return h.TransferScheduleExecutionFailed(ctx, event, data)
+		}
+	default:
+		if event.Type == EventWebhookTest && h.WebhookTest != nil {
+			return h.WebhookTest(ctx, event)
+		}
+	}
+
+	if h.Default != nil {
+		return h.Default(ctx, event)
+	}
+	return nil
+}
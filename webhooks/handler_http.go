@@ -0,0 +1,139 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+type handler struct {
+	verify     func(r *http.Request, body []byte) bool
+	dispatcher Dispatcher
+}
+
+// NewHandler returns an http.Handler that verifies the X-Reevit-Signature header,
+// parses the event and passes it to the dispatcher.
+//
+// It replies 405 for non-POST requests, 401 for invalid signatures, 400 for malformed
+// bodies and 500 when the dispatcher returns an error so that Reevit retries the delivery.
+//
+// Batched deliveries (a JSON array of events) are verified once and dispatched event by event.
+// The response lists acknowledged and failed event IDs; it is 200 when every event succeeded,
+// 207 when only some did so that Reevit redelivers just the failed events, and 500 when none did.
+func NewHandler(secret string, dispatcher Dispatcher) http.Handler {
+	return &handler{
+		verify: func(r *http.Request, body []byte) bool {
+			return VerifySignature(body, r.Header.Get(SignatureHeader), secret)
+		},
+		dispatcher: dispatcher,
+	}
+}
+
+// NewJWSHandler is NewHandler for endpoints in JWS mode: deliveries are verified with
+// VerifyJWS against keys, usually NewJWKS(PlatformJWKSURL), instead of a shared secret.
+func NewJWSHandler(keys KeyResolver, dispatcher Dispatcher) http.Handler {
+	return &handler{
+		verify: func(r *http.Request, body []byte) bool {
+			return VerifyJWS(r.Context(), body, r.Header.Get(JWSHeader), keys) == nil
+		},
+		dispatcher: dispatcher,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if !h.verify(r, body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	if IsBatch(body) {
+		h.serveBatch(w, r, body)
+		return
+	}
+
+	event, err := ParseEvent(body)
+	if err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	if h.dispatcher != nil {
+		if err := h.dispatcher.Dispatch(r.Context(), event); err != nil {
+			http.Error(w, "event handler failed", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]bool{"received": true})
+}
+
+type batchResponse struct {
+	Received []string      `json:"received"`
+	Failed   []batchFailed `json:"failed,omitempty"`
+}
+
+type batchFailed struct {
+	ID    string `json:"id,omitempty"`
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+func (h *handler) serveBatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	events, parseErr := ParseEvents(body)
+	if events == nil {
+		http.Error(w, "invalid event batch", http.StatusBadRequest)
+		return
+	}
+
+	var failures []EventFailure
+	var batchErr *BatchError
+	if errors.As(parseErr, &batchErr) {
+		failures = append(failures, batchErr.Failures...)
+	}
+	if h.dispatcher != nil {
+		if errors.As(DispatchAll(r.Context(), h.dispatcher, events), &batchErr) {
+			failures = append(failures, batchErr.Failures...)
+		}
+	}
+
+	response := batchResponse{Received: []string{}}
+	failed := make(map[int]bool, len(failures))
+	for _, failure := range failures {
+		failed[failure.Index] = true
+		response.Failed = append(response.Failed, batchFailed{ID: failure.EventID, Index: failure.Index, Error: failure.Err.Error()})
+	}
+	for i, event := range events {
+		if event != nil && !failed[i] {
+			response.Received = append(response.Received, event.ID)
+		}
+	}
+
+	status := http.StatusOK
+	switch {
+	case len(failures) > 0 && len(response.Received) == 0:
+		status = http.StatusInternalServerError
+	case len(failures) > 0:
+		status = http.StatusMultiStatus
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,94 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"foo":"bar"}`)
+	signature := Sign(body, "secret")
+	require.Equal(t, "sha256=3f3ab3986b656abb17af3eb1443ed6c08ef8fff9fea83915909d1b421aec89be", signature)
+	require.True(t, VerifySignature(body, signature, "secret"))
+	require.False(t, VerifySignature(body, signature, "other"))
+	require.False(t, VerifySignature(body, "3f3ab3986b656abb17af3eb1443ed6c08ef8fff9fea83915909d1b421aec89be", "secret"))
+	require.False(t, VerifySignature(body, signature, ""))
+}
+
+func TestHandler(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","data":{"id":"pay_1","status":"succeeded"}}`)
+
+	var received string
+	handler := NewHandler("secret", HandlerFuncs{
+		PaymentSucceeded: func(ctx context.Context, event *Event, data *PaymentSucceededEvent) error {
+			received = data.ID
+			return nil
+		},
+	})
+
+	serve := func(method string, body []byte, signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/webhooks/reevit", bytes.NewReader(body))
+		req.Header.Set(SignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPost, body, Sign(body, "secret"))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "pay_1", received)
+
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, body, Sign(body, "wrong")).Code)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, nil, "").Code)
+
+	invalid := []byte(`not json`)
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPost, invalid, Sign(invalid, "secret")).Code)
+
+	failing := NewHandler("secret", HandlerFuncs{
+		Default: func(ctx context.Context, event *Event) error { return errors.New("boom") },
+	})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, Sign(body, "secret"))
+	rec = httptest.NewRecorder()
+	failing.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHandlerBatch(t *testing.T) {
+	body := []byte(`[
+		{"id":"evt_1","type":"payment.succeeded","data":{"id":"pay_1"}},
+		{"id":"evt_2","type":"payment.succeeded","data":{"id":"pay_2"}},
+		{"id":"evt_3","type":"payment.succeeded","data":{"amount":"bad"}}
+	]`)
+
+	handler := NewHandler("secret", HandlerFuncs{
+		PaymentSucceeded: func(ctx context.Context, event *Event, data *PaymentSucceededEvent) error {
+			if data.ID == "pay_2" {
+				return errors.New("database unavailable")
+			}
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, Sign(body, "secret"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusMultiStatus, rec.Code)
+	var response batchResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, []string{"evt_1"}, response.Received)
+	require.Len(t, response.Failed, 2)
+	require.Equal(t, "evt_3", response.Failed[0].ID)
+	require.Equal(t, "evt_2", response.Failed[1].ID)
+}
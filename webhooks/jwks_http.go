@@ -0,0 +1,133 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJWKSTTL = time.Hour
+	// minJWKSRefresh limits refetches triggered by unknown key IDs.
+	minJWKSRefresh = time.Minute
+)
+
+// JWKS is a KeyResolver backed by a remote JSON Web Key Set. Keys are cached for TTL and
+// refetched early when an unknown key ID is seen, so partner key rotation is picked up.
+// Concurrent lookups share a single fetch, and cached keys stay available while it runs.
+type JWKS struct {
+	URL        string
+	HTTPClient *http.Client
+	TTL        time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	inflight  *jwksFetch
+}
+
+// jwksFetch is a key set fetch in progress; err is set before done is closed.
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// NewJWKS returns a JWKS resolver for the key set published at url.
+func NewJWKS(url string) *JWKS {
+	return &JWKS{URL: url, HTTPClient: &http.Client{Timeout: 10 * time.Second}, TTL: defaultJWKSTTL}
+}
+
+// ResolveKey returns the key with the given ID, fetching the key set when needed.
+func (j *JWKS) ResolveKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	ttl := j.TTL
+	if ttl <= 0 {
+		ttl = defaultJWKSTTL
+	}
+	age := time.Since(j.fetchedAt)
+	key, ok := j.keys[kid]
+	if ok && age < ttl {
+		j.mu.Unlock()
+		return key, nil
+	}
+	if j.keys != nil && age < ttl && age < minJWKSRefresh {
+		j.mu.Unlock()
+		return nil, fmt.Errorf("webhooks: unknown key ID %q", kid)
+	}
+
+	// Fetch without holding the lock so that a slow JWKS endpoint does not block lookups of
+	// cached keys; lookups that need the fetch wait for the one already running.
+	fetch := j.inflight
+	owner := fetch == nil
+	if owner {
+		fetch = &jwksFetch{done: make(chan struct{})}
+		j.inflight = fetch
+	}
+	j.mu.Unlock()
+
+	if owner {
+		keys, err := j.fetch(ctx)
+		j.mu.Lock()
+		if err == nil {
+			j.keys = keys
+			j.fetchedAt = time.Now()
+		}
+		j.inflight = nil
+		j.mu.Unlock()
+		fetch.err = err
+		close(fetch.done)
+	} else {
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if fetch.err != nil {
+		if ok {
+			// Serve the stale key rather than failing verification on a transient fetch error.
+			return key, nil
+		}
+		return nil, fetch.err
+	}
+
+	j.mu.Lock()
+	key, ok = j.keys[kid]
+	j.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("webhooks: unknown key ID %q", kid)
+	}
+	return key, nil
+}
+
+func (j *JWKS) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := j.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webhooks: fetching JWKS failed with status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJWKS(body)
+}
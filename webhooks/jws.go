@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Organizations whose security policy forbids shared secrets can switch their endpoints to
+// JWS mode. Reevit then signs every delivery with a detached JWS over the raw body, sent in
+// JWSHeader, using a key published in the platform JWKS. The protected header carries the
+// signing time as "iat", which VerifyJWS checks to reject replayed deliveries.
+
+// JWSHeader is the header carrying the detached JWS of webhooks delivered in JWS mode.
+const JWSHeader = "X-Reevit-JWS"
+
+// PlatformJWKSURL is where Reevit publishes the keys that sign JWS-mode webhooks.
+const PlatformJWKSURL = "https://api.reevit.io/.well-known/jwks.json"
+
+// DefaultJWSTolerance is the maximum age of a JWS-mode delivery accepted by VerifyJWS.
+const DefaultJWSTolerance = 5 * time.Minute
+
+// ErrStaleJWS is returned by VerifyJWS for a delivery signed too long ago, or without a
+// signing time.
+var ErrStaleJWS = errors.New("webhooks: JWS signing time is missing or outside the tolerance")
+
+// VerifyJWS verifies a JWS-mode webhook: jws is the value of JWSHeader, body the raw
+// request body and keys usually NewJWKS(PlatformJWKSURL). It also checks that the
+// delivery was signed within DefaultJWSTolerance of now, in either direction.
+func VerifyJWS(ctx context.Context, body []byte, jws string, keys KeyResolver) error {
+	header, err := verifyDetachedJWS(ctx, body, jws, keys)
+	if err != nil {
+		return err
+	}
+	if header.Iat == 0 {
+		return ErrStaleJWS
+	}
+	age := time.Since(time.Unix(header.Iat, 0))
+	if age > DefaultJWSTolerance || age < -DefaultJWSTolerance {
+		return ErrStaleJWS
+	}
+	return nil
+}
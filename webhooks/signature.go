@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"strings"
+)
+
+// SignatureHeader is the header carrying the signature of Reevit outbound webhooks.
+const SignatureHeader = "X-Reevit-Signature"
+
+const signaturePrefix = "sha256="
+
+// Sign returns the X-Reevit-Signature header value for a body ("sha256=<hex HMAC-SHA256>").
+func Sign(body []byte, secret string) string {
+	signature := signHex(body, secret, sha256.New)
+	if signature == "" {
+		return ""
+	}
+	return signaturePrefix + signature
+}
+
+// VerifySignature reports whether signature is a valid X-Reevit-Signature for the raw body.
+func VerifySignature(body []byte, signature, secret string) bool {
+	signature = strings.TrimSpace(signature)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	expected := Sign(body, secret)
+	if expected == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
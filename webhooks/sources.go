@@ -0,0 +1,149 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+// Providers with published webhook source addresses.
+const (
+	// ProviderReevit is Reevit's live environment.
+	ProviderReevit = "reevit"
+	// ProviderReevitSandbox is Reevit's sandbox environment, which sends from its own ranges.
+	ProviderReevitSandbox = "reevit_sandbox"
+	ProviderPaystack      = "paystack"
+	ProviderFlutterwave   = "flutterwave"
+	ProviderHubtel        = "hubtel"
+	ProviderStripe        = "stripe"
+)
+
+// defaultSources lists the webhook source addresses published by each provider.
+var defaultSources = map[string][]string{
+	ProviderReevit: {
+		"34.89.112.16/28",
+		"35.246.48.64/28",
+	},
+	ProviderReevitSandbox: {
+		"34.105.176.32/28",
+	},
+	ProviderPaystack: {
+		"52.31.139.75/32",
+		"52.49.173.169/32",
+		"52.214.14.220/32",
+	},
+	ProviderFlutterwave: {
+		"18.135.114.215/32",
+		"18.169.14.86/32",
+		"35.177.122.147/32",
+	},
+	ProviderHubtel: {
+		"41.189.178.36/32",
+		"41.189.178.37/32",
+		"154.160.2.194/32",
+	},
+	ProviderStripe: {
+		"3.18.12.63/32",
+		"3.130.192.231/32",
+		"13.235.14.237/32",
+		"13.235.122.149/32",
+		"18.211.135.69/32",
+		"35.154.171.200/32",
+		"52.15.183.38/32",
+		"54.88.130.119/32",
+		"54.88.130.237/32",
+		"54.187.174.169/32",
+		"54.187.205.235/32",
+		"54.187.216.72/32",
+	},
+}
+
+var (
+	defaultAllowlistOnce sync.Once
+	defaultAllowlist     *Allowlist
+	defaultAllowlistErr  error
+)
+
+// Allowlist holds the trusted source ranges of each provider. It is safe for concurrent use.
+type Allowlist struct {
+	mu     sync.RWMutex
+	ranges map[string][]netip.Prefix
+}
+
+// NewAllowlist returns an empty allowlist.
+func NewAllowlist() *Allowlist {
+	return &Allowlist{ranges: map[string][]netip.Prefix{}}
+}
+
+// DefaultAllowlist returns a new allowlist populated with the ranges shipped with the SDK.
+func DefaultAllowlist() (*Allowlist, error) {
+	allowlist := NewAllowlist()
+	for provider, cidrs := range defaultSources {
+		if err := allowlist.Add(provider, cidrs...); err != nil {
+			return nil, err
+		}
+	}
+	return allowlist, nil
+}
+
+// Add trusts the given CIDR ranges (or single addresses) for a provider.
+func (a *Allowlist) Add(provider string, cidrs ...string) error {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(cidr)
+			if addrErr != nil {
+				return fmt.Errorf("webhooks: invalid source range %q: %w", cidr, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.ranges[provider] = append(a.ranges[provider], prefixes...)
+	return nil
+}
+
+// HasRanges reports whether any ranges are configured for a provider.
+func (a *Allowlist) HasRanges(provider string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.ranges[strings.ToLower(strings.TrimSpace(provider))]) > 0
+}
+
+// Contains reports whether ip belongs to one of the provider's trusted ranges.
+func (a *Allowlist) Contains(ip, provider string) bool {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, prefix := range a.ranges[strings.ToLower(strings.TrimSpace(provider))] {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTrustedSource reports whether ip is a published webhook source of the provider,
+// using the ranges shipped with the SDK. Use ProviderReevitSandbox for callbacks from
+// Reevit's sandbox.
+func IsTrustedSource(ip, provider string) bool {
+	defaultAllowlistOnce.Do(func() {
+		defaultAllowlist, defaultAllowlistErr = DefaultAllowlist()
+	})
+	if defaultAllowlistErr != nil {
+		return false
+	}
+	return defaultAllowlist.Contains(ip, provider)
+}
@@ -0,0 +1,82 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// SourceFilter rejects callbacks that do not originate from a provider's trusted ranges.
+// It is meant to run before signature verification so spoofed traffic is dropped cheaply.
+type SourceFilter struct {
+	Provider string
+	// Allowlist defaults to DefaultAllowlist when nil.
+	Allowlist *Allowlist
+	// TrustedProxies are load balancers whose X-Forwarded-For header is honoured.
+	// When the direct peer is a trusted proxy, the right-most untrusted forwarded address is checked.
+	TrustedProxies []netip.Prefix
+}
+
+// Middleware wraps next, responding 403 Forbidden to requests from untrusted sources.
+// It panics if no ranges are configured for the provider, or the default ranges cannot be
+// parsed, so a misconfiguration fails at startup instead of silently rejecting every callback.
+func (f SourceFilter) Middleware(next http.Handler) http.Handler {
+	allowlist := f.Allowlist
+	if allowlist == nil {
+		var err error
+		if allowlist, err = DefaultAllowlist(); err != nil {
+			panic(err)
+		}
+	}
+	if !allowlist.HasRanges(f.Provider) {
+		panic(fmt.Sprintf("webhooks: no trusted source ranges configured for provider %q", f.Provider))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowlist.Contains(f.sourceIP(r), f.Provider) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f SourceFilter) sourceIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !f.isTrustedProxy(host) {
+		return host
+	}
+
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		candidate := strings.TrimSpace(forwarded[i])
+		if candidate == "" {
+			continue
+		}
+		if !f.isTrustedProxy(candidate) {
+			return candidate
+		}
+	}
+	return host
+}
+
+func (f SourceFilter) isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range f.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
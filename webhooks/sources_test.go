@@ -0,0 +1,64 @@
+//go:build !tinygo
+
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTrustedSource(t *testing.T) {
+	require.True(t, IsTrustedSource("52.31.139.75", ProviderPaystack))
+	require.False(t, IsTrustedSource("52.31.139.76", ProviderPaystack))
+	require.False(t, IsTrustedSource("52.31.139.75", ProviderStripe))
+	require.False(t, IsTrustedSource("not-an-ip", ProviderPaystack))
+
+	for _, provider := range []string{ProviderReevit, ProviderReevitSandbox, ProviderFlutterwave, ProviderHubtel} {
+		require.NotEmpty(t, defaultSources[provider], provider)
+		prefix := netip.MustParsePrefix(defaultSources[provider][0])
+		require.True(t, IsTrustedSource(prefix.Addr().String(), provider), provider)
+	}
+	require.False(t, IsTrustedSource("34.105.176.33", ProviderReevit), "sandbox ranges are not trusted for live")
+
+	allowlist, err := DefaultAllowlist()
+	require.NoError(t, err)
+	require.True(t, allowlist.HasRanges(ProviderHubtel))
+}
+
+func TestSourceFilterMiddleware(t *testing.T) {
+	allowlist := NewAllowlist()
+	require.NoError(t, allowlist.Add(ProviderReevit, "203.0.113.0/24"))
+
+	filter := SourceFilter{
+		Provider:       ProviderReevit,
+		Allowlist:      allowlist,
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	}
+	handler := filter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, serve("203.0.113.7:443", ""))
+	require.Equal(t, http.StatusForbidden, serve("198.51.100.1:443", ""))
+	require.Equal(t, http.StatusOK, serve("10.1.2.3:443", "198.51.100.1, 203.0.113.7"))
+	require.Equal(t, http.StatusForbidden, serve("10.1.2.3:443", "203.0.113.7, 198.51.100.1"))
+	// Forwarded headers from untrusted peers are ignored.
+	require.Equal(t, http.StatusForbidden, serve("198.51.100.1:443", "203.0.113.7"))
+
+	require.Panics(t, func() { SourceFilter{Provider: "acme"}.Middleware(handler) })
+}
@@ -0,0 +1,151 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// API versions of webhook payloads. The typed event structs in this package always
+// describe LatestAPIVersion; payloads sent with an older api_version are decoded with
+// that version's struct and up-converted.
+const (
+	APIVersion20240601 = "2024-06-01"
+	LatestAPIVersion   = "2025-01-01"
+)
+
+// PayloadDecoder decodes the data of an event sent with a specific api_version and
+// returns the latest typed payload for the event type.
+type PayloadDecoder func(data json.RawMessage) (interface{}, error)
+
+var (
+	versionDecodersMu sync.RWMutex
+	versionDecoders   = map[string]map[string]PayloadDecoder{}
+)
+
+// RegisterPayloadDecoder registers a decoder for an event type delivered with an older api_version.
+// It allows handlers to keep working against the latest structs when an endpoint is pinned to an
+// older version. Registering the same version and type twice replaces the previous decoder.
+func RegisterPayloadDecoder(apiVersion, eventType string, decode PayloadDecoder) {
+	versionDecodersMu.Lock()
+	defer versionDecodersMu.Unlock()
+	if versionDecoders[apiVersion] == nil {
+		versionDecoders[apiVersion] = map[string]PayloadDecoder{}
+	}
+	versionDecoders[apiVersion][eventType] = decode
+}
+
+func lookupPayloadDecoder(apiVersion, eventType string) (PayloadDecoder, bool) {
+	versionDecodersMu.RLock()
+	defer versionDecodersMu.RUnlock()
+	decode, ok := versionDecoders[apiVersion][eventType]
+	return decode, ok
+}
+
+// PaymentV20240601 is the payment payload shape of api_version 2024-06-01.
+type PaymentV20240601 struct {
+	PaymentID    string                 `json:"payment_id"`
+	Status       string                 `json:"status"`
+	Amount       int64                  `json:"amount"`
+	Currency     string                 `json:"currency"`
+	Method       string                 `json:"method"`
+	PSP          string                 `json:"psp"`
+	ConnectionID string                 `json:"connection_id"`
+	CustomerID   string                 `json:"customer_id"`
+	Reference    string                 `json:"reference"`
+	Metadata     map[string]interface{} `json:"metadata"`
+	ErrorCode    string                 `json:"error_code"`
+	ErrorMessage string                 `json:"error_message"`
+}
+
+// Upgrade converts the payload into the latest Payment struct.
+func (p PaymentV20240601) Upgrade() Payment {
+	return Payment{
+		ID:           p.PaymentID,
+		Status:       p.Status,
+		Amount:       p.Amount,
+		Currency:     p.Currency,
+		Method:       p.Method,
+		Provider:     p.PSP,
+		ConnectionID: p.ConnectionID,
+		CustomerID:   p.CustomerID,
+		Reference:    p.Reference,
+		Metadata:     p.Metadata,
+	}
+}
+
+// SubscriptionV20240601 is the subscription payload shape of api_version 2024-06-01.
+type SubscriptionV20240601 struct {
+	SubscriptionID string                 `json:"subscription_id"`
+	CustomerID     string                 `json:"customer_id"`
+	PlanID         string                 `json:"plan_id"`
+	Status         string                 `json:"status"`
+	Amount         int64                  `json:"amount"`
+	Currency       string                 `json:"currency"`
+	Interval       string                 `json:"interval"`
+	NextBillingAt  string                 `json:"next_billing_at"`
+	Metadata       map[string]interface{} `json:"metadata"`
+}
+
+// Upgrade converts the payload into the latest Subscription struct.
+func (s SubscriptionV20240601) Upgrade() Subscription {
+	return Subscription{
+		ID:            s.SubscriptionID,
+		CustomerID:    s.CustomerID,
+		PlanID:        s.PlanID,
+		Status:        s.Status,
+		Amount:        s.Amount,
+		Currency:      s.Currency,
+		Interval:      s.Interval,
+		NextRenewalAt: s.NextBillingAt,
+		Metadata:      s.Metadata,
+	}
+}
+
+func decodeV20240601Payment(data json.RawMessage) (PaymentV20240601, error) {
+	var legacy PaymentV20240601
+	err := json.Unmarshal(data, &legacy)
+	return legacy, err
+}
+
+func decodeV20240601Subscription(data json.RawMessage) (Subscription, error) {
+	var legacy SubscriptionV20240601
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return Subscription{}, err
+	}
+	return legacy.Upgrade(), nil
+}
+
+func init() {
+	RegisterPayloadDecoder(APIVersion20240601, EventPaymentSucceeded, func(data json.RawMessage) (interface{}, error) {
+		legacy, err := decodeV20240601Payment(data)
+		return &PaymentSucceededEvent{Payment: legacy.Upgrade()}, err
+	})
+	RegisterPayloadDecoder(APIVersion20240601, EventPaymentFailed, func(data json.RawMessage) (interface{}, error) {
+		legacy, err := decodeV20240601Payment(data)
+		return &PaymentFailedEvent{
+			Payment:        legacy.Upgrade(),
+			FailureCode:    legacy.ErrorCode,
+			FailureMessage: legacy.ErrorMessage,
+		}, err
+	})
+	RegisterPayloadDecoder(APIVersion20240601, EventPaymentRefunded, func(data json.RawMessage) (interface{}, error) {
+		legacy, err := decodeV20240601Payment(data)
+		return &PaymentRefundedEvent{Payment: legacy.Upgrade()}, err
+	})
+	RegisterPayloadDecoder(APIVersion20240601, EventPaymentPending, func(data json.RawMessage) (interface{}, error) {
+		legacy, err := decodeV20240601Payment(data)
+		return &PaymentPendingEvent{Payment: legacy.Upgrade()}, err
+	})
+	RegisterPayloadDecoder(APIVersion20240601, EventSubscriptionCreated, func(data json.RawMessage) (interface{}, error) {
+		subscription, err := decodeV20240601Subscription(data)
+		return &SubscriptionCreatedEvent{Subscription: subscription}, err
+	})
+	RegisterPayloadDecoder(APIVersion20240601, EventSubscriptionRenewed, func(data json.RawMessage) (interface{}, error) {
+		subscription, err := decodeV20240601Subscription(data)
+		return &SubscriptionRenewedEvent{Subscription: subscription}, err
+	})
+	RegisterPayloadDecoder(APIVersion20240601, EventSubscriptionCanceled, func(data json.RawMessage) (interface{}, error) {
+		subscription, err := decodeV20240601Subscription(data)
+		return &SubscriptionCanceledEvent{Subscription: subscription}, err
+	})
+}
@@ -2,7 +2,6 @@ package reevit
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -60,14 +59,14 @@ type WebhookEventListOptions struct {
 }
 
 // GetConfig fetches the current outbound webhook configuration.
-func (s *WebhooksService) GetConfig(ctx context.Context) (*WebhookConfig, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, "/v1/webhooks/config", nil)
+func (s *WebhooksService) GetConfig(ctx context.Context, opts ...RequestOption) (*WebhookConfig, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, "/v1/webhooks/config", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var config WebhookConfig
-	if err := s.client.do(ctx, httpRequest, &config); err != nil {
+	if err := s.client.do(httpRequest, &config); err != nil {
 		return nil, err
 	}
 
@@ -76,17 +75,13 @@ func (s *WebhooksService) GetConfig(ctx context.Context) (*WebhookConfig, error)
 
 // UpsertConfig creates or updates the outbound webhook configuration.
 func (s *WebhooksService) UpsertConfig(ctx context.Context, req *WebhookConfigRequest, opts ...RequestOption) (*WebhookConfig, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/webhooks/config", req)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/webhooks/config", req, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var config WebhookConfig
-	if err := s.client.do(ctx, httpRequest, &config); err != nil {
+	if err := s.client.do(httpRequest, &config); err != nil {
 		return nil, err
 	}
 
@@ -95,31 +90,23 @@ func (s *WebhooksService) UpsertConfig(ctx context.Context, req *WebhookConfigRe
 
 // DeleteConfig removes the outbound webhook configuration.
 func (s *WebhooksService) DeleteConfig(ctx context.Context, opts ...RequestOption) error {
-	httpRequest, err := s.client.newRequest(http.MethodDelete, "/v1/webhooks/config", nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodDelete, "/v1/webhooks/config", nil, opts...)
 	if err != nil {
 		return err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
-	return s.client.do(ctx, httpRequest, nil)
+	return s.client.do(httpRequest, nil)
 }
 
 // SendTest dispatches a test webhook.
 func (s *WebhooksService) SendTest(ctx context.Context, opts ...RequestOption) (map[string]interface{}, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, "/v1/webhooks/test", map[string]interface{}{})
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, "/v1/webhooks/test", map[string]interface{}{}, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var payload map[string]interface{}
-	if err := s.client.do(ctx, httpRequest, &payload); err != nil {
+	if err := s.client.do(httpRequest, &payload); err != nil {
 		return nil, err
 	}
 
@@ -127,37 +114,37 @@ func (s *WebhooksService) SendTest(ctx context.Context, opts ...RequestOption) (
 }
 
 // ListEvents returns recorded webhook events.
-func (s *WebhooksService) ListEvents(ctx context.Context, options ...WebhookEventListOptions) ([]WebhookEvent, error) {
+func (s *WebhooksService) ListEvents(ctx context.Context, options *WebhookEventListOptions, opts ...RequestOption) ([]WebhookEvent, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
-		setString(values, "type", options[0].Type)
-		setString(values, "status", options[0].Status)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
+		setString(values, "type", options.Type)
+		setString(values, "status", options.Status)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/webhooks/events", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/webhooks/events", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[WebhookEvent](raw, "events")
+	return decodeArrayResponse[WebhookEvent](s.client, raw, "events")
 }
 
 // GetEvent fetches a single webhook event.
-func (s *WebhooksService) GetEvent(ctx context.Context, eventID string) (*WebhookEvent, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/webhooks/events/%s", eventID), nil)
+func (s *WebhooksService) GetEvent(ctx context.Context, eventID string, opts ...RequestOption) (*WebhookEvent, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/webhooks/events/%s", eventID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var event WebhookEvent
-	if err := s.client.do(ctx, httpRequest, &event); err != nil {
+	if err := s.client.do(httpRequest, &event); err != nil {
 		return nil, err
 	}
 
@@ -166,17 +153,13 @@ func (s *WebhooksService) GetEvent(ctx context.Context, eventID string) (*Webhoo
 
 // ReplayEvent replays a recorded webhook event.
 func (s *WebhooksService) ReplayEvent(ctx context.Context, eventID string, opts ...RequestOption) (map[string]interface{}, error) {
-	httpRequest, err := s.client.newRequest(http.MethodPost, fmt.Sprintf("/v1/webhooks/events/%s/replay", eventID), map[string]interface{}{})
+	httpRequest, err := s.client.newRequest(ctx, http.MethodPost, pathf("/v1/webhooks/events/%s/replay", eventID), map[string]interface{}{}, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(httpRequest)
-	}
-
 	var payload map[string]interface{}
-	if err := s.client.do(ctx, httpRequest, &payload); err != nil {
+	if err := s.client.do(httpRequest, &payload); err != nil {
 		return nil, err
 	}
 
@@ -184,35 +167,35 @@ func (s *WebhooksService) ReplayEvent(ctx context.Context, eventID string, opts
 }
 
 // ListOutbound returns outbound deliveries.
-func (s *WebhooksService) ListOutbound(ctx context.Context, options ...PaginationOptions) ([]OutboundWebhook, error) {
+func (s *WebhooksService) ListOutbound(ctx context.Context, options *PaginationOptions, opts ...RequestOption) ([]OutboundWebhook, error) {
 	values := url.Values{}
-	if len(options) > 0 {
-		setInt(values, "limit", options[0].Limit)
-		setInt(values, "offset", options[0].Offset)
+	if options != nil {
+		setInt(values, "limit", options.Limit)
+		setInt(values, "offset", options.Offset)
 	}
 
-	httpRequest, err := s.client.newRequest(http.MethodGet, buildPath("/v1/webhooks/outbound", values), nil)
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, buildPath("/v1/webhooks/outbound", values), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	raw, err := s.client.doRaw(ctx, httpRequest)
+	raw, err := s.client.doRaw(httpRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeArrayResponse[OutboundWebhook](raw, "outbound")
+	return decodeArrayResponse[OutboundWebhook](s.client, raw, "outbound")
 }
 
 // GetOutbound fetches a single outbound delivery.
-func (s *WebhooksService) GetOutbound(ctx context.Context, outboundID string) (*OutboundWebhook, error) {
-	httpRequest, err := s.client.newRequest(http.MethodGet, fmt.Sprintf("/v1/webhooks/outbound/%s", outboundID), nil)
+func (s *WebhooksService) GetOutbound(ctx context.Context, outboundID string, opts ...RequestOption) (*OutboundWebhook, error) {
+	httpRequest, err := s.client.newRequest(ctx, http.MethodGet, pathf("/v1/webhooks/outbound/%s", outboundID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	var outbound OutboundWebhook
-	if err := s.client.do(ctx, httpRequest, &outbound); err != nil {
+	if err := s.client.do(httpRequest, &outbound); err != nil {
 		return nil, err
 	}
 